@@ -0,0 +1,431 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// generator scans const blocks of a package and generates enum code.
+type generator struct {
+	trimPrefix  string
+	lineComment bool
+	// args is the command line arguments written to the header of generated file.
+	args string
+}
+
+// enumType is an enum type and its constants.
+type enumType struct {
+	Name string
+	// Basic is the underlying basic type, eg: int, uint8, string.
+	Basic    string
+	IsString bool
+	Unsigned bool
+	// BitSize is the bit size of integer type, it's strconv.IntSize for int, uint and uintptr.
+	BitSize string
+	// OverflowInt64 is true if the value may overflow int64, which is the type of sql value.
+	OverflowInt64 bool
+	Values        []enumValue
+}
+
+// enumValue is a constant of enum type.
+type enumValue struct {
+	// Ident is the name of constant in source code.
+	Ident string
+	// Name is the name used by String, JSON and Registry.
+	Name string
+	// Param is the parameter name of the constant in switch helper.
+	Param string
+}
+
+// generate parses the package in dir and returns formatted source of enum code for types.
+// the file named skipFile is not parsed, it's the output file generated before.
+func (g *generator) generate(dir string, typeNames []string, skipFile string) ([]byte, error) {
+	fset := token.NewFileSet()
+	pkgName, files, err := parseDir(fset, dir, skipFile)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &types.Info{Defs: make(map[*ast.Ident]types.Object)}
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		// the package may be incomplete before generating, only constants are required.
+		Error: func(err error) {},
+	}
+	pkg, _ := conf.Check(pkgName, fset, files, info)
+
+	comments := lineComments(files)
+
+	needStrconv := false
+	enums := make([]*enumType, 0, len(typeNames))
+	for _, typeName := range typeNames {
+		e, err := g.collect(pkg, info, comments, strings.TrimSpace(typeName))
+		if err != nil {
+			return nil, err
+		}
+		enums = append(enums, e)
+		needStrconv = needStrconv || !e.IsString
+	}
+
+	var buf bytes.Buffer
+	err = fileTemplate.Execute(&buf, map[string]any{
+		"Args":        g.args,
+		"Package":     pkgName,
+		"Enums":       enums,
+		"NeedStrconv": needStrconv,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+
+	return src, nil
+}
+
+// collect finds constants of the type in declaration order.
+func (g *generator) collect(pkg *types.Package, info *types.Info, comments map[*ast.Ident]string, typeName string) (*enumType, error) {
+	obj := pkg.Scope().Lookup(typeName)
+	if obj == nil {
+		return nil, fmt.Errorf("type %s is not found", typeName)
+	}
+	if _, ok := obj.(*types.TypeName); !ok {
+		return nil, fmt.Errorf("%s is not a type", typeName)
+	}
+
+	basic, ok := obj.Type().Underlying().(*types.Basic)
+	if !ok || basic.Info()&(types.IsInteger|types.IsString) == 0 {
+		return nil, fmt.Errorf("underlying type of %s should be integer or string", typeName)
+	}
+
+	type constant struct {
+		ident *ast.Ident
+		value enumValue
+	}
+
+	var consts []constant
+	for ident, def := range info.Defs {
+		// constants declared in functions aren't visible to the generated code.
+		c, ok := def.(*types.Const)
+		if !ok || c.Parent() != pkg.Scope() || !types.Identical(c.Type(), obj.Type()) || ident.Name == "_" {
+			continue
+		}
+
+		name := strings.TrimPrefix(ident.Name, g.trimPrefix)
+		if comment, ok := comments[ident]; g.lineComment && ok {
+			name = comment
+		}
+
+		consts = append(consts, constant{ident: ident, value: enumValue{Ident: ident.Name, Name: name}})
+	}
+
+	if len(consts) == 0 {
+		return nil, fmt.Errorf("no constant of type %s is found", typeName)
+	}
+
+	sort.Slice(consts, func(i, j int) bool {
+		return consts[i].ident.Pos() < consts[j].ident.Pos()
+	})
+
+	e := &enumType{
+		Name:     typeName,
+		Basic:    basic.Name(),
+		IsString: basic.Info()&types.IsString != 0,
+		Unsigned: basic.Info()&types.IsUnsigned != 0,
+	}
+	switch basic.Kind() {
+	case types.Int8, types.Uint8:
+		e.BitSize = "8"
+	case types.Int16, types.Uint16:
+		e.BitSize = "16"
+	case types.Int32, types.Uint32:
+		e.BitSize = "32"
+	case types.Int64, types.Uint64:
+		e.BitSize = "64"
+	default:
+		e.BitSize = "strconv.IntSize"
+	}
+	switch basic.Kind() {
+	case types.Uint, types.Uint64, types.Uintptr:
+		e.OverflowInt64 = true
+	}
+
+	// constants with duplicate value are aliases, only the first one is used.
+	seenValues := make(map[string]bool)
+	seenParams := make(map[string]bool)
+	for _, c := range consts {
+		value := info.Defs[c.ident].(*types.Const).Val().ExactString()
+		if seenValues[value] {
+			continue
+		}
+		seenValues[value] = true
+
+		c.value.Param = paramName(c.value.Ident, seenParams)
+		e.Values = append(e.Values, c.value)
+	}
+
+	return e, nil
+}
+
+// parseDir parses non-test go files of the package in dir.
+func parseDir(fset *token.FileSet, dir string, skipFile string) (string, []*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var pkgName string
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == skipFile {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if pkgName == "" {
+			pkgName = file.Name.Name
+		} else if pkgName != file.Name.Name {
+			continue
+		}
+		files = append(files, file)
+	}
+
+	if len(files) == 0 {
+		return "", nil, errors.New("no go file is found in " + dir)
+	}
+
+	return pkgName, files, nil
+}
+
+// lineComments returns the line comment text of constants.
+func lineComments(files []*ast.File) map[*ast.Ident]string {
+	result := make(map[*ast.Ident]string)
+	for _, file := range files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			spec, ok := node.(*ast.ValueSpec)
+			if !ok || spec.Comment == nil {
+				return true
+			}
+			text := strings.TrimSpace(spec.Comment.Text())
+			if text == "" {
+				return true
+			}
+			for _, name := range spec.Names {
+				result[name] = text
+			}
+			return false
+		})
+	}
+	return result
+}
+
+// paramName converts the constant name to an unexported parameter name, eg: StatusActive -> onStatusActive.
+func paramName(ident string, seen map[string]bool) string {
+	runes := []rune(ident)
+	runes[0] = unicode.ToUpper(runes[0])
+
+	name := "on" + string(runes)
+	for i := 2; seen[name]; i++ {
+		name = fmt.Sprintf("on%s%d", string(runes), i)
+	}
+	seen[name] = true
+
+	return name
+}
+
+var fileTemplate = template.Must(template.New("enum").Parse(`// Code generated by "enumgen {{.Args}}"; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+{{- if .NeedStrconv}}
+	"strconv"
+{{- end}}
+
+	"github.com/duke-git/lancet/v2/enum"
+)
+{{range .Enums}}
+// {{.Name}}Registry is the enum registry of {{.Name}}.
+var {{.Name}}Registry = enum.NewRegistry[{{.Name}}](
+{{- range .Values}}
+	enum.NewItem({{.Ident}}, {{printf "%q" .Name}}),
+{{- end}}
+)
+
+// {{.Name}}Values returns all values of {{.Name}} in declaration order.
+func {{.Name}}Values() []{{.Name}} {
+	return []{{.Name}}{
+	{{- range .Values}}
+		{{.Ident}},
+	{{- end}}
+	}
+}
+
+// Parse{{.Name}} returns the {{.Name}} value of name.
+func Parse{{.Name}}(name string) ({{.Name}}, error) {
+	switch name {
+	{{- range .Values}}
+	case {{printf "%q" .Name}}:
+		return {{.Ident}}, nil
+	{{- end}}
+	}
+
+	var zero {{.Name}}
+	return zero, fmt.Errorf("invalid {{.Name}} name: %q", name)
+}
+
+// Switch{{.Name}} calls the function of value v and returns its result, every value of {{.Name}} must be handled.
+// it panics if v is not a valid {{.Name}}.
+func Switch{{.Name}}[R any](v {{.Name}}{{range .Values}}, {{.Param}} func() R{{end}}) R {
+	switch v {
+	{{- range .Values}}
+	case {{.Ident}}:
+		return {{.Param}}()
+	{{- end}}
+	}
+
+	panic(fmt.Sprintf("invalid {{.Name}}: %v", {{.Basic}}(v)))
+}
+
+// String returns the name of {{.Name}}.
+func (v {{.Name}}) String() string {
+	switch v {
+	{{- range .Values}}
+	case {{.Ident}}:
+		return {{printf "%q" .Name}}
+	{{- end}}
+	}
+
+	return fmt.Sprintf("{{.Name}}(%v)", {{.Basic}}(v))
+}
+
+// IsValid checks if v is a declared value of {{.Name}}.
+func (v {{.Name}}) IsValid() bool {
+	switch v {
+	case {{range $i, $v := .Values}}{{if $i}}, {{end}}{{$v.Ident}}{{end}}:
+		return true
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface, {{.Name}} is encoded as its name.
+func (v {{.Name}}) MarshalJSON() ([]byte, error) {
+	if !v.IsValid() {
+		return nil, fmt.Errorf("invalid {{.Name}}: %v", {{.Basic}}(v))
+	}
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, it accepts both name and value of {{.Name}}.
+func (v *{{.Name}}) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		return v.parse(name)
+	}
+
+	var value {{.Basic}}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if !{{.Name}}(value).IsValid() {
+		return fmt.Errorf("invalid {{.Name}}: %v", value)
+	}
+	*v = {{.Name}}(value)
+
+	return nil
+}
+
+// Scan implements the sql.Scanner interface, it accepts both name and value of {{.Name}}.
+func (v *{{.Name}}) Scan(src any) error {
+	switch s := src.(type) {
+	case string:
+		return v.parse(s)
+	case []byte:
+		return v.parse(string(s))
+{{- if not .IsString}}
+	case int64:
+		value := {{.Name}}(s)
+{{- if .Unsigned}}
+		if s < 0 || uint64(value) != uint64(s) || !value.IsValid() {
+{{- else}}
+		if int64(value) != s || !value.IsValid() {
+{{- end}}
+			return fmt.Errorf("invalid {{.Name}}: %v", s)
+		}
+		*v = value
+		return nil
+{{- end}}
+	}
+
+	return fmt.Errorf("can not scan %T into {{.Name}}", src)
+}
+
+// Value implements the driver.Valuer interface, {{.Name}} is stored as its value.
+func (v {{.Name}}) Value() (driver.Value, error) {
+	if !v.IsValid() {
+		return nil, fmt.Errorf("invalid {{.Name}}: %v", {{.Basic}}(v))
+	}
+{{- if .IsString}}
+	return string(v), nil
+{{- else}}
+{{- if .OverflowInt64}}
+	if uint64(v) > 1<<63-1 {
+		return nil, fmt.Errorf("{{.Name}} value %v overflows int64", uint64(v))
+	}
+{{- end}}
+	return int64(v), nil
+{{- end}}
+}
+
+// parse sets v by name, or by value in string form.
+func (v *{{.Name}}) parse(s string) error {
+	if value, err := Parse{{.Name}}(s); err == nil {
+		*v = value
+		return nil
+	}
+{{- if .IsString}}
+	if {{.Name}}(s).IsValid() {
+		*v = {{.Name}}(s)
+		return nil
+	}
+{{- else}}
+{{- if .Unsigned}}
+	if n, err := strconv.ParseUint(s, 10, {{.BitSize}}); err == nil && {{.Name}}(n).IsValid() {
+{{- else}}
+	if n, err := strconv.ParseInt(s, 10, {{.BitSize}}); err == nil && {{.Name}}(n).IsValid() {
+{{- end}}
+		*v = {{.Name}}(n)
+		return nil
+	}
+{{- end}}
+
+	return fmt.Errorf("invalid {{.Name}}: %q", s)
+}
+{{end}}`))
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestGenerate(t *testing.T) {
+	assert := internal.NewAssert(t, "TestGenerate")
+
+	dir := filepath.Join("internal", "example")
+
+	tests := []struct {
+		generator *generator
+		typeName  string
+		output    string
+	}{
+		{&generator{trimPrefix: "Status", args: "-type=Status -trimprefix=Status"}, "Status", "status_enum.go"},
+		{&generator{lineComment: true, args: "-type=Color -linecomment"}, "Color", "color_enum.go"},
+	}
+
+	for _, tt := range tests {
+		src, err := tt.generator.generate(dir, []string{tt.typeName}, tt.output)
+		assert.IsNil(err)
+
+		expected, err := os.ReadFile(filepath.Join(dir, tt.output))
+		assert.IsNil(err)
+
+		// the committed code should be regenerated by go generate after the generator is changed.
+		assert.Equal(string(expected), string(src))
+	}
+}
+
+func TestGenerate_Error(t *testing.T) {
+	assert := internal.NewAssert(t, "TestGenerate_Error")
+
+	dir := t.TempDir()
+	src := `package foo
+
+type Status int
+
+type Point struct{ X, Y int }
+
+type Empty string
+
+const Origin = 0
+
+const (
+	A Status = iota
+	B
+)
+
+func local() Status {
+	const Local Status = 7
+	return Local
+}
+`
+	assert.IsNil(os.WriteFile(filepath.Join(dir, "foo.go"), []byte(src), 0644))
+
+	g := &generator{}
+
+	_, err := g.generate(dir, []string{"NotExist"}, "")
+	assert.IsNotNil(err)
+
+	_, err = g.generate(dir, []string{"Point"}, "")
+	assert.IsNotNil(err)
+
+	_, err = g.generate(dir, []string{"Empty"}, "")
+	assert.IsNotNil(err)
+
+	_, err = g.generate(dir, []string{"Origin"}, "")
+	assert.IsNotNil(err)
+
+	_, err = g.generate(t.TempDir(), []string{"Status"}, "")
+	assert.IsNotNil(err)
+
+	out, err := g.generate(dir, []string{"Status"}, "")
+	assert.IsNil(err)
+	assert.Equal(true, strings.Contains(string(out), "func SwitchStatus[R any](v Status, onA func() R, onB func() R) R"))
+	// the constant declared in function is ignored.
+	assert.Equal(false, strings.Contains(string(out), "Local"))
+}
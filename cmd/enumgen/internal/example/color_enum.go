@@ -0,0 +1,141 @@
+// Code generated by "enumgen -type=Color -linecomment"; DO NOT EDIT.
+
+package example
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/duke-git/lancet/v2/enum"
+)
+
+// ColorRegistry is the enum registry of Color.
+var ColorRegistry = enum.NewRegistry[Color](
+	enum.NewItem(Red, "red"),
+	enum.NewItem(Green, "green"),
+	enum.NewItem(Blue, "blue"),
+)
+
+// ColorValues returns all values of Color in declaration order.
+func ColorValues() []Color {
+	return []Color{
+		Red,
+		Green,
+		Blue,
+	}
+}
+
+// ParseColor returns the Color value of name.
+func ParseColor(name string) (Color, error) {
+	switch name {
+	case "red":
+		return Red, nil
+	case "green":
+		return Green, nil
+	case "blue":
+		return Blue, nil
+	}
+
+	var zero Color
+	return zero, fmt.Errorf("invalid Color name: %q", name)
+}
+
+// SwitchColor calls the function of value v and returns its result, every value of Color must be handled.
+// it panics if v is not a valid Color.
+func SwitchColor[R any](v Color, onRed func() R, onGreen func() R, onBlue func() R) R {
+	switch v {
+	case Red:
+		return onRed()
+	case Green:
+		return onGreen()
+	case Blue:
+		return onBlue()
+	}
+
+	panic(fmt.Sprintf("invalid Color: %v", string(v)))
+}
+
+// String returns the name of Color.
+func (v Color) String() string {
+	switch v {
+	case Red:
+		return "red"
+	case Green:
+		return "green"
+	case Blue:
+		return "blue"
+	}
+
+	return fmt.Sprintf("Color(%v)", string(v))
+}
+
+// IsValid checks if v is a declared value of Color.
+func (v Color) IsValid() bool {
+	switch v {
+	case Red, Green, Blue:
+		return true
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface, Color is encoded as its name.
+func (v Color) MarshalJSON() ([]byte, error) {
+	if !v.IsValid() {
+		return nil, fmt.Errorf("invalid Color: %v", string(v))
+	}
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, it accepts both name and value of Color.
+func (v *Color) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		return v.parse(name)
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if !Color(value).IsValid() {
+		return fmt.Errorf("invalid Color: %v", value)
+	}
+	*v = Color(value)
+
+	return nil
+}
+
+// Scan implements the sql.Scanner interface, it accepts both name and value of Color.
+func (v *Color) Scan(src any) error {
+	switch s := src.(type) {
+	case string:
+		return v.parse(s)
+	case []byte:
+		return v.parse(string(s))
+	}
+
+	return fmt.Errorf("can not scan %T into Color", src)
+}
+
+// Value implements the driver.Valuer interface, Color is stored as its value.
+func (v Color) Value() (driver.Value, error) {
+	if !v.IsValid() {
+		return nil, fmt.Errorf("invalid Color: %v", string(v))
+	}
+	return string(v), nil
+}
+
+// parse sets v by name, or by value in string form.
+func (v *Color) parse(s string) error {
+	if value, err := ParseColor(s); err == nil {
+		*v = value
+		return nil
+	}
+	if Color(s).IsValid() {
+		*v = Color(s)
+		return nil
+	}
+
+	return fmt.Errorf("invalid Color: %q", s)
+}
@@ -0,0 +1,47 @@
+// Package example contains enum types used to test the code generated by enumgen.
+package example
+
+//go:generate go run ../.. -type=Status -trimprefix=Status
+//go:generate go run ../.. -type=Color -linecomment
+//go:generate go run ../.. -type=Level -trimprefix=Level
+//go:generate go run ../.. -type=Mask -trimprefix=Mask
+
+// Status is the status of an order.
+type Status int
+
+const (
+	StatusPending Status = iota + 1
+	StatusPaid
+	StatusShipped
+	StatusCanceled
+
+	// StatusDefault is an alias of StatusPending.
+	StatusDefault = StatusPending
+)
+
+// Color is a color in hex format.
+type Color string
+
+const (
+	Red   Color = "#ff0000" // red
+	Green Color = "#00ff00" // green
+	Blue  Color = "#0000ff" // blue
+)
+
+// Level is the level of a log entry.
+type Level uint8
+
+const (
+	LevelDebug Level = iota + 1
+	LevelInfo
+	LevelError
+)
+
+// Mask is a bit mask of permissions.
+type Mask uint64
+
+const (
+	MaskRead  Mask = 1
+	MaskWrite Mask = 2
+	MaskAdmin Mask = 1 << 63
+)
@@ -0,0 +1,179 @@
+package example
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+var (
+	_ json.Marshaler   = Status(0)
+	_ json.Unmarshaler = (*Status)(nil)
+	_ sql.Scanner      = (*Color)(nil)
+	_ driver.Valuer    = Color("")
+)
+
+func TestStatus(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStatus")
+
+	assert.Equal([]Status{StatusPending, StatusPaid, StatusShipped, StatusCanceled}, StatusValues())
+	assert.Equal("Paid", StatusPaid.String())
+	assert.Equal("Pending", StatusDefault.String())
+	assert.Equal("Status(9)", Status(9).String())
+	assert.Equal(true, StatusShipped.IsValid())
+	assert.Equal(false, Status(0).IsValid())
+
+	status, err := ParseStatus("Shipped")
+	assert.IsNil(err)
+	assert.Equal(StatusShipped, status)
+
+	_, err = ParseStatus("Unknown")
+	assert.IsNotNil(err)
+
+	item, ok := StatusRegistry.GetByValue(StatusCanceled)
+	assert.Equal(true, ok)
+	assert.Equal("Canceled", item.Name())
+	assert.Equal(4, StatusRegistry.Size())
+}
+
+func TestStatus_JSON(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStatus_JSON")
+
+	data, err := json.Marshal(map[string]Status{"status": StatusPaid})
+	assert.IsNil(err)
+	assert.Equal(`{"status":"Paid"}`, string(data))
+
+	_, err = json.Marshal(Status(9))
+	assert.IsNotNil(err)
+
+	var status Status
+	assert.IsNil(json.Unmarshal([]byte(`"Shipped"`), &status))
+	assert.Equal(StatusShipped, status)
+
+	assert.IsNil(json.Unmarshal([]byte(`1`), &status))
+	assert.Equal(StatusPending, status)
+
+	assert.IsNotNil(json.Unmarshal([]byte(`9`), &status))
+	assert.IsNotNil(json.Unmarshal([]byte(`"Unknown"`), &status))
+}
+
+func TestStatus_SQL(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStatus_SQL")
+
+	value, err := StatusPaid.Value()
+	assert.IsNil(err)
+	assert.Equal(int64(2), value)
+
+	_, err = Status(9).Value()
+	assert.IsNotNil(err)
+
+	var status Status
+	assert.IsNil(status.Scan(int64(3)))
+	assert.Equal(StatusShipped, status)
+
+	assert.IsNil(status.Scan([]byte("Canceled")))
+	assert.Equal(StatusCanceled, status)
+
+	assert.IsNil(status.Scan("2"))
+	assert.Equal(StatusPaid, status)
+
+	assert.IsNotNil(status.Scan(int64(9)))
+	assert.IsNotNil(status.Scan(1.5))
+}
+
+func TestSwitchStatus(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSwitchStatus")
+
+	isFinal := func(s Status) bool {
+		return SwitchStatus(s,
+			func() bool { return false },
+			func() bool { return false },
+			func() bool { return true },
+			func() bool { return true },
+		)
+	}
+
+	assert.Equal(false, isFinal(StatusPaid))
+	assert.Equal(true, isFinal(StatusCanceled))
+
+	defer func() {
+		assert.IsNotNil(recover())
+	}()
+	isFinal(Status(9))
+}
+
+func TestColor(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestColor")
+
+	assert.Equal("green", Green.String())
+	assert.Equal(`Color(#123456)`, Color("#123456").String())
+
+	data, err := json.Marshal(Blue)
+	assert.IsNil(err)
+	assert.Equal(`"blue"`, string(data))
+
+	var color Color
+	assert.IsNil(json.Unmarshal([]byte(`"red"`), &color))
+	assert.Equal(Red, color)
+
+	assert.IsNil(json.Unmarshal([]byte(`"#00ff00"`), &color))
+	assert.Equal(Green, color)
+
+	value, err := Blue.Value()
+	assert.IsNil(err)
+	assert.Equal("#0000ff", value)
+
+	assert.IsNil(color.Scan("#ff0000"))
+	assert.Equal(Red, color)
+	assert.IsNotNil(color.Scan(int64(1)))
+}
+
+func TestLevel_OutOfRange(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestLevel_OutOfRange")
+
+	var level Level
+	assert.IsNil(level.Scan(int64(2)))
+	assert.Equal(LevelInfo, level)
+	assert.IsNil(level.Scan("3"))
+	assert.Equal(LevelError, level)
+
+	// 257 and 258 are truncated to 1 and 2 by conversion, they must be rejected.
+	assert.IsNotNil(level.Scan(int64(257)))
+	assert.IsNotNil(level.Scan(int64(-255)))
+	assert.IsNotNil(level.Scan("258"))
+	assert.IsNotNil(level.Scan("-1"))
+	assert.Equal(LevelError, level)
+}
+
+func TestMask_Value(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestMask_Value")
+
+	value, err := MaskWrite.Value()
+	assert.IsNil(err)
+	assert.Equal(int64(2), value)
+
+	_, err = MaskAdmin.Value()
+	assert.IsNotNil(err)
+
+	var mask Mask
+	assert.IsNil(mask.Scan("9223372036854775808"))
+	assert.Equal(MaskAdmin, mask)
+	assert.IsNotNil(mask.Scan(int64(-9223372036854775808)))
+}
@@ -0,0 +1,149 @@
+// Code generated by "enumgen -type=Level -trimprefix=Level"; DO NOT EDIT.
+
+package example
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/duke-git/lancet/v2/enum"
+)
+
+// LevelRegistry is the enum registry of Level.
+var LevelRegistry = enum.NewRegistry[Level](
+	enum.NewItem(LevelDebug, "Debug"),
+	enum.NewItem(LevelInfo, "Info"),
+	enum.NewItem(LevelError, "Error"),
+)
+
+// LevelValues returns all values of Level in declaration order.
+func LevelValues() []Level {
+	return []Level{
+		LevelDebug,
+		LevelInfo,
+		LevelError,
+	}
+}
+
+// ParseLevel returns the Level value of name.
+func ParseLevel(name string) (Level, error) {
+	switch name {
+	case "Debug":
+		return LevelDebug, nil
+	case "Info":
+		return LevelInfo, nil
+	case "Error":
+		return LevelError, nil
+	}
+
+	var zero Level
+	return zero, fmt.Errorf("invalid Level name: %q", name)
+}
+
+// SwitchLevel calls the function of value v and returns its result, every value of Level must be handled.
+// it panics if v is not a valid Level.
+func SwitchLevel[R any](v Level, onLevelDebug func() R, onLevelInfo func() R, onLevelError func() R) R {
+	switch v {
+	case LevelDebug:
+		return onLevelDebug()
+	case LevelInfo:
+		return onLevelInfo()
+	case LevelError:
+		return onLevelError()
+	}
+
+	panic(fmt.Sprintf("invalid Level: %v", uint8(v)))
+}
+
+// String returns the name of Level.
+func (v Level) String() string {
+	switch v {
+	case LevelDebug:
+		return "Debug"
+	case LevelInfo:
+		return "Info"
+	case LevelError:
+		return "Error"
+	}
+
+	return fmt.Sprintf("Level(%v)", uint8(v))
+}
+
+// IsValid checks if v is a declared value of Level.
+func (v Level) IsValid() bool {
+	switch v {
+	case LevelDebug, LevelInfo, LevelError:
+		return true
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface, Level is encoded as its name.
+func (v Level) MarshalJSON() ([]byte, error) {
+	if !v.IsValid() {
+		return nil, fmt.Errorf("invalid Level: %v", uint8(v))
+	}
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, it accepts both name and value of Level.
+func (v *Level) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		return v.parse(name)
+	}
+
+	var value uint8
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if !Level(value).IsValid() {
+		return fmt.Errorf("invalid Level: %v", value)
+	}
+	*v = Level(value)
+
+	return nil
+}
+
+// Scan implements the sql.Scanner interface, it accepts both name and value of Level.
+func (v *Level) Scan(src any) error {
+	switch s := src.(type) {
+	case string:
+		return v.parse(s)
+	case []byte:
+		return v.parse(string(s))
+	case int64:
+		value := Level(s)
+		if s < 0 || uint64(value) != uint64(s) || !value.IsValid() {
+			return fmt.Errorf("invalid Level: %v", s)
+		}
+		*v = value
+		return nil
+	}
+
+	return fmt.Errorf("can not scan %T into Level", src)
+}
+
+// Value implements the driver.Valuer interface, Level is stored as its value.
+func (v Level) Value() (driver.Value, error) {
+	if !v.IsValid() {
+		return nil, fmt.Errorf("invalid Level: %v", uint8(v))
+	}
+	return int64(v), nil
+}
+
+// parse sets v by name, or by value in string form.
+func (v *Level) parse(s string) error {
+	if value, err := ParseLevel(s); err == nil {
+		*v = value
+		return nil
+	}
+	if n, err := strconv.ParseUint(s, 10, 8); err == nil && Level(n).IsValid() {
+		*v = Level(n)
+		return nil
+	}
+
+	return fmt.Errorf("invalid Level: %q", s)
+}
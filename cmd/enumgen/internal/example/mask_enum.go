@@ -0,0 +1,152 @@
+// Code generated by "enumgen -type=Mask -trimprefix=Mask"; DO NOT EDIT.
+
+package example
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/duke-git/lancet/v2/enum"
+)
+
+// MaskRegistry is the enum registry of Mask.
+var MaskRegistry = enum.NewRegistry[Mask](
+	enum.NewItem(MaskRead, "Read"),
+	enum.NewItem(MaskWrite, "Write"),
+	enum.NewItem(MaskAdmin, "Admin"),
+)
+
+// MaskValues returns all values of Mask in declaration order.
+func MaskValues() []Mask {
+	return []Mask{
+		MaskRead,
+		MaskWrite,
+		MaskAdmin,
+	}
+}
+
+// ParseMask returns the Mask value of name.
+func ParseMask(name string) (Mask, error) {
+	switch name {
+	case "Read":
+		return MaskRead, nil
+	case "Write":
+		return MaskWrite, nil
+	case "Admin":
+		return MaskAdmin, nil
+	}
+
+	var zero Mask
+	return zero, fmt.Errorf("invalid Mask name: %q", name)
+}
+
+// SwitchMask calls the function of value v and returns its result, every value of Mask must be handled.
+// it panics if v is not a valid Mask.
+func SwitchMask[R any](v Mask, onMaskRead func() R, onMaskWrite func() R, onMaskAdmin func() R) R {
+	switch v {
+	case MaskRead:
+		return onMaskRead()
+	case MaskWrite:
+		return onMaskWrite()
+	case MaskAdmin:
+		return onMaskAdmin()
+	}
+
+	panic(fmt.Sprintf("invalid Mask: %v", uint64(v)))
+}
+
+// String returns the name of Mask.
+func (v Mask) String() string {
+	switch v {
+	case MaskRead:
+		return "Read"
+	case MaskWrite:
+		return "Write"
+	case MaskAdmin:
+		return "Admin"
+	}
+
+	return fmt.Sprintf("Mask(%v)", uint64(v))
+}
+
+// IsValid checks if v is a declared value of Mask.
+func (v Mask) IsValid() bool {
+	switch v {
+	case MaskRead, MaskWrite, MaskAdmin:
+		return true
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface, Mask is encoded as its name.
+func (v Mask) MarshalJSON() ([]byte, error) {
+	if !v.IsValid() {
+		return nil, fmt.Errorf("invalid Mask: %v", uint64(v))
+	}
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, it accepts both name and value of Mask.
+func (v *Mask) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		return v.parse(name)
+	}
+
+	var value uint64
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if !Mask(value).IsValid() {
+		return fmt.Errorf("invalid Mask: %v", value)
+	}
+	*v = Mask(value)
+
+	return nil
+}
+
+// Scan implements the sql.Scanner interface, it accepts both name and value of Mask.
+func (v *Mask) Scan(src any) error {
+	switch s := src.(type) {
+	case string:
+		return v.parse(s)
+	case []byte:
+		return v.parse(string(s))
+	case int64:
+		value := Mask(s)
+		if s < 0 || uint64(value) != uint64(s) || !value.IsValid() {
+			return fmt.Errorf("invalid Mask: %v", s)
+		}
+		*v = value
+		return nil
+	}
+
+	return fmt.Errorf("can not scan %T into Mask", src)
+}
+
+// Value implements the driver.Valuer interface, Mask is stored as its value.
+func (v Mask) Value() (driver.Value, error) {
+	if !v.IsValid() {
+		return nil, fmt.Errorf("invalid Mask: %v", uint64(v))
+	}
+	if uint64(v) > 1<<63-1 {
+		return nil, fmt.Errorf("Mask value %v overflows int64", uint64(v))
+	}
+	return int64(v), nil
+}
+
+// parse sets v by name, or by value in string form.
+func (v *Mask) parse(s string) error {
+	if value, err := ParseMask(s); err == nil {
+		*v = value
+		return nil
+	}
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil && Mask(n).IsValid() {
+		*v = Mask(n)
+		return nil
+	}
+
+	return fmt.Errorf("invalid Mask: %q", s)
+}
@@ -0,0 +1,157 @@
+// Code generated by "enumgen -type=Status -trimprefix=Status"; DO NOT EDIT.
+
+package example
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/duke-git/lancet/v2/enum"
+)
+
+// StatusRegistry is the enum registry of Status.
+var StatusRegistry = enum.NewRegistry[Status](
+	enum.NewItem(StatusPending, "Pending"),
+	enum.NewItem(StatusPaid, "Paid"),
+	enum.NewItem(StatusShipped, "Shipped"),
+	enum.NewItem(StatusCanceled, "Canceled"),
+)
+
+// StatusValues returns all values of Status in declaration order.
+func StatusValues() []Status {
+	return []Status{
+		StatusPending,
+		StatusPaid,
+		StatusShipped,
+		StatusCanceled,
+	}
+}
+
+// ParseStatus returns the Status value of name.
+func ParseStatus(name string) (Status, error) {
+	switch name {
+	case "Pending":
+		return StatusPending, nil
+	case "Paid":
+		return StatusPaid, nil
+	case "Shipped":
+		return StatusShipped, nil
+	case "Canceled":
+		return StatusCanceled, nil
+	}
+
+	var zero Status
+	return zero, fmt.Errorf("invalid Status name: %q", name)
+}
+
+// SwitchStatus calls the function of value v and returns its result, every value of Status must be handled.
+// it panics if v is not a valid Status.
+func SwitchStatus[R any](v Status, onStatusPending func() R, onStatusPaid func() R, onStatusShipped func() R, onStatusCanceled func() R) R {
+	switch v {
+	case StatusPending:
+		return onStatusPending()
+	case StatusPaid:
+		return onStatusPaid()
+	case StatusShipped:
+		return onStatusShipped()
+	case StatusCanceled:
+		return onStatusCanceled()
+	}
+
+	panic(fmt.Sprintf("invalid Status: %v", int(v)))
+}
+
+// String returns the name of Status.
+func (v Status) String() string {
+	switch v {
+	case StatusPending:
+		return "Pending"
+	case StatusPaid:
+		return "Paid"
+	case StatusShipped:
+		return "Shipped"
+	case StatusCanceled:
+		return "Canceled"
+	}
+
+	return fmt.Sprintf("Status(%v)", int(v))
+}
+
+// IsValid checks if v is a declared value of Status.
+func (v Status) IsValid() bool {
+	switch v {
+	case StatusPending, StatusPaid, StatusShipped, StatusCanceled:
+		return true
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface, Status is encoded as its name.
+func (v Status) MarshalJSON() ([]byte, error) {
+	if !v.IsValid() {
+		return nil, fmt.Errorf("invalid Status: %v", int(v))
+	}
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, it accepts both name and value of Status.
+func (v *Status) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		return v.parse(name)
+	}
+
+	var value int
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if !Status(value).IsValid() {
+		return fmt.Errorf("invalid Status: %v", value)
+	}
+	*v = Status(value)
+
+	return nil
+}
+
+// Scan implements the sql.Scanner interface, it accepts both name and value of Status.
+func (v *Status) Scan(src any) error {
+	switch s := src.(type) {
+	case string:
+		return v.parse(s)
+	case []byte:
+		return v.parse(string(s))
+	case int64:
+		value := Status(s)
+		if int64(value) != s || !value.IsValid() {
+			return fmt.Errorf("invalid Status: %v", s)
+		}
+		*v = value
+		return nil
+	}
+
+	return fmt.Errorf("can not scan %T into Status", src)
+}
+
+// Value implements the driver.Valuer interface, Status is stored as its value.
+func (v Status) Value() (driver.Value, error) {
+	if !v.IsValid() {
+		return nil, fmt.Errorf("invalid Status: %v", int(v))
+	}
+	return int64(v), nil
+}
+
+// parse sets v by name, or by value in string form.
+func (v *Status) parse(s string) error {
+	if value, err := ParseStatus(s); err == nil {
+		*v = value
+		return nil
+	}
+	if n, err := strconv.ParseInt(s, 10, strconv.IntSize); err == nil && Status(n).IsValid() {
+		*v = Status(n)
+		return nil
+	}
+
+	return fmt.Errorf("invalid Status: %q", s)
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+// Enumgen generates enum.Registry and helper methods for enum types declared as const blocks.
+//
+// Given the name of an integer or string type T, enumgen creates a new file in the package directory
+// with the following declarations:
+//
+//	var TRegistry *enum.Registry[T]              // registry of all constants
+//	func TValues() []T                           // all constants in declaration order
+//	func ParseT(name string) (T, error)          // lookup constant by name
+//	func SwitchT[R any](v T, onA func() R, ...) R // exhaustive switch helper
+//	func (T) String() string
+//	func (T) IsValid() bool
+//	func (T) MarshalJSON() ([]byte, error)
+//	func (*T) UnmarshalJSON([]byte) error
+//	func (*T) Scan(any) error                    // sql.Scanner
+//	func (T) Value() (driver.Value, error)       // driver.Valuer
+//
+// Usage:
+//
+//	//go:generate go run github.com/duke-git/lancet/v2/cmd/enumgen -type=Status
+//
+// Flags:
+//
+//	-type        comma-separated list of type names, required
+//	-output      output file name, default is <dir>/<type>_enum.go
+//	-trimprefix  prefix to be trimmed from the constant names
+//	-linecomment use the line comment text as the name of constant
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	typeNames   = flag.String("type", "", "comma-separated list of type names, required")
+	output      = flag.String("output", "", "output file name, default is <dir>/<type>_enum.go")
+	trimPrefix  = flag.String("trimprefix", "", "prefix to be trimmed from the constant names")
+	lineComment = flag.Bool("linecomment", false, "use the line comment text as the name of constant")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of enumgen:\n")
+	fmt.Fprintf(os.Stderr, "\tenumgen [flags] -type T [directory]\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("enumgen: ")
+
+	flag.Usage = usage
+	flag.Parse()
+
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+
+	types := strings.Split(*typeNames, ",")
+
+	outputName := *output
+	if outputName == "" {
+		outputName = filepath.Join(dir, strings.ToLower(types[0])+"_enum.go")
+	}
+
+	g := &generator{
+		trimPrefix:  *trimPrefix,
+		lineComment: *lineComment,
+		args:        strings.Join(os.Args[1:], " "),
+	}
+
+	src, err := g.generate(dir, types, filepath.Base(outputName))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(outputName, src, 0644); err != nil {
+		log.Fatalf("writing output: %s", err)
+	}
+}
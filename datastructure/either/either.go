@@ -0,0 +1,160 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+// Package either implements Either type, which holds a value of one of two possible types.
+package either
+
+import (
+	"fmt"
+
+	"github.com/duke-git/lancet/v2/datastructure/result"
+)
+
+// Either is a type that holds either a Left value or a Right value.
+// by convention, Left holds a failure and Right holds a success.
+type Either[L any, R any] struct {
+	left    L
+	right   R
+	isRight bool
+}
+
+// Left returns an Either which holds the left value.
+func Left[L any, R any](value L) Either[L, R] {
+	return Either[L, R]{left: value}
+}
+
+// Right returns an Either which holds the right value.
+func Right[L any, R any](value R) Either[L, R] {
+	return Either[L, R]{right: value, isRight: true}
+}
+
+// FromResult returns a Right of the value if the Result is successful, otherwise returns a Left of the error.
+func FromResult[T any](r result.Result[T]) Either[error, T] {
+	value, err := r.Get()
+	if err != nil {
+		return Left[error, T](err)
+	}
+	return Right[error](value)
+}
+
+// IsLeft checks if the Either holds a left value.
+func (e Either[L, R]) IsLeft() bool {
+	return !e.isRight
+}
+
+// IsRight checks if the Either holds a right value.
+func (e Either[L, R]) IsRight() bool {
+	return e.isRight
+}
+
+// Left returns the left value and true if the Either holds a left value, otherwise returns zero value and false.
+func (e Either[L, R]) Left() (L, bool) {
+	return e.left, !e.isRight
+}
+
+// Right returns the right value and true if the Either holds a right value, otherwise returns zero value and false.
+func (e Either[L, R]) Right() (R, bool) {
+	return e.right, e.isRight
+}
+
+// LeftOrElse returns the left value if the Either holds a left value, otherwise returns other.
+func (e Either[L, R]) LeftOrElse(other L) L {
+	if e.isRight {
+		return other
+	}
+	return e.left
+}
+
+// RightOrElse returns the right value if the Either holds a right value, otherwise returns other.
+func (e Either[L, R]) RightOrElse(other R) R {
+	if e.isRight {
+		return e.right
+	}
+	return other
+}
+
+// Swap returns an Either whose left and right are swapped.
+func (e Either[L, R]) Swap() Either[R, L] {
+	if e.isRight {
+		return Left[R, L](e.right)
+	}
+	return Right[R](e.left)
+}
+
+// IfLeft performs the action with the left value if the Either holds a left value.
+func (e Either[L, R]) IfLeft(action func(value L)) {
+	if !e.isRight {
+		action(e.left)
+	}
+}
+
+// IfRight performs the action with the right value if the Either holds a right value.
+func (e Either[L, R]) IfRight(action func(value R)) {
+	if e.isRight {
+		action(e.right)
+	}
+}
+
+// String implements the fmt.Stringer interface.
+func (e Either[L, R]) String() string {
+	if e.isRight {
+		return fmt.Sprintf("Right(%v)", e.right)
+	}
+	return fmt.Sprintf("Left(%v)", e.left)
+}
+
+// Fold applies onLeft to the left value or onRight to the right value, and returns the result.
+func Fold[L any, R any, T any](e Either[L, R], onLeft func(value L) T, onRight func(value R) T) T {
+	if e.isRight {
+		return onRight(e.right)
+	}
+	return onLeft(e.left)
+}
+
+// MapRight applies the mapper to the right value if the Either holds a right value, otherwise returns the left value as it is.
+func MapRight[L any, R any, T any](e Either[L, R], mapper func(value R) T) Either[L, T] {
+	if e.isRight {
+		return Right[L](mapper(e.right))
+	}
+	return Left[L, T](e.left)
+}
+
+// MapLeft applies the mapper to the left value if the Either holds a left value, otherwise returns the right value as it is.
+func MapLeft[L any, R any, T any](e Either[L, R], mapper func(value L) T) Either[T, R] {
+	if e.isRight {
+		return Right[T](e.right)
+	}
+	return Left[T, R](mapper(e.left))
+}
+
+// FlatMap applies the mapper to the right value if the Either holds a right value and returns its result,
+// otherwise returns the left value as it is.
+func FlatMap[L any, R any, T any](e Either[L, R], mapper func(value R) Either[L, T]) Either[L, T] {
+	if e.isRight {
+		return mapper(e.right)
+	}
+	return Left[L, T](e.left)
+}
+
+// ToResult returns a successful Result of the right value if the Either holds a right value,
+// otherwise returns a failed Result of the left error.
+func ToResult[T any](e Either[error, T]) result.Result[T] {
+	if e.isRight {
+		return result.Ok(e.right)
+	}
+	return result.Err[T](e.left)
+}
+
+// Partition splits eithers into left values and right values.
+func Partition[L any, R any](eithers []Either[L, R]) ([]L, []R) {
+	lefts := []L{}
+	rights := []R{}
+	for _, e := range eithers {
+		if e.isRight {
+			rights = append(rights, e.right)
+		} else {
+			lefts = append(lefts, e.left)
+		}
+	}
+	return lefts, rights
+}
@@ -0,0 +1,104 @@
+package either
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/datastructure/result"
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestLeftRight(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestLeftRight")
+
+	left := Left[string, int]("foo")
+	assert.Equal(true, left.IsLeft())
+	assert.Equal(false, left.IsRight())
+	assert.Equal("Left(foo)", left.String())
+
+	l, ok := left.Left()
+	assert.Equal("foo", l)
+	assert.Equal(true, ok)
+
+	_, ok = left.Right()
+	assert.Equal(false, ok)
+	assert.Equal(1, left.RightOrElse(1))
+	assert.Equal("foo", left.LeftOrElse("bar"))
+
+	right := Right[string](42)
+	r, ok := right.Right()
+	assert.Equal(42, r)
+	assert.Equal(true, ok)
+	assert.Equal("bar", right.LeftOrElse("bar"))
+	assert.Equal("Right(42)", right.String())
+
+	swapped := right.Swap()
+	assert.Equal(true, swapped.IsLeft())
+	assert.Equal(42, swapped.LeftOrElse(0))
+
+	var visited []string
+	left.IfLeft(func(value string) { visited = append(visited, value) })
+	left.IfRight(func(value int) { visited = append(visited, "right") })
+	right.IfRight(func(value int) { visited = append(visited, strconv.Itoa(value)) })
+	assert.Equal([]string{"foo", "42"}, visited)
+}
+
+func TestFold_Map(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestFold_Map")
+
+	length := func(s string) int { return len(s) }
+	double := func(n int) int { return n * 2 }
+
+	assert.Equal(3, Fold(Left[string, int]("foo"), length, double))
+	assert.Equal(4, Fold(Right[string](2), length, double))
+
+	assert.Equal("2", MapRight(Right[string](2), strconv.Itoa).RightOrElse(""))
+	assert.Equal("foo", MapRight(Left[string, int]("foo"), strconv.Itoa).LeftOrElse(""))
+
+	assert.Equal(3, MapLeft(Left[string, int]("foo"), length).LeftOrElse(0))
+	assert.Equal(2, MapLeft(Right[string](2), length).RightOrElse(0))
+
+	parse := func(s string) Either[string, int] {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return Left[string, int](err.Error())
+		}
+		return Right[string](n)
+	}
+	assert.Equal(42, FlatMap(Right[string]("42"), parse).RightOrElse(0))
+	assert.Equal(true, FlatMap(Right[string]("abc"), parse).IsLeft())
+	assert.Equal("foo", FlatMap(Left[string, string]("foo"), parse).LeftOrElse(""))
+}
+
+func TestResult(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestResult")
+
+	errFoo := errors.New("foo")
+
+	e := FromResult(result.Ok(1))
+	assert.Equal(1, e.RightOrElse(0))
+
+	e = FromResult(result.Err[int](errFoo))
+	assert.Equal(errFoo, e.LeftOrElse(nil))
+
+	assert.Equal(1, ToResult(Right[error](1)).Unwrap())
+	assert.Equal(errFoo, ToResult(Left[error, int](errFoo)).Error())
+}
+
+func TestPartition(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestPartition")
+
+	lefts, rights := Partition([]Either[string, int]{
+		Left[string, int]("a"),
+		Right[string](1),
+		Right[string](2),
+		Left[string, int]("b"),
+	})
+	assert.Equal([]string{"a", "b"}, lefts)
+	assert.Equal([]int{1, 2}, rights)
+}
@@ -0,0 +1,289 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+// Package datastructure implements some data structure. hashmap structure.
+package datastructure
+
+import (
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+const (
+	defaultGenericMapCapacity = 8
+	// grow the table when size exceeds maxLoadFactor * capacity
+	maxLoadFactorNum = 7
+	maxLoadFactorDen = 8
+)
+
+type robinHoodBucket[K comparable, V any] struct {
+	key   K
+	value V
+	hash  uint64
+	// distance is the probe sequence length plus one, 0 means the bucket is empty
+	distance uint32
+}
+
+// GenericHashMap is a type-safe hash map which uses open addressing with Robin Hood hashing.
+// Unlike HashMap, keys are compared by equality function instead of their string form,
+// hash and equality functions are pluggable.
+type GenericHashMap[K comparable, V any] struct {
+	buckets []robinHoodBucket[K, V]
+	mask    uint64
+	size    int
+	hasher  func(key K) uint64
+	equal   func(a, b K) bool
+}
+
+// NewGenericHashMap return a GenericHashMap pointer with given initial capacity,
+// keys are hashed by default hash function (pointers by address, structs field by field) and compared by ==.
+func NewGenericHashMap[K comparable, V any](capacity int) *GenericHashMap[K, V] {
+	return NewGenericHashMapWithFunc[K, V](capacity, nil, nil)
+}
+
+// NewGenericHashMapWithFunc return a GenericHashMap pointer with given initial capacity, hash and equality function.
+// equal keys must have same hash value. if hasher or equal is nil, the default one is used.
+func NewGenericHashMapWithFunc[K comparable, V any](capacity int, hasher func(key K) uint64, equal func(a, b K) bool) *GenericHashMap[K, V] {
+	if hasher == nil {
+		hasher = internal.NewHasher[K]()
+	}
+	if equal == nil {
+		equal = func(a, b K) bool {
+			return a == b
+		}
+	}
+
+	hm := &GenericHashMap[K, V]{hasher: hasher, equal: equal}
+	hm.init(tableSizeFor(capacity))
+
+	return hm
+}
+
+// Get return the value of given key, if key is not found, return zero value of V and false.
+func (hm *GenericHashMap[K, V]) Get(key K) (V, bool) {
+	if i, ok := hm.find(key); ok {
+		return hm.buckets[i].value, true
+	}
+
+	var zero V
+	return zero, false
+}
+
+// GetOrDefault return the value of given key, if key is not found, return default value.
+func (hm *GenericHashMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	if value, ok := hm.Get(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// Contains checks if given key is in hashmap or not
+func (hm *GenericHashMap[K, V]) Contains(key K) bool {
+	_, ok := hm.find(key)
+	return ok
+}
+
+// Put new key value in hashmap, if key already exists, its value is replaced.
+func (hm *GenericHashMap[K, V]) Put(key K, value V) {
+	hash := hm.hasher(key)
+	if i, ok := hm.findWithHash(hash, key); ok {
+		hm.buckets[i].value = value
+		return
+	}
+
+	if (hm.size+1)*maxLoadFactorDen > len(hm.buckets)*maxLoadFactorNum {
+		hm.resize(len(hm.buckets) * 2)
+	}
+
+	hm.insert(robinHoodBucket[K, V]{key: key, value: value, hash: hash, distance: 1})
+	hm.size++
+}
+
+// ComputeIfAbsent return the value of given key, if key is not found,
+// compute the value by mapping function, put it into hashmap and return it.
+func (hm *GenericHashMap[K, V]) ComputeIfAbsent(key K, mapping func(key K) V) V {
+	if value, ok := hm.Get(key); ok {
+		return value
+	}
+
+	value := mapping(key)
+	hm.Put(key, value)
+
+	return value
+}
+
+// Delete item by given key in hashmap, return true if the key existed.
+func (hm *GenericHashMap[K, V]) Delete(key K) bool {
+	i, ok := hm.find(key)
+	if !ok {
+		return false
+	}
+
+	// backward shift deletion: move following buckets one step back until an empty bucket or a bucket in its home position
+	for {
+		next := (i + 1) & hm.mask
+		if hm.buckets[next].distance <= 1 {
+			break
+		}
+		hm.buckets[i] = hm.buckets[next]
+		hm.buckets[i].distance--
+		i = next
+	}
+	hm.buckets[i] = robinHoodBucket[K, V]{}
+	hm.size--
+
+	return true
+}
+
+// Size returns current size of hashmap
+func (hm *GenericHashMap[K, V]) Size() int {
+	return hm.size
+}
+
+// IsEmpty checks if hashmap is empty or not
+func (hm *GenericHashMap[K, V]) IsEmpty() bool {
+	return hm.size == 0
+}
+
+// Clear removes all items of hashmap, the capacity is retained.
+func (hm *GenericHashMap[K, V]) Clear() {
+	for i := range hm.buckets {
+		hm.buckets[i] = robinHoodBucket[K, V]{}
+	}
+	hm.size = 0
+}
+
+// Iterate executes iteratee function for every key and value pair of hashmap (random order),
+// when iteratee return false, will break the loop.
+func (hm *GenericHashMap[K, V]) Iterate(iteratee func(key K, value V) bool) {
+	for i := range hm.buckets {
+		bucket := &hm.buckets[i]
+		if bucket.distance != 0 && !iteratee(bucket.key, bucket.value) {
+			return
+		}
+	}
+}
+
+// Keys returns a slice of the hashmap's keys (random order)
+func (hm *GenericHashMap[K, V]) Keys() []K {
+	keys := make([]K, 0, hm.size)
+	hm.Iterate(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values returns a slice of the hashmap's values (random order)
+func (hm *GenericHashMap[K, V]) Values() []V {
+	values := make([]V, 0, hm.size)
+	hm.Iterate(func(_ K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Clone return a copy of hashmap
+func (hm *GenericHashMap[K, V]) Clone() *GenericHashMap[K, V] {
+	clone := *hm
+	clone.buckets = make([]robinHoodBucket[K, V], len(hm.buckets))
+	copy(clone.buckets, hm.buckets)
+
+	return &clone
+}
+
+// Merge puts all key and value pairs of other hashmaps into hashmap,
+// the value of later hashmap overwrites the former one if keys are same.
+func (hm *GenericHashMap[K, V]) Merge(others ...*GenericHashMap[K, V]) {
+	for _, other := range others {
+		other.Iterate(func(key K, value V) bool {
+			hm.Put(key, value)
+			return true
+		})
+	}
+}
+
+// MergeWith puts all key and value pairs of other hashmap into hashmap,
+// if a key exists in both hashmaps, the value is decided by resolve function.
+func (hm *GenericHashMap[K, V]) MergeWith(other *GenericHashMap[K, V], resolve func(key K, oldValue, newValue V) V) {
+	other.Iterate(func(key K, value V) bool {
+		if oldValue, ok := hm.Get(key); ok {
+			value = resolve(key, oldValue, value)
+		}
+		hm.Put(key, value)
+		return true
+	})
+}
+
+// ToMap return a built-in map contains all key and value pairs of hashmap
+func (hm *GenericHashMap[K, V]) ToMap() map[K]V {
+	result := make(map[K]V, hm.size)
+	hm.Iterate(func(key K, value V) bool {
+		result[key] = value
+		return true
+	})
+	return result
+}
+
+func (hm *GenericHashMap[K, V]) init(capacity int) {
+	hm.buckets = make([]robinHoodBucket[K, V], capacity)
+	hm.mask = uint64(capacity - 1)
+}
+
+func (hm *GenericHashMap[K, V]) find(key K) (uint64, bool) {
+	return hm.findWithHash(hm.hasher(key), key)
+}
+
+func (hm *GenericHashMap[K, V]) findWithHash(hash uint64, key K) (uint64, bool) {
+	i := hash & hm.mask
+	for distance := uint32(1); ; distance++ {
+		bucket := &hm.buckets[i]
+		// the key would have been placed before a bucket which is closer to its home position
+		if bucket.distance < distance {
+			return 0, false
+		}
+		if bucket.hash == hash && hm.equal(bucket.key, key) {
+			return i, true
+		}
+		i = (i + 1) & hm.mask
+	}
+}
+
+// insert put a new bucket into table, rich buckets (close to home) give their place to poor ones.
+func (hm *GenericHashMap[K, V]) insert(bucket robinHoodBucket[K, V]) {
+	i := bucket.hash & hm.mask
+	for {
+		current := &hm.buckets[i]
+		if current.distance == 0 {
+			*current = bucket
+			return
+		}
+		if current.distance < bucket.distance {
+			*current, bucket = bucket, *current
+		}
+		bucket.distance++
+		i = (i + 1) & hm.mask
+	}
+}
+
+func (hm *GenericHashMap[K, V]) resize(capacity int) {
+	old := hm.buckets
+	hm.init(capacity)
+
+	for i := range old {
+		if old[i].distance != 0 {
+			bucket := old[i]
+			bucket.distance = 1
+			hm.insert(bucket)
+		}
+	}
+}
+
+// tableSizeFor return the smallest power of two which can hold capacity items without exceeding load factor.
+func tableSizeFor(capacity int) int {
+	size := defaultGenericMapCapacity
+	for size*maxLoadFactorNum < capacity*maxLoadFactorDen {
+		size <<= 1
+	}
+	return size
+}
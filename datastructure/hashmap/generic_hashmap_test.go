@@ -0,0 +1,309 @@
+package datastructure
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestGenericHashMap_PutAndGet(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGenericHashMap_PutAndGet")
+
+	hm := NewGenericHashMap[string, int](0)
+
+	hm.Put("abc", 3)
+	v, ok := hm.Get("abc")
+	assert.Equal(3, v)
+	assert.Equal(true, ok)
+
+	_, ok = hm.Get("abcd")
+	assert.Equal(false, ok)
+	assert.Equal(10, hm.GetOrDefault("abcd", 10))
+
+	hm.Put("abc", 4)
+	v, _ = hm.Get("abc")
+	assert.Equal(4, v)
+	assert.Equal(1, hm.Size())
+}
+
+func TestGenericHashMap_SameStringForm(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGenericHashMap_SameStringForm")
+
+	type key struct {
+		first  string
+		second string
+	}
+
+	// both keys are formatted as "{a b }"
+	hm := NewGenericHashMap[key, int](0)
+	hm.Put(key{"a b", ""}, 1)
+	hm.Put(key{"a", "b "}, 2)
+
+	assert.Equal(2, hm.Size())
+
+	v, _ := hm.Get(key{"a b", ""})
+	assert.Equal(1, v)
+	v, _ = hm.Get(key{"a", "b "})
+	assert.Equal(2, v)
+}
+
+func TestGenericHashMap_PointerKey(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGenericHashMap_PointerKey")
+
+	type user struct{ name string }
+	p := &user{name: "a"}
+
+	hm := NewGenericHashMap[*user, int](0)
+	hm.Put(p, 1)
+	p.name = "b"
+
+	v, ok := hm.Get(p)
+	assert.Equal(true, ok)
+	assert.Equal(1, v)
+	assert.Equal(false, hm.Contains(&user{name: "b"}))
+}
+
+func TestGenericHashMap_Resize(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGenericHashMap_Resize")
+
+	hm := NewGenericHashMap[int, int](3)
+	for i := 0; i < 1000; i++ {
+		hm.Put(i, i*10)
+	}
+
+	assert.Equal(1000, hm.Size())
+	for i := 0; i < 1000; i++ {
+		v, ok := hm.Get(i)
+		assert.Equal(true, ok)
+		assert.Equal(i*10, v)
+	}
+}
+
+func TestGenericHashMap_Delete(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGenericHashMap_Delete")
+
+	hm := NewGenericHashMap[int, int](0)
+	hm.Put(1, 1)
+	hm.Put(2, 2)
+
+	assert.Equal(true, hm.Delete(1))
+	assert.Equal(false, hm.Delete(1))
+	assert.Equal(false, hm.Contains(1))
+	assert.Equal(true, hm.Contains(2))
+	assert.Equal(1, hm.Size())
+
+	hm.Clear()
+	assert.Equal(true, hm.IsEmpty())
+}
+
+func TestGenericHashMap_Random(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGenericHashMap_Random")
+
+	r := rand.New(rand.NewSource(1))
+	model := map[int]int{}
+	// a weak hash function makes many keys collide
+	hm := NewGenericHashMapWithFunc[int, int](0, func(key int) uint64 { return uint64(key % 64) }, nil)
+
+	for i := 0; i < 20000; i++ {
+		key := r.Intn(2000)
+		switch r.Intn(3) {
+		case 0:
+			_, existed := model[key]
+			assert.Equal(existed, hm.Delete(key))
+			delete(model, key)
+		default:
+			model[key] = i
+			hm.Put(key, i)
+		}
+	}
+
+	assert.Equal(len(model), hm.Size())
+	assert.Equal(model, hm.ToMap())
+}
+
+func TestGenericHashMap_CustomEqual(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGenericHashMap_CustomEqual")
+
+	hm := NewGenericHashMapWithFunc[string, int](0,
+		func(key string) uint64 { return internal.HashString(strings.ToLower(key)) },
+		func(a, b string) bool { return strings.EqualFold(a, b) },
+	)
+
+	hm.Put("Go", 1)
+	hm.Put("GO", 2)
+
+	assert.Equal(1, hm.Size())
+	v, _ := hm.Get("go")
+	assert.Equal(2, v)
+}
+
+func TestGenericHashMap_KeysValues(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGenericHashMap_KeysValues")
+
+	hm := NewGenericHashMap[int, string](0)
+	hm.Put(1, "a")
+	hm.Put(2, "b")
+	hm.Put(3, "c")
+
+	keys := hm.Keys()
+	sort.Ints(keys)
+	values := hm.Values()
+	sort.Strings(values)
+
+	assert.Equal([]int{1, 2, 3}, keys)
+	assert.Equal([]string{"a", "b", "c"}, values)
+
+	count := 0
+	hm.Iterate(func(key int, value string) bool {
+		count++
+		return false
+	})
+	assert.Equal(1, count)
+}
+
+func TestGenericHashMap_CloneAndMerge(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGenericHashMap_CloneAndMerge")
+
+	hm1 := NewGenericHashMap[string, int](0)
+	hm1.Put("a", 1)
+	hm1.Put("b", 2)
+
+	hm2 := hm1.Clone()
+	hm2.Put("b", 20)
+	hm2.Put("c", 30)
+
+	v, _ := hm1.Get("b")
+	assert.Equal(2, v)
+	assert.Equal(false, hm1.Contains("c"))
+
+	merged := hm1.Clone()
+	merged.Merge(hm2)
+	assert.Equal(map[string]int{"a": 1, "b": 20, "c": 30}, merged.ToMap())
+
+	summed := hm1.Clone()
+	summed.MergeWith(hm2, func(key string, oldValue, newValue int) int {
+		return oldValue + newValue
+	})
+	assert.Equal(map[string]int{"a": 2, "b": 22, "c": 30}, summed.ToMap())
+}
+
+func TestGenericHashMap_ComputeIfAbsent(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGenericHashMap_ComputeIfAbsent")
+
+	hm := NewGenericHashMap[string, []int](0)
+
+	calls := 0
+	mapping := func(key string) []int {
+		calls++
+		return []int{}
+	}
+
+	hm.Put("a", append(hm.ComputeIfAbsent("a", mapping), 1))
+	hm.Put("a", append(hm.ComputeIfAbsent("a", mapping), 2))
+
+	v, _ := hm.Get("a")
+	assert.Equal([]int{1, 2}, v)
+	assert.Equal(1, calls)
+}
+
+func BenchmarkGenericHashMap_Put(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		hm := NewGenericHashMap[int, int](0)
+		for j := 0; j < 1000; j++ {
+			hm.Put(j, j)
+		}
+	}
+}
+
+func BenchmarkBuiltinMap_Put(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		m := make(map[int]int)
+		for j := 0; j < 1000; j++ {
+			m[j] = j
+		}
+	}
+}
+
+func BenchmarkHashMap_Put(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		hm := NewHashMap()
+		for j := 0; j < 1000; j++ {
+			hm.Put(j, j)
+		}
+	}
+}
+
+func BenchmarkGenericHashMap_Get(b *testing.B) {
+	hm := NewGenericHashMap[int, int](1000)
+	for j := 0; j < 1000; j++ {
+		hm.Put(j, j)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hm.Get(i % 1000)
+	}
+}
+
+func BenchmarkBuiltinMap_Get(b *testing.B) {
+	m := make(map[int]int, 1000)
+	for j := 0; j < 1000; j++ {
+		m[j] = j
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = m[i%1000]
+	}
+}
+
+func BenchmarkGenericHashMap_GetString(b *testing.B) {
+	keys := make([]string, 1000)
+	hm := NewGenericHashMap[string, int](1000)
+	for j := range keys {
+		keys[j] = strings.Repeat("k", j%20) + string(rune('a'+j%26)) + string(rune(j))
+		hm.Put(keys[j], j)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hm.Get(keys[i%1000])
+	}
+}
+
+func BenchmarkBuiltinMap_GetString(b *testing.B) {
+	keys := make([]string, 1000)
+	m := make(map[string]int, 1000)
+	for j := range keys {
+		keys[j] = strings.Repeat("k", j%20) + string(rune('a'+j%26)) + string(rune(j))
+		m[keys[j]] = j
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = m[keys[i%1000]]
+	}
+}
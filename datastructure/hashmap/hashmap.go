@@ -18,7 +18,8 @@ type mapNode struct {
 	next  *mapNode
 }
 
-// HashMap implements a hash map
+// HashMap implements a hash map, keys are hashed by their string form.
+// GenericHashMap is recommended if types of key and value are known.
 type HashMap struct {
 	capacity uint64
 	size     uint64
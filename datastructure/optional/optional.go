@@ -1,6 +1,7 @@
 package optional
 
 import (
+	"encoding/json"
 	"sync"
 )
 
@@ -106,3 +107,74 @@ func (o Optional[T]) OrElseTrigger(errorHandler func() error) (T, error) {
 	}
 	return *o.value, nil
 }
+
+// Filter returns the Optional if the value is not nil and matches the predicate, otherwise returns an empty Optional.
+func (o Optional[T]) Filter(predicate func(value T) bool) Optional[T] {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	if o.value != nil && predicate(*o.value) {
+		return o
+	}
+	return Default[T]()
+}
+
+// Map applies the mapper to the value if not nil and returns an Optional of the result,
+// otherwise returns an empty Optional. Use the Map function to map to another type.
+func (o Optional[T]) Map(mapper func(value T) T) Optional[T] {
+	return Map(o, mapper)
+}
+
+// FlatMap applies the mapper to the value if not nil and returns its result, otherwise returns an empty Optional.
+// Use the FlatMap function to map to another type.
+func (o Optional[T]) FlatMap(mapper func(value T) Optional[T]) Optional[T] {
+	return FlatMap(o, mapper)
+}
+
+// Map applies the mapper to the value of Optional if not nil and returns an Optional of the result,
+// otherwise returns an empty Optional.
+func Map[T any, U any](o Optional[T], mapper func(value T) U) Optional[U] {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	if o.value == nil {
+		return Default[U]()
+	}
+	return Of(mapper(*o.value))
+}
+
+// FlatMap applies the mapper to the value of Optional if not nil and returns its result,
+// otherwise returns an empty Optional.
+func FlatMap[T any, U any](o Optional[T], mapper func(value T) Optional[U]) Optional[U] {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	if o.value == nil {
+		return Default[U]()
+	}
+	return mapper(*o.value)
+}
+
+// MarshalJSON implements the json.Marshaler interface, an empty Optional is encoded as null.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if o.mu == nil || o.IsNil() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.Unwarp())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, null is decoded as an empty Optional.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = Default[T]()
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*o = Of(value)
+
+	return nil
+}
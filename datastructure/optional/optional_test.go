@@ -1,7 +1,9 @@
 package optional
 
 import (
+	"encoding/json"
 	"errors"
+	"strconv"
 	"testing"
 
 	"github.com/duke-git/lancet/v2/internal"
@@ -149,3 +151,65 @@ func TestGetWithPanicStandard(t *testing.T) {
 		_ = optDefault.Unwarp()
 	}()
 }
+
+func TestFilter(t *testing.T) {
+	assert := internal.NewAssert(t, "TestFilter")
+
+	isEven := func(n int) bool { return n%2 == 0 }
+
+	assert.Equal(42, Of(42).Filter(isEven).Unwarp())
+	assert.ShouldBeTrue(Of(41).Filter(isEven).IsNil())
+	assert.ShouldBeTrue(Default[int]().Filter(isEven).IsNil())
+}
+
+func TestMap(t *testing.T) {
+	assert := internal.NewAssert(t, "TestMap")
+
+	assert.Equal(84, Of(42).Map(func(n int) int { return n * 2 }).Unwarp())
+	assert.Equal("42", Map(Of(42), strconv.Itoa).Unwarp())
+	assert.ShouldBeTrue(Map(Default[int](), strconv.Itoa).IsNil())
+}
+
+func TestFlatMap(t *testing.T) {
+	assert := internal.NewAssert(t, "TestFlatMap")
+
+	parse := func(s string) Optional[int] {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return Default[int]()
+		}
+		return Of(n)
+	}
+
+	assert.Equal(42, FlatMap(Of("42"), parse).Unwarp())
+	assert.ShouldBeTrue(FlatMap(Of("abc"), parse).IsNil())
+	assert.ShouldBeTrue(FlatMap(Default[string](), parse).IsNil())
+	assert.ShouldBeTrue(Of(1).FlatMap(func(n int) Optional[int] { return Default[int]() }).IsNil())
+}
+
+func TestJSON(t *testing.T) {
+	assert := internal.NewAssert(t, "TestJSON")
+
+	type user struct {
+		Name  string           `json:"name"`
+		Age   Optional[int]    `json:"age"`
+		Email Optional[string] `json:"email"`
+	}
+
+	data, err := json.Marshal(user{Name: "foo", Age: Of(20), Email: Default[string]()})
+	assert.IsNil(err)
+	assert.Equal(`{"name":"foo","age":20,"email":null}`, string(data))
+
+	data, err = json.Marshal(user{Name: "bar"})
+	assert.IsNil(err)
+	assert.Equal(`{"name":"bar","age":null,"email":null}`, string(data))
+
+	var u user
+	err = json.Unmarshal([]byte(`{"name":"foo","age":20,"email":null}`), &u)
+	assert.IsNil(err)
+	assert.Equal(20, u.Age.Unwarp())
+	assert.ShouldBeTrue(u.Email.IsNil())
+
+	err = json.Unmarshal([]byte(`{"age":"abc"}`), &u)
+	assert.IsNotNil(err)
+}
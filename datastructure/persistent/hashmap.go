@@ -0,0 +1,394 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+// Package datastructure contains some data structure. Persistent structure contains PersistentVector, PersistentHashMap and PersistentSortedMap.
+package datastructure
+
+import (
+	"math/bits"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+const (
+	hamtBits = 5
+	hamtMask = 1<<hamtBits - 1
+	// when all 64 bits of hash are consumed, keys are stored in a collision node
+	hamtMaxShift = 64
+)
+
+type hamtEntry[K comparable, V any] struct {
+	hash  uint64
+	key   K
+	value V
+	// child is not nil if the entry is a sub node
+	child *hamtNode[K, V]
+}
+
+// hamtNode is a bitmap indexed node, or a collision node if it's at max depth.
+type hamtNode[K comparable, V any] struct {
+	edit    *editToken
+	bitmap  uint32
+	entries []hamtEntry[K, V]
+}
+
+// PersistentHashMap is an immutable hash map implemented with hash array mapped trie (HAMT).
+// Every modification returns a new version which shares structure with the old one,
+// Get, Put and Delete are O(log32 n). The zero value is an empty map.
+type PersistentHashMap[K comparable, V any] struct {
+	root   *hamtNode[K, V]
+	size   int
+	hasher func(key K) uint64
+}
+
+// NewPersistentHashMap return a empty PersistentHashMap pointer.
+func NewPersistentHashMap[K comparable, V any]() *PersistentHashMap[K, V] {
+	return NewPersistentHashMapWithHasher[K, V](internal.Hash[K])
+}
+
+// NewPersistentHashMapWithHasher return a empty PersistentHashMap pointer which uses given hash function.
+// equal keys must have same hash value.
+func NewPersistentHashMapWithHasher[K comparable, V any](hasher func(key K) uint64) *PersistentHashMap[K, V] {
+	return &PersistentHashMap[K, V]{hasher: hasher}
+}
+
+// FromMap return a PersistentHashMap pointer contains all key and value of given map.
+func FromMap[K comparable, V any](m map[K]V) *PersistentHashMap[K, V] {
+	transient := NewPersistentHashMap[K, V]().Transient()
+	for k, v := range m {
+		transient.Put(k, v)
+	}
+	return transient.Persistent()
+}
+
+// Size return the number of keys in map
+func (m *PersistentHashMap[K, V]) Size() int {
+	return m.size
+}
+
+// IsEmpty checks if map is empty or not
+func (m *PersistentHashMap[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Get return the value of given key, if key is not found, return zero value of V and false.
+func (m *PersistentHashMap[K, V]) Get(key K) (V, bool) {
+	hash := m.hash(key)
+	node := m.root
+
+	for shift := uint(0); node != nil; shift += hamtBits {
+		if shift >= hamtMaxShift {
+			for _, e := range node.entries {
+				if e.key == key {
+					return e.value, true
+				}
+			}
+			break
+		}
+
+		bit := hamtBit(hash, shift)
+		if node.bitmap&bit == 0 {
+			break
+		}
+
+		e := node.entries[hamtIndex(node.bitmap, bit)]
+		if e.child == nil {
+			if e.key == key {
+				return e.value, true
+			}
+			break
+		}
+		node = e.child
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Contain checks if key is in map or not
+func (m *PersistentHashMap[K, V]) Contain(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Put return a new map with key and value added, if key already exists, its value is replaced.
+func (m *PersistentHashMap[K, V]) Put(key K, value V) *PersistentHashMap[K, V] {
+	result := *m
+	result.put(nil, key, value)
+	return &result
+}
+
+// Delete return a new map without the key, if key is not found, return the map itself.
+func (m *PersistentHashMap[K, V]) Delete(key K) *PersistentHashMap[K, V] {
+	result := *m
+	if !result.delete(nil, key) {
+		return m
+	}
+	return &result
+}
+
+// Iterate call function by every key and value of map in unspecified order,
+// when iteratee return false, will break the loop.
+func (m *PersistentHashMap[K, V]) Iterate(iteratee func(key K, value V) bool) {
+	iterateHamt(m.root, iteratee)
+}
+
+// Keys return all keys of map in unspecified order
+func (m *PersistentHashMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.size)
+	m.Iterate(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// ToMap return a built-in map contains all key and value of map
+func (m *PersistentHashMap[K, V]) ToMap() map[K]V {
+	result := make(map[K]V, m.size)
+	m.Iterate(func(key K, value V) bool {
+		result[key] = value
+		return true
+	})
+	return result
+}
+
+// Transient return a mutable copy of map for batch updates, the map itself is not affected.
+func (m *PersistentHashMap[K, V]) Transient() *TransientHashMap[K, V] {
+	return &TransientHashMap[K, V]{m: *m, edit: &editToken{}}
+}
+
+// TransientHashMap is a mutable builder of PersistentHashMap, it mutates nodes it owns in place,
+// so batch updates are much cheaper than updating a PersistentHashMap one by one.
+// TransientHashMap is not safe for concurrent use.
+type TransientHashMap[K comparable, V any] struct {
+	m    PersistentHashMap[K, V]
+	edit *editToken
+}
+
+// Size return the number of keys in map
+func (t *TransientHashMap[K, V]) Size() int {
+	return t.m.size
+}
+
+// Get return the value of given key, if key is not found, return zero value of V and false.
+func (t *TransientHashMap[K, V]) Get(key K) (V, bool) {
+	return t.m.Get(key)
+}
+
+// Put add key and value into map, if key already exists, its value is replaced.
+func (t *TransientHashMap[K, V]) Put(key K, value V) *TransientHashMap[K, V] {
+	t.m.put(t.edit, key, value)
+	return t
+}
+
+// Delete remove the key from map, return true if the key existed.
+func (t *TransientHashMap[K, V]) Delete(key K) bool {
+	return t.m.delete(t.edit, key)
+}
+
+// Persistent return an immutable snapshot of the transient map.
+// the transient is still usable after that, later updates don't affect the returned map.
+func (t *TransientHashMap[K, V]) Persistent() *PersistentHashMap[K, V] {
+	result := t.m
+	t.edit = &editToken{}
+	return &result
+}
+
+func (m *PersistentHashMap[K, V]) put(edit *editToken, key K, value V) {
+	root := m.root
+	if root == nil {
+		root = &hamtNode[K, V]{edit: edit}
+	}
+
+	var added bool
+	m.root, added = hamtPut(edit, root, 0, hamtEntry[K, V]{hash: m.hash(key), key: key, value: value})
+	if added {
+		m.size++
+	}
+}
+
+func (m *PersistentHashMap[K, V]) delete(edit *editToken, key K) bool {
+	if m.root == nil {
+		return false
+	}
+
+	root, removed := hamtDelete(edit, m.root, 0, m.hash(key), key)
+	if !removed {
+		return false
+	}
+
+	m.root = root
+	m.size--
+	return true
+}
+
+// hash return hash value of key, the zero value of PersistentHashMap uses default hash function.
+func (m *PersistentHashMap[K, V]) hash(key K) uint64 {
+	if m.hasher == nil {
+		return internal.Hash(key)
+	}
+	return m.hasher(key)
+}
+
+func hamtPut[K comparable, V any](edit *editToken, node *hamtNode[K, V], shift uint, entry hamtEntry[K, V]) (*hamtNode[K, V], bool) {
+	if shift >= hamtMaxShift {
+		for i, e := range node.entries {
+			if e.key == entry.key {
+				node = editableHamtNode(edit, node)
+				node.entries[i].value = entry.value
+				return node, false
+			}
+		}
+		node = editableHamtNode(edit, node)
+		node.entries = append(node.entries, entry)
+		return node, true
+	}
+
+	bit := hamtBit(entry.hash, shift)
+	index := hamtIndex(node.bitmap, bit)
+
+	if node.bitmap&bit == 0 {
+		node = editableHamtNode(edit, node)
+		node.entries = append(node.entries, hamtEntry[K, V]{})
+		copy(node.entries[index+1:], node.entries[index:])
+		node.entries[index] = entry
+		node.bitmap |= bit
+		return node, true
+	}
+
+	existing := node.entries[index]
+	var added bool
+
+	switch {
+	case existing.child != nil:
+		var child *hamtNode[K, V]
+		child, added = hamtPut(edit, existing.child, shift+hamtBits, entry)
+		if child == existing.child {
+			return node, added
+		}
+		existing = hamtEntry[K, V]{child: child}
+	case existing.key == entry.key:
+		existing = entry
+	default:
+		existing = hamtEntry[K, V]{child: mergeHamtEntries(edit, shift+hamtBits, existing, entry)}
+		added = true
+	}
+
+	node = editableHamtNode(edit, node)
+	node.entries[index] = existing
+
+	return node, added
+}
+
+func hamtDelete[K comparable, V any](edit *editToken, node *hamtNode[K, V], shift uint, hash uint64, key K) (*hamtNode[K, V], bool) {
+	if shift >= hamtMaxShift {
+		for i, e := range node.entries {
+			if e.key == key {
+				return removeHamtEntry(edit, node, i, 0), true
+			}
+		}
+		return node, false
+	}
+
+	bit := hamtBit(hash, shift)
+	if node.bitmap&bit == 0 {
+		return node, false
+	}
+
+	index := hamtIndex(node.bitmap, bit)
+	existing := node.entries[index]
+
+	if existing.child == nil {
+		if existing.key != key {
+			return node, false
+		}
+		return removeHamtEntry(edit, node, index, bit), true
+	}
+
+	child, removed := hamtDelete(edit, existing.child, shift+hamtBits, hash, key)
+	if !removed {
+		return node, false
+	}
+	if child == nil {
+		return removeHamtEntry(edit, node, index, bit), true
+	}
+
+	node = editableHamtNode(edit, node)
+	// inline the sub node if it has only one key left
+	if len(child.entries) == 1 && child.entries[0].child == nil {
+		node.entries[index] = child.entries[0]
+	} else {
+		node.entries[index] = hamtEntry[K, V]{child: child}
+	}
+
+	return node, true
+}
+
+// removeHamtEntry remove entry at index, return nil if node becomes empty.
+func removeHamtEntry[K comparable, V any](edit *editToken, node *hamtNode[K, V], index int, bit uint32) *hamtNode[K, V] {
+	if len(node.entries) == 1 {
+		return nil
+	}
+
+	node = editableHamtNode(edit, node)
+	copy(node.entries[index:], node.entries[index+1:])
+	node.entries[len(node.entries)-1] = hamtEntry[K, V]{}
+	node.entries = node.entries[:len(node.entries)-1]
+	node.bitmap &^= bit
+
+	return node
+}
+
+func mergeHamtEntries[K comparable, V any](edit *editToken, shift uint, e1, e2 hamtEntry[K, V]) *hamtNode[K, V] {
+	if shift >= hamtMaxShift {
+		return &hamtNode[K, V]{edit: edit, entries: []hamtEntry[K, V]{e1, e2}}
+	}
+
+	bit1, bit2 := hamtBit(e1.hash, shift), hamtBit(e2.hash, shift)
+	if bit1 == bit2 {
+		child := mergeHamtEntries(edit, shift+hamtBits, e1, e2)
+		return &hamtNode[K, V]{edit: edit, bitmap: bit1, entries: []hamtEntry[K, V]{{child: child}}}
+	}
+
+	if bit1 > bit2 {
+		e1, e2 = e2, e1
+	}
+	return &hamtNode[K, V]{edit: edit, bitmap: bit1 | bit2, entries: []hamtEntry[K, V]{e1, e2}}
+}
+
+func iterateHamt[K comparable, V any](node *hamtNode[K, V], iteratee func(key K, value V) bool) bool {
+	if node == nil {
+		return true
+	}
+	for _, e := range node.entries {
+		if e.child != nil {
+			if !iterateHamt(e.child, iteratee) {
+				return false
+			}
+		} else if !iteratee(e.key, e.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// editableHamtNode return node itself if it's owned by edit, or else return a copy owned by edit.
+func editableHamtNode[K comparable, V any](edit *editToken, node *hamtNode[K, V]) *hamtNode[K, V] {
+	if canEdit(edit, node.edit) {
+		return node
+	}
+
+	entries := make([]hamtEntry[K, V], len(node.entries), len(node.entries)+1)
+	copy(entries, node.entries)
+
+	return &hamtNode[K, V]{edit: edit, bitmap: node.bitmap, entries: entries}
+}
+
+func hamtBit(hash uint64, shift uint) uint32 {
+	return 1 << ((hash >> shift) & hamtMask)
+}
+
+func hamtIndex(bitmap uint32, bit uint32) int {
+	return bits.OnesCount32(bitmap & (bit - 1))
+}
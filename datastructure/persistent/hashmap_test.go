@@ -0,0 +1,146 @@
+package datastructure
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestPersistentHashMap_PutGet(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPersistentHashMap_PutGet")
+
+	m0 := NewPersistentHashMap[string, int]()
+	m1 := m0.Put("a", 1)
+	m2 := m1.Put("b", 2).Put("a", 10)
+
+	assert.Equal(0, m0.Size())
+	assert.Equal(1, m1.Size())
+	assert.Equal(2, m2.Size())
+
+	v, _ := m1.Get("a")
+	assert.Equal(1, v)
+	v, _ = m2.Get("a")
+	assert.Equal(10, v)
+
+	_, ok := m2.Get("c")
+	assert.Equal(false, ok)
+	assert.Equal(map[string]int{"a": 10, "b": 2}, m2.ToMap())
+
+	var zero PersistentHashMap[int, int]
+	assert.Equal(true, zero.Put(1, 1).Contain(1))
+}
+
+func TestPersistentHashMap_PointerKey(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPersistentHashMap_PointerKey")
+
+	type user struct{ name string }
+	p := &user{name: "a"}
+
+	m := NewPersistentHashMap[*user, int]().Put(p, 1)
+	p.name = "b"
+
+	v, ok := m.Get(p)
+	assert.Equal(true, ok)
+	assert.Equal(1, v)
+	assert.Equal(false, m.Contain(&user{name: "b"}))
+}
+
+func TestPersistentHashMap_Delete(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPersistentHashMap_Delete")
+
+	m1 := FromMap(map[int]string{1: "a", 2: "b", 3: "c"})
+	m2 := m1.Delete(2)
+
+	assert.Equal(true, m1.Contain(2))
+	assert.Equal(false, m2.Contain(2))
+	assert.Equal(2, m2.Size())
+	assert.Equal(true, m2 == m2.Delete(5))
+}
+
+func TestPersistentHashMap_Collision(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPersistentHashMap_Collision")
+
+	// every key has same hash value
+	m := NewPersistentHashMapWithHasher[int, int](func(key int) uint64 { return 42 })
+	for i := 0; i < 10; i++ {
+		m = m.Put(i, i*i)
+	}
+	assert.Equal(10, m.Size())
+
+	v, ok := m.Get(3)
+	assert.Equal(9, v)
+	assert.Equal(true, ok)
+
+	m = m.Put(3, 0)
+	v, _ = m.Get(3)
+	assert.Equal(0, v)
+	assert.Equal(10, m.Size())
+
+	for i := 0; i < 10; i++ {
+		m = m.Delete(i)
+	}
+	assert.Equal(true, m.IsEmpty())
+}
+
+func TestPersistentHashMap_Random(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPersistentHashMap_Random")
+
+	r := rand.New(rand.NewSource(1))
+	model := map[int]int{}
+	m := NewPersistentHashMapWithHasher[int, int](func(key int) uint64 { return uint64(key % 512) })
+
+	for i := 0; i < 5000; i++ {
+		key := r.Intn(1000)
+		if r.Intn(3) == 0 {
+			delete(model, key)
+			m = m.Delete(key)
+		} else {
+			model[key] = i
+			m = m.Put(key, i)
+		}
+	}
+
+	assert.Equal(len(model), m.Size())
+	assert.Equal(model, m.ToMap())
+	assert.Equal(len(model), len(m.Keys()))
+}
+
+func TestTransientHashMap(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestTransientHashMap")
+
+	base := NewPersistentHashMap[int, int]().Put(0, 0)
+
+	transient := base.Transient()
+	for i := 1; i < 1000; i++ {
+		transient.Put(i, i)
+	}
+	assert.Equal(true, transient.Delete(0))
+	assert.Equal(false, transient.Delete(0))
+
+	snapshot := transient.Persistent()
+	transient.Put(1, 100)
+	transient.Delete(2)
+
+	assert.Equal(1, base.Size())
+	assert.Equal(999, snapshot.Size())
+	assert.Equal(998, transient.Size())
+
+	v, _ := snapshot.Get(1)
+	assert.Equal(1, v)
+	v, _ = transient.Get(1)
+	assert.Equal(100, v)
+	assert.Equal(true, snapshot.Contain(2))
+}
@@ -0,0 +1,13 @@
+package datastructure
+
+// editToken marks nodes owned by a transient collection, the transient can mutate those nodes in place.
+// it has non-zero size, so that pointers of different tokens are never equal.
+type editToken struct {
+	_ byte
+}
+
+// canEdit checks if node with token `owner` can be mutated in place by `edit`.
+// nodes of persistent collections have nil token, they are never mutated.
+func canEdit(edit, owner *editToken) bool {
+	return edit != nil && edit == owner
+}
@@ -0,0 +1,366 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+// Package datastructure contains some data structure. Persistent structure contains PersistentVector, PersistentHashMap and PersistentSortedMap.
+package datastructure
+
+import (
+	"github.com/duke-git/lancet/v2/constraints"
+)
+
+type sortedMapNode[K any, V any] struct {
+	edit   *editToken
+	key    K
+	value  V
+	left   *sortedMapNode[K, V]
+	right  *sortedMapNode[K, V]
+	height int
+}
+
+// PersistentSortedMap is an immutable sorted map implemented with AVL tree and path copying.
+// Every modification returns a new version which shares structure with the old one,
+// Get, Put and Delete are O(log n).
+// type K should implements Compare function in constraints.Comparator interface.
+type PersistentSortedMap[K any, V any] struct {
+	root       *sortedMapNode[K, V]
+	size       int
+	comparator constraints.Comparator
+}
+
+// NewPersistentSortedMap return a empty PersistentSortedMap pointer
+// param `comparator` is used to compare keys in the map
+func NewPersistentSortedMap[K any, V any](comparator constraints.Comparator) *PersistentSortedMap[K, V] {
+	return &PersistentSortedMap[K, V]{comparator: comparator}
+}
+
+// Size return the number of keys in map
+func (m *PersistentSortedMap[K, V]) Size() int {
+	return m.size
+}
+
+// IsEmpty checks if map is empty or not
+func (m *PersistentSortedMap[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Get return the value of given key, if key is not found, return zero value of V and false.
+func (m *PersistentSortedMap[K, V]) Get(key K) (V, bool) {
+	node := m.root
+	for node != nil {
+		c := m.comparator.Compare(key, node.key)
+		switch {
+		case c < 0:
+			node = node.left
+		case c > 0:
+			node = node.right
+		default:
+			return node.value, true
+		}
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Contain checks if key is in map or not
+func (m *PersistentSortedMap[K, V]) Contain(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Put return a new map with key and value added, if key already exists, its value is replaced.
+func (m *PersistentSortedMap[K, V]) Put(key K, value V) *PersistentSortedMap[K, V] {
+	result := *m
+	result.put(nil, key, value)
+	return &result
+}
+
+// Delete return a new map without the key, if key is not found, return the map itself.
+func (m *PersistentSortedMap[K, V]) Delete(key K) *PersistentSortedMap[K, V] {
+	result := *m
+	if !result.delete(nil, key) {
+		return m
+	}
+	return &result
+}
+
+// First return the smallest key and its value, if map is empty, return false.
+func (m *PersistentSortedMap[K, V]) First() (K, V, bool) {
+	node := m.root
+	for node != nil && node.left != nil {
+		node = node.left
+	}
+	return sortedMapEntry(node)
+}
+
+// Last return the largest key and its value, if map is empty, return false.
+func (m *PersistentSortedMap[K, V]) Last() (K, V, bool) {
+	node := m.root
+	for node != nil && node.right != nil {
+		node = node.right
+	}
+	return sortedMapEntry(node)
+}
+
+// Range call function by every key and value in the closed interval [from, to] in ascending order,
+// when iteratee return false, will break the loop.
+func (m *PersistentSortedMap[K, V]) Range(from, to K, iteratee func(key K, value V) bool) {
+	m.rangeNode(m.root, from, to, iteratee)
+}
+
+// Iterate call function by every key and value of map in ascending order,
+// when iteratee return false, will break the loop.
+func (m *PersistentSortedMap[K, V]) Iterate(iteratee func(key K, value V) bool) {
+	iterateSortedMap(m.root, iteratee)
+}
+
+// Keys return all keys of map in ascending order
+func (m *PersistentSortedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.size)
+	m.Iterate(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values return all values of map in ascending order of keys
+func (m *PersistentSortedMap[K, V]) Values() []V {
+	values := make([]V, 0, m.size)
+	m.Iterate(func(_ K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Transient return a mutable copy of map for batch updates, the map itself is not affected.
+func (m *PersistentSortedMap[K, V]) Transient() *TransientSortedMap[K, V] {
+	return &TransientSortedMap[K, V]{m: *m, edit: &editToken{}}
+}
+
+// TransientSortedMap is a mutable builder of PersistentSortedMap, it mutates nodes it owns in place,
+// so batch updates are much cheaper than updating a PersistentSortedMap one by one.
+// TransientSortedMap is not safe for concurrent use.
+type TransientSortedMap[K any, V any] struct {
+	m    PersistentSortedMap[K, V]
+	edit *editToken
+}
+
+// Size return the number of keys in map
+func (t *TransientSortedMap[K, V]) Size() int {
+	return t.m.size
+}
+
+// Get return the value of given key, if key is not found, return zero value of V and false.
+func (t *TransientSortedMap[K, V]) Get(key K) (V, bool) {
+	return t.m.Get(key)
+}
+
+// Put add key and value into map, if key already exists, its value is replaced.
+func (t *TransientSortedMap[K, V]) Put(key K, value V) *TransientSortedMap[K, V] {
+	t.m.put(t.edit, key, value)
+	return t
+}
+
+// Delete remove the key from map, return true if the key existed.
+func (t *TransientSortedMap[K, V]) Delete(key K) bool {
+	return t.m.delete(t.edit, key)
+}
+
+// Persistent return an immutable snapshot of the transient map.
+// the transient is still usable after that, later updates don't affect the returned map.
+func (t *TransientSortedMap[K, V]) Persistent() *PersistentSortedMap[K, V] {
+	result := t.m
+	t.edit = &editToken{}
+	return &result
+}
+
+func (m *PersistentSortedMap[K, V]) put(edit *editToken, key K, value V) {
+	var added bool
+	m.root, added = m.insert(edit, m.root, key, value)
+	if added {
+		m.size++
+	}
+}
+
+func (m *PersistentSortedMap[K, V]) delete(edit *editToken, key K) bool {
+	root, removed := m.remove(edit, m.root, key)
+	if !removed {
+		return false
+	}
+
+	m.root = root
+	m.size--
+	return true
+}
+
+func (m *PersistentSortedMap[K, V]) insert(edit *editToken, node *sortedMapNode[K, V], key K, value V) (*sortedMapNode[K, V], bool) {
+	if node == nil {
+		return &sortedMapNode[K, V]{edit: edit, key: key, value: value, height: 1}, true
+	}
+
+	var added bool
+	c := m.comparator.Compare(key, node.key)
+
+	node = editableSortedMapNode(edit, node)
+	switch {
+	case c < 0:
+		node.left, added = m.insert(edit, node.left, key, value)
+	case c > 0:
+		node.right, added = m.insert(edit, node.right, key, value)
+	default:
+		node.value = value
+		return node, false
+	}
+
+	return rebalanceSortedMap(edit, node), added
+}
+
+func (m *PersistentSortedMap[K, V]) remove(edit *editToken, node *sortedMapNode[K, V], key K) (*sortedMapNode[K, V], bool) {
+	if node == nil {
+		return nil, false
+	}
+
+	c := m.comparator.Compare(key, node.key)
+	switch {
+	case c < 0:
+		left, removed := m.remove(edit, node.left, key)
+		if !removed {
+			return node, false
+		}
+		node = editableSortedMapNode(edit, node)
+		node.left = left
+	case c > 0:
+		right, removed := m.remove(edit, node.right, key)
+		if !removed {
+			return node, false
+		}
+		node = editableSortedMapNode(edit, node)
+		node.right = right
+	default:
+		if node.left == nil {
+			return node.right, true
+		}
+		if node.right == nil {
+			return node.left, true
+		}
+
+		successor := node.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+
+		node = editableSortedMapNode(edit, node)
+		node.key, node.value = successor.key, successor.value
+		node.right, _ = m.remove(edit, node.right, successor.key)
+	}
+
+	return rebalanceSortedMap(edit, node), true
+}
+
+func (m *PersistentSortedMap[K, V]) rangeNode(node *sortedMapNode[K, V], from, to K, iteratee func(key K, value V) bool) bool {
+	if node == nil {
+		return true
+	}
+
+	lowerOk := m.comparator.Compare(node.key, from) >= 0
+	upperOk := m.comparator.Compare(node.key, to) <= 0
+
+	if lowerOk && !m.rangeNode(node.left, from, to, iteratee) {
+		return false
+	}
+	if lowerOk && upperOk && !iteratee(node.key, node.value) {
+		return false
+	}
+	if upperOk {
+		return m.rangeNode(node.right, from, to, iteratee)
+	}
+	return true
+}
+
+func iterateSortedMap[K any, V any](node *sortedMapNode[K, V], iteratee func(key K, value V) bool) bool {
+	if node == nil {
+		return true
+	}
+	return iterateSortedMap(node.left, iteratee) &&
+		iteratee(node.key, node.value) &&
+		iterateSortedMap(node.right, iteratee)
+}
+
+func sortedMapEntry[K any, V any](node *sortedMapNode[K, V]) (K, V, bool) {
+	if node == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return node.key, node.value, true
+}
+
+// editableSortedMapNode return node itself if it's owned by edit, or else return a copy owned by edit.
+func editableSortedMapNode[K any, V any](edit *editToken, node *sortedMapNode[K, V]) *sortedMapNode[K, V] {
+	if canEdit(edit, node.edit) {
+		return node
+	}
+
+	result := *node
+	result.edit = edit
+	return &result
+}
+
+func sortedMapNodeHeight[K any, V any](node *sortedMapNode[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+func updateSortedMapNode[K any, V any](node *sortedMapNode[K, V]) {
+	lh, rh := sortedMapNodeHeight(node.left), sortedMapNodeHeight(node.right)
+	if lh > rh {
+		node.height = lh + 1
+	} else {
+		node.height = rh + 1
+	}
+}
+
+// rotateSortedMapLeft rotate node left, node should be editable.
+func rotateSortedMapLeft[K any, V any](edit *editToken, node *sortedMapNode[K, V]) *sortedMapNode[K, V] {
+	right := editableSortedMapNode(edit, node.right)
+	node.right = right.left
+	right.left = node
+	updateSortedMapNode(node)
+	updateSortedMapNode(right)
+	return right
+}
+
+// rotateSortedMapRight rotate node right, node should be editable.
+func rotateSortedMapRight[K any, V any](edit *editToken, node *sortedMapNode[K, V]) *sortedMapNode[K, V] {
+	left := editableSortedMapNode(edit, node.left)
+	node.left = left.right
+	left.right = node
+	updateSortedMapNode(node)
+	updateSortedMapNode(left)
+	return left
+}
+
+// rebalanceSortedMap rebalance node, node should be editable.
+func rebalanceSortedMap[K any, V any](edit *editToken, node *sortedMapNode[K, V]) *sortedMapNode[K, V] {
+	updateSortedMapNode(node)
+
+	balance := sortedMapNodeHeight(node.left) - sortedMapNodeHeight(node.right)
+	if balance > 1 {
+		if sortedMapNodeHeight(node.left.left) < sortedMapNodeHeight(node.left.right) {
+			node.left = rotateSortedMapLeft(edit, editableSortedMapNode(edit, node.left))
+		}
+		return rotateSortedMapRight(edit, node)
+	}
+	if balance < -1 {
+		if sortedMapNodeHeight(node.right.right) < sortedMapNodeHeight(node.right.left) {
+			node.right = rotateSortedMapRight(edit, editableSortedMapNode(edit, node.right))
+		}
+		return rotateSortedMapLeft(edit, node)
+	}
+
+	return node
+}
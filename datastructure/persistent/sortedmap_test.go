@@ -0,0 +1,162 @@
+package datastructure
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+type intComparator struct{}
+
+func (c *intComparator) Compare(v1, v2 any) int {
+	val1, _ := v1.(int)
+	val2, _ := v2.(int)
+
+	if val1 < val2 {
+		return -1
+	} else if val1 > val2 {
+		return 1
+	}
+	return 0
+}
+
+func TestPersistentSortedMap_PutGet(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPersistentSortedMap_PutGet")
+
+	m0 := NewPersistentSortedMap[int, string](&intComparator{})
+	m1 := m0.Put(3, "c").Put(1, "a")
+	m2 := m1.Put(2, "b").Put(1, "aa")
+
+	assert.Equal(0, m0.Size())
+	assert.Equal([]int{1, 3}, m1.Keys())
+	assert.Equal([]int{1, 2, 3}, m2.Keys())
+	assert.Equal([]string{"aa", "b", "c"}, m2.Values())
+
+	v, _ := m1.Get(1)
+	assert.Equal("a", v)
+	v, _ = m2.Get(1)
+	assert.Equal("aa", v)
+	assert.Equal(false, m1.Contain(2))
+}
+
+func TestPersistentSortedMap_Delete(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPersistentSortedMap_Delete")
+
+	m1 := NewPersistentSortedMap[int, int](&intComparator{})
+	for i := 0; i < 10; i++ {
+		m1 = m1.Put(i, i)
+	}
+
+	m2 := m1.Delete(5).Delete(0)
+	assert.Equal(10, m1.Size())
+	assert.Equal([]int{1, 2, 3, 4, 6, 7, 8, 9}, m2.Keys())
+	assert.Equal(true, m2 == m2.Delete(5))
+}
+
+func TestPersistentSortedMap_FirstLastRange(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPersistentSortedMap_FirstLastRange")
+
+	m := NewPersistentSortedMap[int, int](&intComparator{})
+
+	_, _, ok := m.First()
+	assert.Equal(false, ok)
+
+	for i := 0; i < 20; i += 2 {
+		m = m.Put(i, i*10)
+	}
+
+	k, v, _ := m.First()
+	assert.Equal(0, k)
+	assert.Equal(0, v)
+
+	k, v, _ = m.Last()
+	assert.Equal(18, k)
+	assert.Equal(180, v)
+
+	keys := []int{}
+	m.Range(3, 11, func(key int, value int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal([]int{4, 6, 8, 10}, keys)
+
+	keys = []int{}
+	m.Range(3, 11, func(key int, value int) bool {
+		keys = append(keys, key)
+		return len(keys) < 2
+	})
+	assert.Equal([]int{4, 6}, keys)
+}
+
+func TestPersistentSortedMap_Random(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPersistentSortedMap_Random")
+
+	r := rand.New(rand.NewSource(1))
+	model := map[int]int{}
+	m := NewPersistentSortedMap[int, int](&intComparator{})
+	snapshot, snapshotSize := m, 0
+
+	for i := 0; i < 3000; i++ {
+		key := r.Intn(500)
+		if r.Intn(3) == 0 {
+			delete(model, key)
+			m = m.Delete(key)
+		} else {
+			model[key] = i
+			m = m.Put(key, i)
+		}
+		if i == 1000 {
+			snapshot, snapshotSize = m, len(model)
+		}
+	}
+
+	keys := make([]int, 0, len(model))
+	for k := range model {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	assert.Equal(keys, m.Keys())
+	assert.Equal(snapshotSize, snapshot.Size())
+	assert.Equal(snapshotSize, len(snapshot.Keys()))
+	assert.Equal(true, m.root.height <= 13)
+}
+
+func TestTransientSortedMap(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestTransientSortedMap")
+
+	base := NewPersistentSortedMap[int, int](&intComparator{}).Put(0, 0)
+
+	transient := base.Transient()
+	for i := 1; i < 100; i++ {
+		transient.Put(i, i)
+	}
+	assert.Equal(true, transient.Delete(0))
+	assert.Equal(false, transient.Delete(0))
+
+	snapshot := transient.Persistent()
+	transient.Put(1, 100)
+	transient.Delete(2)
+
+	assert.Equal(1, base.Size())
+	assert.Equal(99, snapshot.Size())
+	assert.Equal(98, transient.Size())
+
+	v, _ := snapshot.Get(1)
+	assert.Equal(1, v)
+	v, _ = transient.Get(1)
+	assert.Equal(100, v)
+	assert.Equal(true, snapshot.Contain(2))
+}
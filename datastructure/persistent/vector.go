@@ -0,0 +1,410 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+// Package datastructure contains some data structure. Persistent structure contains PersistentVector, PersistentHashMap and PersistentSortedMap.
+package datastructure
+
+import (
+	"errors"
+)
+
+const (
+	vectorBits  = 5
+	vectorWidth = 1 << vectorBits
+	vectorMask  = vectorWidth - 1
+)
+
+type vectorNode[T any] struct {
+	edit     *editToken
+	children []*vectorNode[T]
+	values   []T
+}
+
+// PersistentVector is an immutable vector implemented with 32-way trie and tail buffer.
+// Every modification returns a new version which shares structure with the old one,
+// Get, Set and Append are O(log32 n). The zero value is an empty vector.
+type PersistentVector[T any] struct {
+	size  int
+	shift uint
+	root  *vectorNode[T]
+	tail  []T
+}
+
+// NewPersistentVector return a PersistentVector pointer contains given values.
+func NewPersistentVector[T any](values ...T) *PersistentVector[T] {
+	transient := (&PersistentVector[T]{}).Transient()
+	for _, v := range values {
+		transient.Append(v)
+	}
+	return transient.Persistent()
+}
+
+// Size return the number of elements in vector
+func (v *PersistentVector[T]) Size() int {
+	return v.size
+}
+
+// IsEmpty checks if vector is empty or not
+func (v *PersistentVector[T]) IsEmpty() bool {
+	return v.size == 0
+}
+
+// Get return the element at index, if index is out of range, return zero value and false.
+func (v *PersistentVector[T]) Get(index int) (T, bool) {
+	var zero T
+	if index < 0 || index >= v.size {
+		return zero, false
+	}
+	return v.leafFor(index)[index&vectorMask], true
+}
+
+// Append return a new vector with value appended at the end.
+func (v *PersistentVector[T]) Append(value T) *PersistentVector[T] {
+	result := *v
+	result.appendValue(nil, value)
+	return &result
+}
+
+// Set return a new vector with element at index replaced by value,
+// if index equals to size, the value is appended. it returns error if index is out of range.
+func (v *PersistentVector[T]) Set(index int, value T) (*PersistentVector[T], error) {
+	if index == v.size {
+		return v.Append(value), nil
+	}
+	if index < 0 || index > v.size {
+		return v, errors.New("persistent vector: index out of range")
+	}
+
+	result := *v
+	result.setValue(nil, index, value)
+	return &result, nil
+}
+
+// Pop return a new vector with the last element removed, and the removed element.
+// if vector is empty, return itself, zero value and false.
+func (v *PersistentVector[T]) Pop() (*PersistentVector[T], T, bool) {
+	var zero T
+	if v.size == 0 {
+		return v, zero, false
+	}
+
+	last := v.tail[len(v.tail)-1]
+	result := *v
+	result.popValue(nil)
+	return &result, last, true
+}
+
+// ToSlice return a slice contains all elements of vector
+func (v *PersistentVector[T]) ToSlice() []T {
+	result := make([]T, 0, v.size)
+	v.Iterate(func(_ int, value T) bool {
+		result = append(result, value)
+		return true
+	})
+	return result
+}
+
+// Iterate call function by every element of vector in order,
+// when iteratee return false, will break the loop.
+func (v *PersistentVector[T]) Iterate(iteratee func(index int, value T) bool) {
+	for i := 0; i < v.size; i += vectorWidth {
+		leaf := v.leafFor(i)
+		for j, value := range leaf {
+			if !iteratee(i+j, value) {
+				return
+			}
+		}
+	}
+}
+
+// Transient return a mutable copy of vector for batch updates, the vector itself is not affected.
+func (v *PersistentVector[T]) Transient() *TransientVector[T] {
+	return &TransientVector[T]{vector: *v, edit: &editToken{}}
+}
+
+// TransientVector is a mutable builder of PersistentVector, it mutates nodes it owns in place,
+// so batch updates are much cheaper than updating a PersistentVector one by one.
+// TransientVector is not safe for concurrent use.
+type TransientVector[T any] struct {
+	vector    PersistentVector[T]
+	edit      *editToken
+	ownedTail bool
+}
+
+// Size return the number of elements in vector
+func (t *TransientVector[T]) Size() int {
+	return t.vector.size
+}
+
+// Get return the element at index, if index is out of range, return zero value and false.
+func (t *TransientVector[T]) Get(index int) (T, bool) {
+	return t.vector.Get(index)
+}
+
+// Append add value at the end of vector
+func (t *TransientVector[T]) Append(value T) *TransientVector[T] {
+	t.ensureTail()
+	t.vector.appendValue(t.edit, value)
+	return t
+}
+
+// Set replace the element at index with value, if index equals to size, the value is appended.
+// it returns error if index is out of range.
+func (t *TransientVector[T]) Set(index int, value T) error {
+	if index == t.vector.size {
+		t.Append(value)
+		return nil
+	}
+	if index < 0 || index > t.vector.size {
+		return errors.New("persistent vector: index out of range")
+	}
+
+	t.ensureTail()
+	t.vector.setValue(t.edit, index, value)
+	return nil
+}
+
+// Pop remove the last element and return it, if vector is empty, return zero value and false.
+func (t *TransientVector[T]) Pop() (T, bool) {
+	var zero T
+	if t.vector.size == 0 {
+		return zero, false
+	}
+
+	t.ensureTail()
+	last := t.vector.tail[len(t.vector.tail)-1]
+	t.vector.popValue(t.edit)
+	return last, true
+}
+
+// Persistent return an immutable snapshot of the transient vector.
+// the transient is still usable after that, later updates don't affect the returned vector.
+func (t *TransientVector[T]) Persistent() *PersistentVector[T] {
+	result := t.vector
+	if result.size > 0 {
+		tail := make([]T, len(result.tail))
+		copy(tail, result.tail)
+		result.tail = tail
+	}
+
+	t.edit = &editToken{}
+	t.ownedTail = false
+
+	return &result
+}
+
+// ensureTail make sure the tail buffer is owned by transient, so it can be appended in place.
+func (t *TransientVector[T]) ensureTail() {
+	if t.ownedTail {
+		return
+	}
+	tail := make([]T, len(t.vector.tail), vectorWidth)
+	copy(tail, t.vector.tail)
+	t.vector.tail = tail
+	t.ownedTail = true
+}
+
+func (v *PersistentVector[T]) tailOffset() int {
+	if v.size < vectorWidth {
+		return 0
+	}
+	return ((v.size - 1) >> vectorBits) << vectorBits
+}
+
+func (v *PersistentVector[T]) leafFor(index int) []T {
+	if index >= v.tailOffset() {
+		return v.tail
+	}
+
+	node := v.root
+	for level := v.shift; level > 0; level -= vectorBits {
+		node = node.children[(index>>level)&vectorMask]
+	}
+	return node.values
+}
+
+// appendValue append value in place, edit is nil for persistent update, tail is copied in that case.
+func (v *PersistentVector[T]) appendValue(edit *editToken, value T) {
+	if v.root == nil {
+		v.root = &vectorNode[T]{edit: edit}
+		v.shift = vectorBits
+	}
+
+	if v.size-v.tailOffset() < vectorWidth {
+		if edit == nil {
+			tail := make([]T, len(v.tail), len(v.tail)+1)
+			copy(tail, v.tail)
+			v.tail = tail
+		}
+		v.tail = append(v.tail, value)
+		v.size++
+		return
+	}
+
+	// tail is full, push it into the tree
+	tailNode := &vectorNode[T]{edit: edit, values: v.tail}
+	if (v.size >> vectorBits) > (1 << v.shift) {
+		v.root = &vectorNode[T]{
+			edit:     edit,
+			children: []*vectorNode[T]{v.root, newVectorPath(edit, v.shift, tailNode)},
+		}
+		v.shift += vectorBits
+	} else {
+		v.root = v.pushTail(edit, v.shift, v.root, tailNode)
+	}
+
+	if edit == nil {
+		v.tail = []T{value}
+	} else {
+		v.tail = make([]T, 1, vectorWidth)
+		v.tail[0] = value
+	}
+	v.size++
+}
+
+func (v *PersistentVector[T]) pushTail(edit *editToken, level uint, parent *vectorNode[T], tailNode *vectorNode[T]) *vectorNode[T] {
+	node := editableVectorNode(edit, parent)
+	subIndex := ((v.size - 1) >> level) & vectorMask
+
+	var child *vectorNode[T]
+	if level == vectorBits {
+		child = tailNode
+	} else if subIndex < len(node.children) {
+		child = v.pushTail(edit, level-vectorBits, node.children[subIndex], tailNode)
+	} else {
+		child = newVectorPath(edit, level-vectorBits, tailNode)
+	}
+
+	if subIndex < len(node.children) {
+		node.children[subIndex] = child
+	} else {
+		node.children = append(node.children, child)
+	}
+
+	return node
+}
+
+func (v *PersistentVector[T]) setValue(edit *editToken, index int, value T) {
+	if index >= v.tailOffset() {
+		if edit == nil {
+			tail := make([]T, len(v.tail))
+			copy(tail, v.tail)
+			v.tail = tail
+		}
+		v.tail[index&vectorMask] = value
+		return
+	}
+
+	v.root = setVectorValue(edit, v.shift, v.root, index, value)
+}
+
+func setVectorValue[T any](edit *editToken, level uint, node *vectorNode[T], index int, value T) *vectorNode[T] {
+	node = editableVectorNode(edit, node)
+	if level == 0 {
+		node.values[index&vectorMask] = value
+	} else {
+		subIndex := (index >> level) & vectorMask
+		node.children[subIndex] = setVectorValue(edit, level-vectorBits, node.children[subIndex], index, value)
+	}
+	return node
+}
+
+func (v *PersistentVector[T]) popValue(edit *editToken) {
+	var zero T
+
+	if v.size == 1 {
+		*v = PersistentVector[T]{}
+		return
+	}
+
+	if v.size-v.tailOffset() > 1 {
+		if edit == nil {
+			tail := make([]T, len(v.tail)-1)
+			copy(tail, v.tail)
+			v.tail = tail
+		} else {
+			v.tail[len(v.tail)-1] = zero
+			v.tail = v.tail[:len(v.tail)-1]
+		}
+		v.size--
+		return
+	}
+
+	// tail becomes empty, take the last leaf of tree as new tail
+	newTail := v.leafFor(v.size - 2)
+	if edit != nil {
+		tail := make([]T, len(newTail), vectorWidth)
+		copy(tail, newTail)
+		newTail = tail
+	}
+
+	root := v.popTail(edit, v.shift, v.root)
+	if root == nil {
+		root = &vectorNode[T]{edit: edit}
+	}
+	if v.shift > vectorBits && len(root.children) == 1 {
+		root = root.children[0]
+		v.shift -= vectorBits
+	}
+
+	v.root = root
+	v.tail = newTail
+	v.size--
+}
+
+func (v *PersistentVector[T]) popTail(edit *editToken, level uint, node *vectorNode[T]) *vectorNode[T] {
+	subIndex := ((v.size - 2) >> level) & vectorMask
+
+	if level > vectorBits {
+		child := v.popTail(edit, level-vectorBits, node.children[subIndex])
+		if child == nil && subIndex == 0 {
+			return nil
+		}
+
+		node = editableVectorNode(edit, node)
+		if child == nil {
+			node.children[subIndex] = nil
+			node.children = node.children[:subIndex]
+		} else {
+			node.children[subIndex] = child
+		}
+		return node
+	}
+
+	if subIndex == 0 {
+		return nil
+	}
+
+	node = editableVectorNode(edit, node)
+	node.children[subIndex] = nil
+	node.children = node.children[:subIndex]
+	return node
+}
+
+func newVectorPath[T any](edit *editToken, level uint, node *vectorNode[T]) *vectorNode[T] {
+	if level == 0 {
+		return node
+	}
+	return &vectorNode[T]{
+		edit:     edit,
+		children: []*vectorNode[T]{newVectorPath(edit, level-vectorBits, node)},
+	}
+}
+
+// editableVectorNode return node itself if it's owned by edit, or else return a copy owned by edit.
+func editableVectorNode[T any](edit *editToken, node *vectorNode[T]) *vectorNode[T] {
+	if canEdit(edit, node.edit) {
+		return node
+	}
+
+	result := &vectorNode[T]{edit: edit}
+	if node.children != nil {
+		result.children = make([]*vectorNode[T], len(node.children), vectorWidth)
+		copy(result.children, node.children)
+	}
+	if node.values != nil {
+		result.values = make([]T, len(node.values))
+		copy(result.values, node.values)
+	}
+	return result
+}
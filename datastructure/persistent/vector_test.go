@@ -0,0 +1,173 @@
+package datastructure
+
+import (
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestPersistentVector_Append(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPersistentVector_Append")
+
+	v0 := NewPersistentVector[int]()
+	v1 := v0.Append(1)
+	v2 := v1.Append(2)
+
+	assert.Equal(0, v0.Size())
+	assert.Equal([]int{1}, v1.ToSlice())
+	assert.Equal([]int{1, 2}, v2.ToSlice())
+
+	var zero PersistentVector[string]
+	assert.Equal(true, zero.IsEmpty())
+	assert.Equal([]string{"a"}, zero.Append("a").ToSlice())
+}
+
+func TestPersistentVector_Large(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPersistentVector_Large")
+
+	const n = 40000
+
+	versions := []*PersistentVector[int]{}
+	v := NewPersistentVector[int]()
+	for i := 0; i < n; i++ {
+		v = v.Append(i)
+		if i%10000 == 0 {
+			versions = append(versions, v)
+		}
+	}
+
+	assert.Equal(n, v.Size())
+	for i := 0; i < n; i++ {
+		value, ok := v.Get(i)
+		if !ok || value != i {
+			t.Fatalf("expected %d at index %d, got %d", i, i, value)
+		}
+	}
+
+	for i, version := range versions {
+		assert.Equal(i*10000+1, version.Size())
+	}
+
+	_, ok := v.Get(n)
+	assert.Equal(false, ok)
+}
+
+func TestPersistentVector_Set(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPersistentVector_Set")
+
+	v1 := NewPersistentVector[int]()
+	for i := 0; i < 1100; i++ {
+		v1 = v1.Append(i)
+	}
+
+	v2, err := v1.Set(5, -5)
+	assert.IsNil(err)
+	v3, _ := v2.Set(1099, -1099)
+	v4, _ := v3.Set(1100, 1100)
+
+	_, err = v1.Set(2000, 0)
+	assert.IsNotNil(err)
+
+	value, _ := v1.Get(5)
+	assert.Equal(5, value)
+	value, _ = v2.Get(5)
+	assert.Equal(-5, value)
+	value, _ = v2.Get(1099)
+	assert.Equal(1099, value)
+	value, _ = v3.Get(1099)
+	assert.Equal(-1099, value)
+	assert.Equal(1101, v4.Size())
+}
+
+func TestPersistentVector_Pop(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPersistentVector_Pop")
+
+	const n = 1100
+
+	v := NewPersistentVector[int]()
+	for i := 0; i < n; i++ {
+		v = v.Append(i)
+	}
+	origin := v
+
+	for i := n - 1; i >= 0; i-- {
+		var last int
+		var ok bool
+		v, last, ok = v.Pop()
+		assert.Equal(true, ok)
+		assert.Equal(i, last)
+		assert.Equal(i, v.Size())
+		if i > 0 {
+			value, _ := v.Get(i - 1)
+			assert.Equal(i-1, value)
+		}
+	}
+
+	_, _, ok := v.Pop()
+	assert.Equal(false, ok)
+	assert.Equal(n, origin.Size())
+
+	value, _ := origin.Get(n - 1)
+	assert.Equal(n-1, value)
+}
+
+func TestTransientVector(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestTransientVector")
+
+	base := NewPersistentVector(1, 2, 3)
+
+	transient := base.Transient()
+	for i := 4; i <= 2000; i++ {
+		transient.Append(i)
+	}
+	assert.IsNil(transient.Set(0, 100))
+	assert.IsNotNil(transient.Set(5000, 0))
+
+	last, ok := transient.Pop()
+	assert.Equal(2000, last)
+	assert.Equal(true, ok)
+
+	snapshot := transient.Persistent()
+	transient.Set(1, 200)
+	transient.Append(2001)
+
+	assert.Equal([]int{1, 2, 3}, base.ToSlice())
+	assert.Equal(1999, snapshot.Size())
+
+	value, _ := snapshot.Get(0)
+	assert.Equal(100, value)
+	value, _ = snapshot.Get(1)
+	assert.Equal(2, value)
+
+	value, _ = transient.Get(1)
+	assert.Equal(200, value)
+	assert.Equal(2000, transient.Size())
+}
+
+func TestPersistentVector_Iterate(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPersistentVector_Iterate")
+
+	v := NewPersistentVector[int]()
+	for i := 0; i < 100; i++ {
+		v = v.Append(i)
+	}
+
+	sum := 0
+	v.Iterate(func(index int, value int) bool {
+		sum += value
+		return index < 49
+	})
+	assert.Equal(1225, sum)
+}
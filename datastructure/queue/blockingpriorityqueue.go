@@ -0,0 +1,102 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package datastructure
+
+import (
+	"github.com/duke-git/lancet/v2/constraints"
+)
+
+// BlockingPriorityQueue is a priority queue which is safe for concurrent use,
+// Take always returns the max value of the queue according to comparator.
+// type T should implements Compare function in constraints.Comparator interface.
+type BlockingPriorityQueue[T any] struct {
+	BlockingQueue[T]
+}
+
+// NewBlockingPriorityQueue return a BlockingPriorityQueue pointer,
+// param `capacity` is the max number of elements, if capacity <= 0, the queue is unbounded.
+// param `comparator` is used to compare values in the queue.
+func NewBlockingPriorityQueue[T any](capacity int, comparator constraints.Comparator) *BlockingPriorityQueue[T] {
+	items := &heapContainer[T]{
+		less: func(a, b T) bool {
+			return comparator.Compare(a, b) > 0
+		},
+	}
+
+	q := &BlockingPriorityQueue[T]{}
+	q.init(capacity, items)
+
+	return q
+}
+
+// heapContainer is a binary heap container, the top element is the one which is less than all others.
+type heapContainer[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (h *heapContainer[T]) push(value T) {
+	h.items = append(h.items, value)
+	h.up(len(h.items) - 1)
+}
+
+func (h *heapContainer[T]) pop() T {
+	var zero T
+
+	n := len(h.items) - 1
+	top := h.items[0]
+	h.items[0] = h.items[n]
+	h.items[n] = zero
+	h.items = h.items[:n]
+	h.down(0)
+
+	return top
+}
+
+func (h *heapContainer[T]) peek() T {
+	return h.items[0]
+}
+
+func (h *heapContainer[T]) len() int {
+	return len(h.items)
+}
+
+func (h *heapContainer[T]) values() []T {
+	values := make([]T, len(h.items))
+	copy(values, h.items)
+	return values
+}
+
+func (h *heapContainer[T]) clear() {
+	h.items = nil
+}
+
+func (h *heapContainer[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.items[i], h.items[parent]) {
+			break
+		}
+		h.items[i], h.items[parent] = h.items[parent], h.items[i]
+		i = parent
+	}
+}
+
+func (h *heapContainer[T]) down(i int) {
+	n := len(h.items)
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && h.less(h.items[right], h.items[child]) {
+			child = right
+		}
+		if !h.less(h.items[child], h.items[i]) {
+			break
+		}
+		h.items[i], h.items[child] = h.items[child], h.items[i]
+		i = child
+	}
+}
@@ -0,0 +1,50 @@
+package datastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestBlockingPriorityQueue_PutTake(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBlockingPriorityQueue_PutTake")
+
+	q := NewBlockingPriorityQueue[int](0, &intComparator{})
+	ctx := context.Background()
+
+	for _, v := range []int{5, 1, 8, 3, 9, 2} {
+		assert.IsNil(q.Put(ctx, v))
+	}
+
+	head, _ := q.Peek()
+	assert.Equal(9, head)
+
+	result := []int{}
+	for !q.IsEmpty() {
+		v, err := q.Take(ctx)
+		assert.IsNil(err)
+		result = append(result, v)
+	}
+
+	assert.Equal([]int{9, 8, 5, 3, 2, 1}, result)
+}
+
+func TestBlockingPriorityQueue_Capacity(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBlockingPriorityQueue_Capacity")
+
+	q := NewBlockingPriorityQueue[int](2, &intComparator{})
+
+	assert.Equal(true, q.TryPut(1))
+	assert.Equal(true, q.TryPut(2))
+	assert.Equal(false, q.Offer(3, 10*time.Millisecond))
+
+	v, ok := q.Poll(10 * time.Millisecond)
+	assert.Equal(2, v)
+	assert.Equal(true, ok)
+}
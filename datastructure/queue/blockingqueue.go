@@ -0,0 +1,279 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package datastructure
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// container is the underlying storage of blocking queues.
+type container[T any] interface {
+	push(value T)
+	pop() T
+	peek() T
+	len() int
+	values() []T
+	clear()
+}
+
+// dequeContainer is a fifo container.
+type dequeContainer[T any] struct {
+	deque *Deque[T]
+}
+
+func (c *dequeContainer[T]) push(value T) {
+	c.deque.PushBack(value)
+}
+
+func (c *dequeContainer[T]) pop() T {
+	value, _ := c.deque.PopFront()
+	return value
+}
+
+func (c *dequeContainer[T]) peek() T {
+	value, _ := c.deque.Front()
+	return value
+}
+
+func (c *dequeContainer[T]) len() int {
+	return c.deque.Size()
+}
+
+func (c *dequeContainer[T]) values() []T {
+	return c.deque.Data()
+}
+
+func (c *dequeContainer[T]) clear() {
+	c.deque.Clear()
+}
+
+// BlockingQueue is a fifo queue which is safe for concurrent use, it's suitable for producer/consumer scenario.
+// Put blocks while the queue is full and Take blocks while the queue is empty,
+// both of them can be canceled by context.
+type BlockingQueue[T any] struct {
+	mu       sync.Mutex
+	items    container[T]
+	capacity int
+	notEmpty chan struct{}
+	notFull  chan struct{}
+
+	// number of goroutines blocked in Take and Put, channels are only replaced when someone is waiting.
+	takeWaiters int
+	putWaiters  int
+}
+
+// NewBlockingQueue return a BlockingQueue pointer,
+// param `capacity` is the max number of elements, if capacity <= 0, the queue is unbounded.
+func NewBlockingQueue[T any](capacity int) *BlockingQueue[T] {
+	q := &BlockingQueue[T]{}
+	// the deque grows on demand, capacity is only the bound of queue.
+	q.init(capacity, &dequeContainer[T]{deque: NewDeque[T](0)})
+	return q
+}
+
+func (q *BlockingQueue[T]) init(capacity int, items container[T]) {
+	q.items = items
+	q.capacity = capacity
+	q.notEmpty = make(chan struct{})
+	q.notFull = make(chan struct{})
+}
+
+// Put insert value into queue, it blocks until there is space in the queue or ctx is done.
+func (q *BlockingQueue[T]) Put(ctx context.Context, value T) error {
+	q.mu.Lock()
+	for q.isFull() {
+		wait := q.notFull
+		q.putWaiters++
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			q.mu.Lock()
+			q.putWaiters--
+			q.mu.Unlock()
+			return ctx.Err()
+		case <-wait:
+		}
+
+		q.mu.Lock()
+		q.putWaiters--
+	}
+
+	q.push(value)
+	q.mu.Unlock()
+
+	return nil
+}
+
+// Take remove the head value of queue and return it, it blocks until the queue is not empty or ctx is done.
+func (q *BlockingQueue[T]) Take(ctx context.Context) (T, error) {
+	q.mu.Lock()
+	for q.items.len() == 0 {
+		wait := q.notEmpty
+		q.takeWaiters++
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			q.mu.Lock()
+			q.takeWaiters--
+			q.mu.Unlock()
+			var zero T
+			return zero, ctx.Err()
+		case <-wait:
+		}
+
+		q.mu.Lock()
+		q.takeWaiters--
+	}
+
+	value := q.pop()
+	q.mu.Unlock()
+
+	return value, nil
+}
+
+// Offer insert value into queue, waiting up to the timeout for space to become available.
+// it returns false if the queue is still full after timeout.
+func (q *BlockingQueue[T]) Offer(value T, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return q.Put(ctx, value) == nil
+}
+
+// Poll remove the head value of queue and return it, waiting up to the timeout for an element to become available.
+// it returns zero value and false if the queue is still empty after timeout.
+func (q *BlockingQueue[T]) Poll(timeout time.Duration) (T, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	value, err := q.Take(ctx)
+	return value, err == nil
+}
+
+// TryPut insert value into queue without blocking, return false if the queue is full.
+func (q *BlockingQueue[T]) TryPut(value T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.isFull() {
+		return false
+	}
+	q.push(value)
+
+	return true
+}
+
+// TryTake remove the head value of queue without blocking, return zero value and false if the queue is empty.
+func (q *BlockingQueue[T]) TryTake() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.items.len() == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return q.pop(), true
+}
+
+// Peek return the head value of queue without removing it, return zero value and false if the queue is empty.
+func (q *BlockingQueue[T]) Peek() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.items.len() == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return q.items.peek(), true
+}
+
+// Size return number of elements in queue
+func (q *BlockingQueue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.items.len()
+}
+
+// IsEmpty checks if queue is empty or not
+func (q *BlockingQueue[T]) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+// IsFull checks if queue is full or not, an unbounded queue is never full.
+func (q *BlockingQueue[T]) IsFull() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.isFull()
+}
+
+// Capacity return the max number of elements of queue, 0 means unbounded.
+func (q *BlockingQueue[T]) Capacity() int {
+	if q.capacity <= 0 {
+		return 0
+	}
+	return q.capacity
+}
+
+// RemainingCapacity return the number of elements that the queue can accept without blocking,
+// return -1 if the queue is unbounded.
+func (q *BlockingQueue[T]) RemainingCapacity() int {
+	if q.capacity <= 0 {
+		return -1
+	}
+	return q.capacity - q.Size()
+}
+
+// Data return a snapshot slice of queue data
+func (q *BlockingQueue[T]) Data() []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.items.values()
+}
+
+// Clear the queue data, all blocked Put calls will be woken up.
+func (q *BlockingQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.items.clear()
+	if q.putWaiters > 0 {
+		broadcast(&q.notFull)
+	}
+}
+
+func (q *BlockingQueue[T]) isFull() bool {
+	return q.capacity > 0 && q.items.len() >= q.capacity
+}
+
+// push should be called with lock held.
+func (q *BlockingQueue[T]) push(value T) {
+	q.items.push(value)
+	if q.takeWaiters > 0 {
+		broadcast(&q.notEmpty)
+	}
+}
+
+// pop should be called with lock held.
+func (q *BlockingQueue[T]) pop() T {
+	value := q.items.pop()
+	if q.putWaiters > 0 {
+		broadcast(&q.notFull)
+	}
+	return value
+}
+
+// broadcast wakes up all goroutines waiting on the channel and replaces it with a new one.
+func broadcast(ch *chan struct{}) {
+	close(*ch)
+	*ch = make(chan struct{})
+}
@@ -0,0 +1,174 @@
+package datastructure
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestBlockingQueue_PutTake(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBlockingQueue_PutTake")
+
+	q := NewBlockingQueue[int](2)
+	ctx := context.Background()
+
+	assert.IsNil(q.Put(ctx, 1))
+	assert.IsNil(q.Put(ctx, 2))
+	assert.Equal(true, q.IsFull())
+	assert.Equal([]int{1, 2}, q.Data())
+
+	v, err := q.Take(ctx)
+	assert.IsNil(err)
+	assert.Equal(1, v)
+
+	v, _ = q.Take(ctx)
+	assert.Equal(2, v)
+	assert.Equal(true, q.IsEmpty())
+}
+
+func TestBlockingQueue_LargeCapacity(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBlockingQueue_LargeCapacity")
+
+	// the storage isn't allocated up to capacity.
+	q := NewBlockingQueue[int](1 << 20)
+	deque := q.items.(*dequeContainer[int]).deque
+	assert.Equal(minDequeCapacity, len(deque.data))
+
+	ctx := context.Background()
+	for i := 0; i < 100; i++ {
+		assert.IsNil(q.Put(ctx, i))
+	}
+	assert.Equal(100, q.Size())
+	assert.Equal(true, len(deque.data) < 1<<10)
+}
+
+func TestBlockingQueue_Timeout(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBlockingQueue_Timeout")
+
+	q := NewBlockingQueue[int](1)
+
+	_, ok := q.Poll(10 * time.Millisecond)
+	assert.Equal(false, ok)
+
+	assert.Equal(true, q.Offer(1, 10*time.Millisecond))
+	assert.Equal(false, q.Offer(2, 10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := q.Put(ctx, 3)
+	assert.Equal(context.Canceled, err)
+
+	v, ok := q.Poll(10 * time.Millisecond)
+	assert.Equal(1, v)
+	assert.Equal(true, ok)
+}
+
+func TestBlockingQueue_TryPutTryTake(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBlockingQueue_TryPutTryTake")
+
+	q := NewBlockingQueue[string](1)
+	assert.Equal(1, q.Capacity())
+	assert.Equal(1, q.RemainingCapacity())
+
+	assert.Equal(true, q.TryPut("a"))
+	assert.Equal(false, q.TryPut("b"))
+	assert.Equal(0, q.RemainingCapacity())
+
+	v, ok := q.Peek()
+	assert.Equal("a", v)
+	assert.Equal(true, ok)
+
+	v, ok = q.TryTake()
+	assert.Equal("a", v)
+	assert.Equal(true, ok)
+
+	_, ok = q.TryTake()
+	assert.Equal(false, ok)
+
+	unbounded := NewBlockingQueue[int](0)
+	assert.Equal(-1, unbounded.RemainingCapacity())
+	for i := 0; i < 100; i++ {
+		assert.Equal(true, unbounded.TryPut(i))
+	}
+	assert.Equal(false, unbounded.IsFull())
+}
+
+func TestBlockingQueue_Blocking(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBlockingQueue_Blocking")
+
+	q := NewBlockingQueue[int](1)
+	ctx := context.Background()
+
+	done := make(chan int)
+	go func() {
+		v, _ := q.Take(ctx)
+		done <- v
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	q.Put(ctx, 1)
+	assert.Equal(1, <-done)
+
+	q.Put(ctx, 2)
+	putDone := make(chan struct{})
+	go func() {
+		q.Put(ctx, 3)
+		close(putDone)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	q.Clear()
+	<-putDone
+	assert.Equal([]int{3}, q.Data())
+}
+
+func TestBlockingQueue_ProducerConsumer(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBlockingQueue_ProducerConsumer")
+
+	q := NewBlockingQueue[int](4)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				q.Put(ctx, n*100+j)
+			}
+		}(i)
+	}
+
+	results := make(chan int, 400)
+	for i := 0; i < 4; i++ {
+		go func() {
+			for j := 0; j < 100; j++ {
+				v, _ := q.Take(ctx)
+				results <- v
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	seen := map[int]bool{}
+	for i := 0; i < 400; i++ {
+		seen[<-results] = true
+	}
+	assert.Equal(400, len(seen))
+}
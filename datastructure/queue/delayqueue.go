@@ -0,0 +1,236 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package datastructure
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DelayQueue is a queue which is safe for concurrent use, an element can only be taken when its delay has expired.
+// The head of the queue is the element whose delay expired furthest in the past.
+type DelayQueue[T any] struct {
+	mu       sync.Mutex
+	items    *heapContainer[delayItem[T]]
+	capacity int
+	changed  chan struct{}
+	notFull  chan struct{}
+
+	takeWaiters int
+	putWaiters  int
+}
+
+type delayItem[T any] struct {
+	value    T
+	deadline time.Time
+}
+
+// NewDelayQueue return a DelayQueue pointer,
+// param `capacity` is the max number of elements, if capacity <= 0, the queue is unbounded.
+func NewDelayQueue[T any](capacity int) *DelayQueue[T] {
+	return &DelayQueue[T]{
+		items: &heapContainer[delayItem[T]]{
+			less: func(a, b delayItem[T]) bool {
+				return a.deadline.Before(b.deadline)
+			},
+		},
+		capacity: capacity,
+		changed:  make(chan struct{}),
+		notFull:  make(chan struct{}),
+	}
+}
+
+// Put insert value into queue, the value can be taken after delay.
+// it blocks until there is space in the queue or ctx is done.
+func (q *DelayQueue[T]) Put(ctx context.Context, value T, delay time.Duration) error {
+	q.mu.Lock()
+	for q.isFull() {
+		wait := q.notFull
+		q.putWaiters++
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			q.mu.Lock()
+			q.putWaiters--
+			q.mu.Unlock()
+			return ctx.Err()
+		case <-wait:
+		}
+
+		q.mu.Lock()
+		q.putWaiters--
+	}
+
+	q.push(value, delay)
+	q.mu.Unlock()
+
+	return nil
+}
+
+// TryPut insert value into queue without blocking, return false if the queue is full.
+func (q *DelayQueue[T]) TryPut(value T, delay time.Duration) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.isFull() {
+		return false
+	}
+	q.push(value, delay)
+
+	return true
+}
+
+// Take remove the head value of queue and return it,
+// it blocks until there is an element whose delay has expired or ctx is done.
+func (q *DelayQueue[T]) Take(ctx context.Context) (T, error) {
+	var timer *time.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	q.mu.Lock()
+	for {
+		var expired <-chan time.Time
+		if q.items.len() > 0 {
+			wait := time.Until(q.items.peek().deadline)
+			if wait <= 0 {
+				break
+			}
+
+			if timer == nil {
+				timer = time.NewTimer(wait)
+			} else {
+				timer.Reset(wait)
+			}
+			expired = timer.C
+		}
+
+		changed := q.changed
+		q.takeWaiters++
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			q.mu.Lock()
+			q.takeWaiters--
+			q.mu.Unlock()
+			var zero T
+			return zero, ctx.Err()
+		case <-changed:
+		case <-expired:
+		}
+
+		if timer != nil && !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+
+		q.mu.Lock()
+		q.takeWaiters--
+	}
+
+	value := q.pop()
+	q.mu.Unlock()
+
+	return value, nil
+}
+
+// Poll remove the head value of queue and return it, waiting up to the timeout for an element to expire.
+// it returns zero value and false if there is no expired element after timeout.
+func (q *DelayQueue[T]) Poll(timeout time.Duration) (T, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	value, err := q.Take(ctx)
+	return value, err == nil
+}
+
+// TryTake remove the head value of queue without blocking,
+// return zero value and false if there is no expired element.
+func (q *DelayQueue[T]) TryTake() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.items.len() == 0 || time.Now().Before(q.items.peek().deadline) {
+		var zero T
+		return zero, false
+	}
+
+	return q.pop(), true
+}
+
+// Peek return the head value of queue and its remaining delay without removing it,
+// the remaining delay is negative if the element has already expired.
+// return zero value and false if the queue is empty.
+func (q *DelayQueue[T]) Peek() (T, time.Duration, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.items.len() == 0 {
+		var zero T
+		return zero, 0, false
+	}
+
+	head := q.items.peek()
+	return head.value, time.Until(head.deadline), true
+}
+
+// Size return number of elements in queue, including unexpired ones.
+func (q *DelayQueue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.items.len()
+}
+
+// IsEmpty checks if queue is empty or not
+func (q *DelayQueue[T]) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+// IsFull checks if queue is full or not, an unbounded queue is never full.
+func (q *DelayQueue[T]) IsFull() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.isFull()
+}
+
+// Clear the queue data, all blocked Put calls will be woken up.
+func (q *DelayQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.items.clear()
+	if q.putWaiters > 0 {
+		broadcast(&q.notFull)
+	}
+}
+
+func (q *DelayQueue[T]) isFull() bool {
+	return q.capacity > 0 && q.items.len() >= q.capacity
+}
+
+// push should be called with lock held.
+func (q *DelayQueue[T]) push(value T, delay time.Duration) {
+	q.items.push(delayItem[T]{value: value, deadline: time.Now().Add(delay)})
+	if q.takeWaiters > 0 {
+		broadcast(&q.changed)
+	}
+}
+
+// pop should be called with lock held.
+func (q *DelayQueue[T]) pop() T {
+	item := q.items.pop()
+	if q.putWaiters > 0 {
+		broadcast(&q.notFull)
+	}
+	return item.value
+}
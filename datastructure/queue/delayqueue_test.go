@@ -0,0 +1,86 @@
+package datastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestDelayQueue_Take(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDelayQueue_Take")
+
+	q := NewDelayQueue[string](0)
+	ctx := context.Background()
+
+	q.Put(ctx, "b", 40*time.Millisecond)
+	q.Put(ctx, "a", 20*time.Millisecond)
+
+	_, ok := q.TryTake()
+	assert.Equal(false, ok)
+
+	start := time.Now()
+	v, err := q.Take(ctx)
+	assert.IsNil(err)
+	assert.Equal("a", v)
+	assert.Equal(true, time.Since(start) >= 15*time.Millisecond)
+
+	v, _ = q.Take(ctx)
+	assert.Equal("b", v)
+	assert.Equal(true, q.IsEmpty())
+}
+
+func TestDelayQueue_EarlierElement(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDelayQueue_EarlierElement")
+
+	q := NewDelayQueue[int](0)
+	ctx := context.Background()
+
+	q.Put(ctx, 1, time.Hour)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.Put(ctx, 2, 0)
+	}()
+
+	v, ok := q.Poll(time.Second)
+	assert.Equal(2, v)
+	assert.Equal(true, ok)
+
+	head, delay, ok := q.Peek()
+	assert.Equal(1, head)
+	assert.Equal(true, delay > 0)
+	assert.Equal(true, ok)
+}
+
+func TestDelayQueue_Timeout(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDelayQueue_Timeout")
+
+	q := NewDelayQueue[int](1)
+	ctx := context.Background()
+
+	_, ok := q.Poll(10 * time.Millisecond)
+	assert.Equal(false, ok)
+
+	assert.Equal(true, q.TryPut(1, time.Hour))
+	assert.Equal(false, q.TryPut(2, 0))
+	assert.Equal(true, q.IsFull())
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	err := q.Put(timeoutCtx, 2, 0)
+	assert.Equal(context.DeadlineExceeded, err)
+
+	_, err = q.Take(timeoutCtx)
+	assert.Equal(context.DeadlineExceeded, err)
+
+	q.Clear()
+	assert.Equal(0, q.Size())
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package datastructure
+
+import (
+	"fmt"
+	"reflect"
+)
+
+const minDequeCapacity = 8
+
+// Deque implements double-ended queue with ring buffer,
+// push and pop at both ends are amortized O(1), the buffer grows and shrinks automatically.
+type Deque[T any] struct {
+	data []T
+	head int
+	size int
+}
+
+// NewDeque return a empty Deque pointer, param `capacity` is the initial capacity of the buffer.
+func NewDeque[T any](capacity int) *Deque[T] {
+	if capacity < minDequeCapacity {
+		capacity = minDequeCapacity
+	}
+	return &Deque[T]{data: make([]T, capacity)}
+}
+
+// Size return number of elements in deque
+func (d *Deque[T]) Size() int {
+	return d.size
+}
+
+// IsEmpty checks if deque is empty or not
+func (d *Deque[T]) IsEmpty() bool {
+	return d.size == 0
+}
+
+// PushFront insert element at the front of deque
+func (d *Deque[T]) PushFront(value T) {
+	d.grow()
+	d.head = d.index(len(d.data) - 1)
+	d.data[d.head] = value
+	d.size++
+}
+
+// PushBack insert element at the back of deque
+func (d *Deque[T]) PushBack(value T) {
+	d.grow()
+	d.data[d.index(d.size)] = value
+	d.size++
+}
+
+// PopFront remove the front element of deque and return it, if deque is empty, return zero value and false
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.size == 0 {
+		return zero, false
+	}
+
+	value := d.data[d.head]
+	d.data[d.head] = zero
+	d.head = d.index(1)
+	d.size--
+	d.shrink()
+
+	return value, true
+}
+
+// PopBack remove the back element of deque and return it, if deque is empty, return zero value and false
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.size == 0 {
+		return zero, false
+	}
+
+	i := d.index(d.size - 1)
+	value := d.data[i]
+	d.data[i] = zero
+	d.size--
+	d.shrink()
+
+	return value, true
+}
+
+// Front return the front element of deque, if deque is empty, return zero value and false
+func (d *Deque[T]) Front() (T, bool) {
+	return d.Get(0)
+}
+
+// Back return the back element of deque, if deque is empty, return zero value and false
+func (d *Deque[T]) Back() (T, bool) {
+	return d.Get(d.size - 1)
+}
+
+// Get return the element at index (0 is the front), if index is out of range, return zero value and false
+func (d *Deque[T]) Get(index int) (T, bool) {
+	var zero T
+	if index < 0 || index >= d.size {
+		return zero, false
+	}
+	return d.data[d.index(index)], true
+}
+
+// Data return slice of deque data from front to back
+func (d *Deque[T]) Data() []T {
+	data := make([]T, d.size)
+	for i := 0; i < d.size; i++ {
+		data[i] = d.data[d.index(i)]
+	}
+	return data
+}
+
+// Clear the deque data
+func (d *Deque[T]) Clear() {
+	d.data = make([]T, minDequeCapacity)
+	d.head = 0
+	d.size = 0
+}
+
+// Contain checks if the value is in deque or not
+func (d *Deque[T]) Contain(value T) bool {
+	for i := 0; i < d.size; i++ {
+		if reflect.DeepEqual(d.data[d.index(i)], value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Print deque data
+func (d *Deque[T]) Print() {
+	fmt.Printf("%+v\n", d.Data())
+}
+
+// index convert logical index to physical index of ring buffer
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) % len(d.data)
+}
+
+// grow double the buffer when it's full
+func (d *Deque[T]) grow() {
+	if d.size < len(d.data) {
+		return
+	}
+	d.resize(len(d.data) * 2)
+}
+
+// shrink halve the buffer when it's only a quarter full
+func (d *Deque[T]) shrink() {
+	if len(d.data) > minDequeCapacity && d.size <= len(d.data)/4 {
+		d.resize(len(d.data) / 2)
+	}
+}
+
+func (d *Deque[T]) resize(capacity int) {
+	data := make([]T, capacity)
+	for i := 0; i < d.size; i++ {
+		data[i] = d.data[d.index(i)]
+	}
+	d.data = data
+	d.head = 0
+}
@@ -0,0 +1,102 @@
+package datastructure
+
+import (
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestDeque_Push(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDeque_Push")
+
+	deque := NewDeque[int](0)
+	deque.PushBack(2)
+	deque.PushBack(3)
+	deque.PushFront(1)
+
+	assert.Equal([]int{1, 2, 3}, deque.Data())
+	assert.Equal(3, deque.Size())
+
+	front, _ := deque.Front()
+	back, _ := deque.Back()
+	assert.Equal(1, front)
+	assert.Equal(3, back)
+}
+
+func TestDeque_Pop(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDeque_Pop")
+
+	deque := NewDeque[int](0)
+
+	_, ok := deque.PopFront()
+	assert.Equal(false, ok)
+	_, ok = deque.PopBack()
+	assert.Equal(false, ok)
+
+	deque.PushBack(1)
+	deque.PushBack(2)
+	deque.PushBack(3)
+
+	v, ok := deque.PopFront()
+	assert.Equal(1, v)
+	assert.Equal(true, ok)
+
+	v, ok = deque.PopBack()
+	assert.Equal(3, v)
+	assert.Equal(true, ok)
+
+	assert.Equal([]int{2}, deque.Data())
+}
+
+func TestDeque_GrowAndShrink(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDeque_GrowAndShrink")
+
+	deque := NewDeque[int](0)
+	for i := 0; i < 100; i++ {
+		if i%2 == 0 {
+			deque.PushBack(i)
+		} else {
+			deque.PushFront(i)
+		}
+	}
+
+	assert.Equal(100, deque.Size())
+	assert.Equal(true, len(deque.data) >= 100)
+
+	first, _ := deque.Get(0)
+	last, _ := deque.Get(99)
+	assert.Equal(99, first)
+	assert.Equal(98, last)
+
+	for i := 0; i < 95; i++ {
+		deque.PopFront()
+	}
+	assert.Equal(5, deque.Size())
+	assert.Equal(true, len(deque.data) < 100)
+	assert.Equal([]int{90, 92, 94, 96, 98}, deque.Data())
+}
+
+func TestDeque_Contain(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDeque_Contain")
+
+	deque := NewDeque[string](4)
+	deque.PushBack("a")
+	deque.PushFront("b")
+
+	assert.Equal(true, deque.Contain("a"))
+	assert.Equal(false, deque.Contain("c"))
+
+	_, ok := deque.Get(2)
+	assert.Equal(false, ok)
+
+	deque.Clear()
+	assert.Equal(true, deque.IsEmpty())
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package datastructure
+
+import (
+	"sync/atomic"
+)
+
+// cacheLinePad is used to prevent false sharing between the enqueue and dequeue positions.
+type cacheLinePad [64]byte
+
+type lockFreeCell[T any] struct {
+	sequence atomic.Uint64
+	value    T
+}
+
+// LockFreeQueue is a bounded multi-producer/multi-consumer queue implemented with ring buffer and sync/atomic,
+// it's based on Dmitry Vyukov's bounded MPMC queue algorithm.
+// Enqueue and Dequeue never block, they return false when the queue is full or empty.
+type LockFreeQueue[T any] struct {
+	_          cacheLinePad
+	buffer     []lockFreeCell[T]
+	mask       uint64
+	_          cacheLinePad
+	enqueuePos atomic.Uint64
+	_          cacheLinePad
+	dequeuePos atomic.Uint64
+	_          cacheLinePad
+}
+
+// NewLockFreeQueue return a LockFreeQueue pointer,
+// param `capacity` is rounded up to the next power of two, the minimum capacity is 2.
+func NewLockFreeQueue[T any](capacity int) *LockFreeQueue[T] {
+	size := 2
+	for size < capacity {
+		size <<= 1
+	}
+
+	q := &LockFreeQueue[T]{
+		buffer: make([]lockFreeCell[T], size),
+		mask:   uint64(size - 1),
+	}
+	for i := range q.buffer {
+		q.buffer[i].sequence.Store(uint64(i))
+	}
+
+	return q
+}
+
+// Enqueue put element into queue, return false if the queue is full.
+func (q *LockFreeQueue[T]) Enqueue(value T) bool {
+	pos := q.enqueuePos.Load()
+	for {
+		cell := &q.buffer[pos&q.mask]
+		seq := cell.sequence.Load()
+		diff := int64(seq) - int64(pos)
+
+		switch {
+		case diff == 0:
+			if q.enqueuePos.CompareAndSwap(pos, pos+1) {
+				cell.value = value
+				cell.sequence.Store(pos + 1)
+				return true
+			}
+			pos = q.enqueuePos.Load()
+		case diff < 0:
+			return false
+		default:
+			pos = q.enqueuePos.Load()
+		}
+	}
+}
+
+// Dequeue remove head element of queue and return it, return zero value and false if the queue is empty.
+func (q *LockFreeQueue[T]) Dequeue() (T, bool) {
+	var zero T
+
+	pos := q.dequeuePos.Load()
+	for {
+		cell := &q.buffer[pos&q.mask]
+		seq := cell.sequence.Load()
+		diff := int64(seq) - int64(pos+1)
+
+		switch {
+		case diff == 0:
+			if q.dequeuePos.CompareAndSwap(pos, pos+1) {
+				value := cell.value
+				cell.value = zero
+				cell.sequence.Store(pos + q.mask + 1)
+				return value, true
+			}
+			pos = q.dequeuePos.Load()
+		case diff < 0:
+			return zero, false
+		default:
+			pos = q.dequeuePos.Load()
+		}
+	}
+}
+
+// Size return number of elements in queue,
+// the result is approximate when there are concurrent Enqueue or Dequeue calls.
+func (q *LockFreeQueue[T]) Size() int {
+	for {
+		dequeuePos := q.dequeuePos.Load()
+		enqueuePos := q.enqueuePos.Load()
+		if dequeuePos == q.dequeuePos.Load() {
+			if enqueuePos < dequeuePos {
+				return 0
+			}
+			return int(enqueuePos - dequeuePos)
+		}
+	}
+}
+
+// IsEmpty checks if queue is empty or not
+func (q *LockFreeQueue[T]) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+// IsFull checks if queue is full or not
+func (q *LockFreeQueue[T]) IsFull() bool {
+	return q.Size() >= q.Capacity()
+}
+
+// Capacity return the max number of elements of queue
+func (q *LockFreeQueue[T]) Capacity() int {
+	return len(q.buffer)
+}
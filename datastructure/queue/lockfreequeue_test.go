@@ -0,0 +1,118 @@
+package datastructure
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestLockFreeQueue_EnqueueDequeue(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestLockFreeQueue_EnqueueDequeue")
+
+	q := NewLockFreeQueue[int](3)
+	assert.Equal(4, q.Capacity())
+	assert.Equal(true, q.IsEmpty())
+
+	for i := 1; i <= 4; i++ {
+		assert.Equal(true, q.Enqueue(i))
+	}
+	assert.Equal(false, q.Enqueue(5))
+	assert.Equal(true, q.IsFull())
+	assert.Equal(4, q.Size())
+
+	for i := 1; i <= 4; i++ {
+		v, ok := q.Dequeue()
+		assert.Equal(i, v)
+		assert.Equal(true, ok)
+	}
+
+	_, ok := q.Dequeue()
+	assert.Equal(false, ok)
+	assert.Equal(true, q.IsEmpty())
+}
+
+func TestLockFreeQueue_Wraparound(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestLockFreeQueue_Wraparound")
+
+	q := NewLockFreeQueue[int](2)
+	for i := 0; i < 100; i++ {
+		assert.Equal(true, q.Enqueue(i))
+		v, ok := q.Dequeue()
+		assert.Equal(i, v)
+		assert.Equal(true, ok)
+	}
+}
+
+func TestLockFreeQueue_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestLockFreeQueue_Concurrent")
+
+	const producers, perProducer = 4, 1000
+
+	q := NewLockFreeQueue[int](64)
+
+	var wg sync.WaitGroup
+	for i := 0; i < producers; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			for j := 0; j < perProducer; j++ {
+				for !q.Enqueue(n*perProducer + j) {
+					runtime.Gosched()
+				}
+			}
+		}(i)
+	}
+
+	results := make(chan int, producers*perProducer)
+	for i := 0; i < producers; i++ {
+		go func() {
+			for j := 0; j < perProducer; j++ {
+				for {
+					if v, ok := q.Dequeue(); ok {
+						results <- v
+						break
+					}
+					runtime.Gosched()
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	seen := make(map[int]bool, producers*perProducer)
+	for i := 0; i < producers*perProducer; i++ {
+		seen[<-results] = true
+	}
+	assert.Equal(producers*perProducer, len(seen))
+}
+
+func BenchmarkLockFreeQueue(b *testing.B) {
+	q := NewLockFreeQueue[int](1024)
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			q.Enqueue(1)
+			q.Dequeue()
+		}
+	})
+}
+
+func BenchmarkMutexQueue(b *testing.B) {
+	q := NewBlockingQueue[int](1024)
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			q.TryPut(1)
+			q.TryTake()
+		}
+	})
+}
@@ -0,0 +1,230 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+// Package result implements Result type, which holds either a value or an error.
+package result
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/duke-git/lancet/v2/datastructure/optional"
+)
+
+// Result is a type that holds either a success value or an error.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok returns a successful Result with the value.
+func Ok[T any](value T) Result[T] {
+	return Result[T]{value: value}
+}
+
+// Err returns a failed Result with the error, if err is nil, the Result is successful with zero value.
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+// From returns a Result from the value and error pair returned by a function.
+func From[T any](value T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+	return Ok(value)
+}
+
+// Try calls the function and returns its result as Result, a panic in fn is recovered as an error.
+func Try[T any](fn func() (T, error)) (result Result[T]) {
+	defer func() {
+		if r := recover(); r != nil {
+			if err, ok := r.(error); ok {
+				result = Err[T](err)
+			} else {
+				result = Err[T](fmt.Errorf("panic: %v", r))
+			}
+		}
+	}()
+
+	return From(fn())
+}
+
+// FromOptional returns a successful Result with the value of Optional if not nil, otherwise returns a failed Result with err.
+func FromOptional[T any](opt optional.Optional[T], err error) Result[T] {
+	if opt.IsNil() {
+		return Err[T](err)
+	}
+	return Ok(opt.Unwarp())
+}
+
+// IsOk checks if the Result is successful.
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// IsErr checks if the Result is failed.
+func (r Result[T]) IsErr() bool {
+	return r.err != nil
+}
+
+// Error returns the error of Result, it's nil if the Result is successful.
+func (r Result[T]) Error() error {
+	return r.err
+}
+
+// Get returns the value and error of Result.
+func (r Result[T]) Get() (T, error) {
+	return r.value, r.err
+}
+
+// Unwrap returns the value if the Result is successful, otherwise panics with the error.
+func (r Result[T]) Unwrap() T {
+	if r.err != nil {
+		panic(fmt.Sprintf("Result.Unwrap: %v", r.err))
+	}
+	return r.value
+}
+
+// UnwrapOr returns the value if the Result is successful, otherwise returns other.
+func (r Result[T]) UnwrapOr(other T) T {
+	if r.err != nil {
+		return other
+	}
+	return r.value
+}
+
+// UnwrapOrElse returns the value if the Result is successful, otherwise invokes fn with the error and returns its result.
+func (r Result[T]) UnwrapOrElse(fn func(err error) T) T {
+	if r.err != nil {
+		return fn(r.err)
+	}
+	return r.value
+}
+
+// Map applies the mapper to the value if the Result is successful, otherwise returns the Result as it is.
+// Use the Map function to map to another type.
+func (r Result[T]) Map(mapper func(value T) T) Result[T] {
+	return Map(r, mapper)
+}
+
+// MapErr applies the mapper to the error if the Result is failed, otherwise returns the Result as it is.
+func (r Result[T]) MapErr(mapper func(err error) error) Result[T] {
+	if r.err == nil {
+		return r
+	}
+	return Err[T](mapper(r.err))
+}
+
+// FlatMap applies the mapper to the value if the Result is successful and returns its result,
+// otherwise returns the Result as it is. Use the FlatMap function to map to another type.
+func (r Result[T]) FlatMap(mapper func(value T) Result[T]) Result[T] {
+	return FlatMap(r, mapper)
+}
+
+// AndThen calls fn with the value if the Result is successful and returns the Result of fn,
+// otherwise returns the Result as it is.
+func (r Result[T]) AndThen(fn func(value T) (T, error)) Result[T] {
+	if r.err != nil {
+		return r
+	}
+	return From(fn(r.value))
+}
+
+// OrElse calls fn with the error if the Result is failed and returns its result, otherwise returns the Result as it is.
+func (r Result[T]) OrElse(fn func(err error) Result[T]) Result[T] {
+	if r.err == nil {
+		return r
+	}
+	return fn(r.err)
+}
+
+// IfOk performs the action with the value if the Result is successful.
+func (r Result[T]) IfOk(action func(value T)) Result[T] {
+	if r.err == nil {
+		action(r.value)
+	}
+	return r
+}
+
+// IfErr performs the action with the error if the Result is failed.
+func (r Result[T]) IfErr(action func(err error)) Result[T] {
+	if r.err != nil {
+		action(r.err)
+	}
+	return r
+}
+
+// IsErrorOf checks if the error of Result matches target, see errors.Is.
+func (r Result[T]) IsErrorOf(target error) bool {
+	return r.err != nil && errors.Is(r.err, target)
+}
+
+// ToOptional returns an Optional of the value if the Result is successful, otherwise returns an empty Optional.
+func (r Result[T]) ToOptional() optional.Optional[T] {
+	if r.err != nil {
+		return optional.Default[T]()
+	}
+	return optional.Of(r.value)
+}
+
+// String implements the fmt.Stringer interface.
+func (r Result[T]) String() string {
+	if r.err != nil {
+		return fmt.Sprintf("Err(%v)", r.err)
+	}
+	return fmt.Sprintf("Ok(%v)", r.value)
+}
+
+// Map applies the mapper to the value of Result if it's successful, otherwise returns a failed Result with the same error.
+func Map[T any, U any](r Result[T], mapper func(value T) U) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return Ok(mapper(r.value))
+}
+
+// FlatMap applies the mapper to the value of Result if it's successful and returns its result,
+// otherwise returns a failed Result with the same error.
+func FlatMap[T any, U any](r Result[T], mapper func(value T) Result[U]) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return mapper(r.value)
+}
+
+// AndThen calls fn with the value of Result if it's successful and returns the Result of fn,
+// otherwise returns a failed Result with the same error.
+func AndThen[T any, U any](r Result[T], fn func(value T) (U, error)) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return From(fn(r.value))
+}
+
+// Collect returns a successful Result of all values if all results are successful,
+// otherwise returns the first failed Result.
+func Collect[T any](results []Result[T]) Result[[]T] {
+	values := make([]T, 0, len(results))
+	for _, r := range results {
+		if r.err != nil {
+			return Err[[]T](r.err)
+		}
+		values = append(values, r.value)
+	}
+	return Ok(values)
+}
+
+// Partition splits results into values of successful results and errors of failed results.
+func Partition[T any](results []Result[T]) ([]T, []error) {
+	values := []T{}
+	errs := []error{}
+	for _, r := range results {
+		if r.err != nil {
+			errs = append(errs, r.err)
+		} else {
+			values = append(values, r.value)
+		}
+	}
+	return values, errs
+}
@@ -0,0 +1,157 @@
+package result
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/datastructure/optional"
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestOkErr(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestOkErr")
+
+	ok := Ok(1)
+	assert.Equal(true, ok.IsOk())
+	assert.Equal(false, ok.IsErr())
+	assert.Equal(1, ok.Unwrap())
+	assert.IsNil(ok.Error())
+	assert.Equal("Ok(1)", ok.String())
+
+	errFoo := errors.New("foo")
+	failed := Err[int](errFoo)
+	assert.Equal(true, failed.IsErr())
+	assert.Equal(errFoo, failed.Error())
+	assert.Equal(2, failed.UnwrapOr(2))
+	assert.Equal(3, failed.UnwrapOrElse(func(err error) int { return 3 }))
+	assert.Equal(true, failed.IsErrorOf(errFoo))
+	assert.Equal("Err(foo)", failed.String())
+
+	value, err := failed.Get()
+	assert.Equal(0, value)
+	assert.Equal(errFoo, err)
+
+	defer func() {
+		assert.IsNotNil(recover())
+	}()
+	failed.Unwrap()
+}
+
+func TestTry(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestTry")
+
+	r := Try(func() (int, error) { return strconv.Atoi("42") })
+	assert.Equal(42, r.Unwrap())
+
+	r = Try(func() (int, error) { return strconv.Atoi("abc") })
+	assert.Equal(true, r.IsErr())
+
+	r = Try(func() (int, error) { panic("boom") })
+	assert.Equal("panic: boom", r.Error().Error())
+
+	errFoo := errors.New("foo")
+	r = Try(func() (int, error) { panic(errFoo) })
+	assert.Equal(true, r.IsErrorOf(errFoo))
+}
+
+func TestMap(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestMap")
+
+	double := func(n int) int { return n * 2 }
+
+	assert.Equal(4, Ok(2).Map(double).Unwrap())
+	assert.Equal("2", Map(Ok(2), strconv.Itoa).Unwrap())
+
+	errFoo := errors.New("foo")
+	r := Map(Err[int](errFoo), strconv.Itoa)
+	assert.Equal(errFoo, r.Error())
+
+	wrapped := Err[int](errFoo).MapErr(func(err error) error { return errors.New("bar: " + err.Error()) })
+	assert.Equal("bar: foo", wrapped.Error().Error())
+	assert.Equal(1, Ok(1).MapErr(func(err error) error { return nil }).Unwrap())
+}
+
+func TestFlatMap_AndThen(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestFlatMap_AndThen")
+
+	parse := func(s string) Result[int] {
+		return From(strconv.Atoi(s))
+	}
+
+	assert.Equal(42, FlatMap(Ok("42"), parse).Unwrap())
+	assert.Equal(true, FlatMap(Ok("abc"), parse).IsErr())
+
+	r := AndThen(Ok("42"), strconv.Atoi)
+	assert.Equal(42, r.Unwrap())
+
+	called := false
+	r = AndThen(Err[string](errors.New("foo")), func(s string) (int, error) {
+		called = true
+		return 0, nil
+	})
+	assert.Equal(false, called)
+	assert.Equal(true, r.IsErr())
+
+	positive := func(n int) (int, error) {
+		if n <= 0 {
+			return 0, errors.New("not positive")
+		}
+		return n, nil
+	}
+	assert.Equal(1, Ok(1).AndThen(positive).Unwrap())
+	assert.Equal("not positive", Ok(-1).AndThen(positive).Error().Error())
+	assert.Equal(true, Ok(1).FlatMap(func(n int) Result[int] { return Err[int](errors.New("foo")) }).IsErr())
+}
+
+func TestOrElse(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestOrElse")
+
+	fallback := func(err error) Result[int] { return Ok(0) }
+
+	assert.Equal(0, Err[int](errors.New("foo")).OrElse(fallback).Unwrap())
+	assert.Equal(1, Ok(1).OrElse(fallback).Unwrap())
+
+	var okValue int
+	var errValue error
+	Ok(1).IfOk(func(v int) { okValue = v }).IfErr(func(err error) { errValue = err })
+	assert.Equal(1, okValue)
+	assert.IsNil(errValue)
+
+	Err[int](errors.New("foo")).IfOk(func(v int) { okValue = 2 }).IfErr(func(err error) { errValue = err })
+	assert.Equal(1, okValue)
+	assert.IsNotNil(errValue)
+}
+
+func TestOptional(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestOptional")
+
+	assert.Equal(1, Ok(1).ToOptional().Unwarp())
+	assert.Equal(true, Err[int](errors.New("foo")).ToOptional().IsNil())
+
+	errNotFound := errors.New("not found")
+	assert.Equal(1, FromOptional(optional.Of(1), errNotFound).Unwrap())
+	assert.Equal(errNotFound, FromOptional(optional.Default[int](), errNotFound).Error())
+}
+
+func TestCollect_Partition(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestCollect_Partition")
+
+	assert.Equal([]int{1, 2}, Collect([]Result[int]{Ok(1), Ok(2)}).Unwrap())
+
+	errFoo := errors.New("foo")
+	errBar := errors.New("bar")
+	results := []Result[int]{Ok(1), Err[int](errFoo), Ok(2), Err[int](errBar)}
+	assert.Equal(errFoo, Collect(results).Error())
+
+	values, errs := Partition(results)
+	assert.Equal([]int{1, 2}, values)
+	assert.Equal([]error{errFoo, errBar}, errs)
+}
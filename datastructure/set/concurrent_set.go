@@ -0,0 +1,199 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+// Package datastructure contains some data structure. ConcurrentSet is a set which is safe for concurrent use.
+package datastructure
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+const defaultShardCount = 32
+
+// ConcurrentSet is a set which is safe for concurrent use by multiple goroutines.
+// Elements are spread over several shards, each shard is guarded by its own lock (lock striping),
+// so goroutines operating on different shards don't block each other.
+type ConcurrentSet[T comparable] struct {
+	shards []*setShard[T]
+}
+
+type setShard[T comparable] struct {
+	sync.RWMutex
+	items map[T]struct{}
+}
+
+// NewConcurrentSet create a instance of ConcurrentSet with default shard count from given values.
+func NewConcurrentSet[T comparable](items ...T) *ConcurrentSet[T] {
+	return NewConcurrentSetWithShards(defaultShardCount, items...)
+}
+
+// NewConcurrentSetWithShards create a instance of ConcurrentSet with given shard count.
+// if shardCount is less than 1, default shard count (32) will be used.
+func NewConcurrentSetWithShards[T comparable](shardCount int, items ...T) *ConcurrentSet[T] {
+	if shardCount < 1 {
+		shardCount = defaultShardCount
+	}
+
+	shards := make([]*setShard[T], shardCount)
+	for i := range shards {
+		shards[i] = &setShard[T]{items: make(map[T]struct{})}
+	}
+
+	set := &ConcurrentSet[T]{shards: shards}
+	set.Add(items...)
+
+	return set
+}
+
+// Add items to set.
+func (s *ConcurrentSet[T]) Add(items ...T) {
+	for _, item := range items {
+		shard := s.getShard(item)
+		shard.Lock()
+		shard.items[item] = struct{}{}
+		shard.Unlock()
+	}
+}
+
+// AddIfNotExist adds the item to set and returns true if it does not exist in the set,
+// or else it does nothing and returns false.
+func (s *ConcurrentSet[T]) AddIfNotExist(item T) bool {
+	shard := s.getShard(item)
+	shard.Lock()
+	defer shard.Unlock()
+
+	if _, ok := shard.items[item]; ok {
+		return false
+	}
+	shard.items[item] = struct{}{}
+
+	return true
+}
+
+// Contain checks if set contains item or not.
+func (s *ConcurrentSet[T]) Contain(item T) bool {
+	shard := s.getShard(item)
+	shard.RLock()
+	defer shard.RUnlock()
+
+	_, ok := shard.items[item]
+	return ok
+}
+
+// ContainAll checks if set contains all items.
+func (s *ConcurrentSet[T]) ContainAll(items ...T) bool {
+	for _, item := range items {
+		if !s.Contain(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Delete items of set.
+func (s *ConcurrentSet[T]) Delete(items ...T) {
+	for _, item := range items {
+		shard := s.getShard(item)
+		shard.Lock()
+		delete(shard.items, item)
+		shard.Unlock()
+	}
+}
+
+// Pop delete an element of set then return it, if set is empty, return zero value of T and false.
+func (s *ConcurrentSet[T]) Pop() (v T, ok bool) {
+	for _, shard := range s.shards {
+		shard.Lock()
+		for item := range shard.items {
+			delete(shard.items, item)
+			shard.Unlock()
+			return item, true
+		}
+		shard.Unlock()
+	}
+
+	return v, false
+}
+
+// Size get the number of elements in set.
+func (s *ConcurrentSet[T]) Size() int {
+	size := 0
+	for _, shard := range s.shards {
+		shard.RLock()
+		size += len(shard.items)
+		shard.RUnlock()
+	}
+	return size
+}
+
+// IsEmpty checks the set is empty or not.
+func (s *ConcurrentSet[T]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+// Clear removes all elements of set.
+func (s *ConcurrentSet[T]) Clear() {
+	for _, shard := range s.shards {
+		shard.Lock()
+		shard.items = make(map[T]struct{})
+		shard.Unlock()
+	}
+}
+
+// Iterate call function by every element of set.
+// the function is called while holding the read lock of a shard, so it should not modify the set.
+func (s *ConcurrentSet[T]) Iterate(fn func(item T)) {
+	for _, shard := range s.shards {
+		shard.RLock()
+		for item := range shard.items {
+			fn(item)
+		}
+		shard.RUnlock()
+	}
+}
+
+// ToSlice returns a slice containing all values of the set.
+func (s *ConcurrentSet[T]) ToSlice() []T {
+	result := make([]T, 0, s.Size())
+	s.Iterate(func(item T) {
+		result = append(result, item)
+	})
+	return result
+}
+
+// ToSet returns a snapshot of the set as a Set.
+func (s *ConcurrentSet[T]) ToSet() Set[T] {
+	return FromSlice(s.ToSlice())
+}
+
+// Clone return a copy of set, the shard count of new set is same as origin set.
+func (s *ConcurrentSet[T]) Clone() *ConcurrentSet[T] {
+	return NewConcurrentSetWithShards(len(s.shards), s.ToSlice()...)
+}
+
+// MarshalJSON implements the json.Marshaler interface, the set is encoded as a json array.
+func (s *ConcurrentSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.ToSlice())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, it decodes a json array into the set.
+func (s *ConcurrentSet[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+
+	if len(s.shards) == 0 {
+		*s = *NewConcurrentSet[T]()
+	}
+	s.Add(items...)
+
+	return nil
+}
+
+func (s *ConcurrentSet[T]) getShard(item T) *setShard[T] {
+	return s.shards[internal.Hash(item)%uint64(len(s.shards))]
+}
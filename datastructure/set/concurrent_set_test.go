@@ -0,0 +1,151 @@
+package datastructure
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestConcurrentSet_Add(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestConcurrentSet_Add")
+
+	set := NewConcurrentSet[int]()
+	set.Add(1, 2, 3, 3)
+
+	assert.Equal(3, set.Size())
+	assert.Equal(true, set.ContainAll(1, 2, 3))
+	assert.Equal(false, set.Contain(4))
+
+	assert.Equal(false, set.AddIfNotExist(1))
+	assert.Equal(true, set.AddIfNotExist(4))
+	assert.Equal(4, set.Size())
+}
+
+func TestConcurrentSet_Delete(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestConcurrentSet_Delete")
+
+	set := NewConcurrentSet(1, 2, 3)
+	set.Delete(1, 5)
+
+	assert.Equal(2, set.Size())
+	assert.Equal(false, set.Contain(1))
+
+	set.Clear()
+	assert.Equal(true, set.IsEmpty())
+}
+
+func TestConcurrentSet_Pop(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestConcurrentSet_Pop")
+
+	set := NewConcurrentSetWithShards(4, "a")
+
+	v, ok := set.Pop()
+	assert.Equal("a", v)
+	assert.Equal(true, ok)
+
+	_, ok = set.Pop()
+	assert.Equal(false, ok)
+}
+
+func TestConcurrentSet_ToSlice(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestConcurrentSet_ToSlice")
+
+	set := NewConcurrentSet(3, 1, 2)
+	values := set.ToSlice()
+	sort.Ints(values)
+
+	assert.Equal([]int{1, 2, 3}, values)
+	assert.Equal(true, New(1, 2, 3).Equal(set.ToSet()))
+
+	cloned := set.Clone()
+	cloned.Add(4)
+	assert.Equal(3, set.Size())
+	assert.Equal(4, cloned.Size())
+}
+
+func TestConcurrentSet_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestConcurrentSet_Concurrent")
+
+	set := NewConcurrentSet[int]()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				set.Add(n*100 + j)
+				set.Contain(j)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(1000, set.Size())
+}
+
+func TestConcurrentSet_ZeroFloat(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestConcurrentSet_ZeroFloat")
+
+	negZero := 0.0
+	negZero = -negZero
+
+	set := NewConcurrentSet(0.0)
+	assert.Equal(true, set.Contain(negZero))
+}
+
+func TestConcurrentSet_PointerKey(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestConcurrentSet_PointerKey")
+
+	type user struct{ name string }
+
+	users := make([]*user, 100)
+	for i := range users {
+		users[i] = &user{name: "a"}
+	}
+
+	set := NewConcurrentSet(users...)
+	for _, u := range users {
+		u.name = "b"
+	}
+
+	for _, u := range users {
+		assert.Equal(true, set.Contain(u))
+	}
+	assert.Equal(false, set.Contain(&user{name: "b"}))
+	assert.Equal(100, set.Size())
+}
+
+func TestConcurrentSet_JSON(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestConcurrentSet_JSON")
+
+	set := NewConcurrentSet("a", "b")
+
+	data, err := json.Marshal(set)
+	assert.IsNil(err)
+
+	var decoded ConcurrentSet[string]
+	err = json.Unmarshal(data, &decoded)
+	assert.IsNil(err)
+	assert.Equal(2, decoded.Size())
+	assert.Equal(true, decoded.ContainAll("a", "b"))
+}
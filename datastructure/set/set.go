@@ -4,7 +4,10 @@
 // Package datastructure contains some data structure. Set is a data container, like slice, but element of set is not duplicate.
 package datastructure
 
-import "sort"
+import (
+	"encoding/json"
+	"sort"
+)
 
 // Set is a data container, like slice, but element of set is not duplicate.
 type Set[T comparable] map[T]struct{}
@@ -218,3 +221,23 @@ func (s Set[T]) ToSortedSlice(less func(v1, v2 T) bool) []T {
 	})
 	return result
 }
+
+// MarshalJSON implements the json.Marshaler interface, the set is encoded as a json array.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.ToSlice())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, it decodes a json array into the set.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+
+	if *s == nil {
+		*s = make(Set[T], len(items))
+	}
+	s.Add(items...)
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package datastructure
 
 import (
+	"encoding/json"
 	"reflect"
 	"sort"
 	"testing"
@@ -333,3 +334,22 @@ func TestSet_ToSortedSlice(t *testing.T) {
 		{"Spike", 25},
 	}))
 }
+
+func TestSet_JSON(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSet_JSON")
+
+	set := New(1, 2, 3)
+
+	data, err := json.Marshal(set)
+	assert.IsNil(err)
+
+	var decoded Set[int]
+	err = json.Unmarshal(data, &decoded)
+	assert.IsNil(err)
+	assert.Equal(true, set.Equal(decoded))
+
+	err = json.Unmarshal([]byte(`{"a":1}`), &decoded)
+	assert.IsNotNil(err)
+}
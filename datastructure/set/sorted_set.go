@@ -0,0 +1,461 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+// Package datastructure contains some data structure. SortedSet is a set which keeps its elements in order.
+package datastructure
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/duke-git/lancet/v2/constraints"
+)
+
+// SortedSet is a set which keeps its elements in ascending order, it is backed by an AVL tree,
+// so Add, Delete, Contain, Rank and At operations are O(log n).
+// type T should implements Compare function in constraints.Comparator interface.
+type SortedSet[T any] struct {
+	root       *avlNode[T]
+	comparator constraints.Comparator
+}
+
+type avlNode[T any] struct {
+	value  T
+	left   *avlNode[T]
+	right  *avlNode[T]
+	height int
+	size   int
+}
+
+// NewSortedSet create a instance of SortedSet from given values.
+// param `comparator` is used to compare values in the set.
+func NewSortedSet[T any](comparator constraints.Comparator, items ...T) *SortedSet[T] {
+	set := &SortedSet[T]{comparator: comparator}
+	set.Add(items...)
+	return set
+}
+
+// Add items to set.
+func (s *SortedSet[T]) Add(items ...T) {
+	for _, item := range items {
+		s.root, _ = s.insert(s.root, item)
+	}
+}
+
+// AddIfNotExist adds the item to set and returns true if it does not exist in the set,
+// or else it does nothing and returns false.
+func (s *SortedSet[T]) AddIfNotExist(item T) bool {
+	var added bool
+	s.root, added = s.insert(s.root, item)
+	return added
+}
+
+// Delete items of set.
+func (s *SortedSet[T]) Delete(items ...T) {
+	for _, item := range items {
+		s.root, _ = s.remove(s.root, item)
+	}
+}
+
+// Contain checks if set contains item or not.
+func (s *SortedSet[T]) Contain(item T) bool {
+	node := s.root
+	for node != nil {
+		c := s.comparator.Compare(item, node.value)
+		switch {
+		case c < 0:
+			node = node.left
+		case c > 0:
+			node = node.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// Size get the number of elements in set.
+func (s *SortedSet[T]) Size() int {
+	return nodeSize(s.root)
+}
+
+// IsEmpty checks the set is empty or not.
+func (s *SortedSet[T]) IsEmpty() bool {
+	return s.root == nil
+}
+
+// Clear removes all elements of set.
+func (s *SortedSet[T]) Clear() {
+	s.root = nil
+}
+
+// Min returns the smallest element of set, if set is empty, return zero value of T and false.
+func (s *SortedSet[T]) Min() (T, bool) {
+	var zero T
+	if s.root == nil {
+		return zero, false
+	}
+
+	node := s.root
+	for node.left != nil {
+		node = node.left
+	}
+	return node.value, true
+}
+
+// Max returns the largest element of set, if set is empty, return zero value of T and false.
+func (s *SortedSet[T]) Max() (T, bool) {
+	var zero T
+	if s.root == nil {
+		return zero, false
+	}
+
+	node := s.root
+	for node.right != nil {
+		node = node.right
+	}
+	return node.value, true
+}
+
+// Floor returns the largest element which is less than or equal to item.
+// if there is no such element, return zero value of T and false.
+func (s *SortedSet[T]) Floor(item T) (T, bool) {
+	var result T
+	found := false
+
+	node := s.root
+	for node != nil {
+		c := s.comparator.Compare(item, node.value)
+		if c == 0 {
+			return node.value, true
+		}
+		if c < 0 {
+			node = node.left
+		} else {
+			result, found = node.value, true
+			node = node.right
+		}
+	}
+
+	return result, found
+}
+
+// Ceiling returns the smallest element which is greater than or equal to item.
+// if there is no such element, return zero value of T and false.
+func (s *SortedSet[T]) Ceiling(item T) (T, bool) {
+	var result T
+	found := false
+
+	node := s.root
+	for node != nil {
+		c := s.comparator.Compare(item, node.value)
+		if c == 0 {
+			return node.value, true
+		}
+		if c > 0 {
+			node = node.right
+		} else {
+			result, found = node.value, true
+			node = node.left
+		}
+	}
+
+	return result, found
+}
+
+// Rank returns the number of elements which are less than item.
+// if item is in the set, it's the zero-based index of item.
+func (s *SortedSet[T]) Rank(item T) int {
+	rank := 0
+
+	node := s.root
+	for node != nil {
+		c := s.comparator.Compare(item, node.value)
+		if c <= 0 {
+			node = node.left
+		} else {
+			rank += nodeSize(node.left) + 1
+			node = node.right
+		}
+	}
+
+	return rank
+}
+
+// At returns the element at the given zero-based index in sorted order.
+// if index is out of range, return zero value of T and false.
+func (s *SortedSet[T]) At(index int) (T, bool) {
+	var zero T
+	if index < 0 || index >= s.Size() {
+		return zero, false
+	}
+
+	node := s.root
+	for node != nil {
+		leftSize := nodeSize(node.left)
+		switch {
+		case index < leftSize:
+			node = node.left
+		case index > leftSize:
+			index -= leftSize + 1
+			node = node.right
+		default:
+			return node.value, true
+		}
+	}
+
+	return zero, false
+}
+
+// Range returns elements which are in the closed interval [from, to] in ascending order.
+func (s *SortedSet[T]) Range(from, to T) []T {
+	result := []T{}
+	s.rangeNode(s.root, from, to, &result)
+	return result
+}
+
+// Iterate call function by every element of set in ascending order,
+// when iteratee return false, will break the loop.
+func (s *SortedSet[T]) Iterate(iteratee func(item T) bool) {
+	inOrder(s.root, iteratee)
+}
+
+// ToSlice returns a slice containing all values of the set in ascending order.
+func (s *SortedSet[T]) ToSlice() []T {
+	result := make([]T, 0, s.Size())
+	s.Iterate(func(item T) bool {
+		result = append(result, item)
+		return true
+	})
+	return result
+}
+
+// Clone return a copy of set.
+func (s *SortedSet[T]) Clone() *SortedSet[T] {
+	return &SortedSet[T]{root: cloneNode(s.root), comparator: s.comparator}
+}
+
+// Equal checks if two set has same elements or not.
+func (s *SortedSet[T]) Equal(other *SortedSet[T]) bool {
+	if s.Size() != other.Size() {
+		return false
+	}
+
+	values, otherValues := s.ToSlice(), other.ToSlice()
+	for i := range values {
+		if s.comparator.Compare(values[i], otherValues[i]) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Union creates a new set contain all element of set s and other.
+func (s *SortedSet[T]) Union(other *SortedSet[T]) *SortedSet[T] {
+	set := s.Clone()
+	set.Add(other.ToSlice()...)
+	return set
+}
+
+// Intersection creates a new set whose element both be contained in set s and other.
+func (s *SortedSet[T]) Intersection(other *SortedSet[T]) *SortedSet[T] {
+	set := NewSortedSet[T](s.comparator)
+	s.Iterate(func(item T) bool {
+		if other.Contain(item) {
+			set.Add(item)
+		}
+		return true
+	})
+	return set
+}
+
+// Minus creates a set of whose element in origin set but not in compared set.
+func (s *SortedSet[T]) Minus(comparedSet *SortedSet[T]) *SortedSet[T] {
+	set := NewSortedSet[T](s.comparator)
+	s.Iterate(func(item T) bool {
+		if !comparedSet.Contain(item) {
+			set.Add(item)
+		}
+		return true
+	})
+	return set
+}
+
+// MarshalJSON implements the json.Marshaler interface, the set is encoded as a sorted json array.
+func (s *SortedSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.ToSlice())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, it decodes a json array into the set.
+// the set should be created by NewSortedSet, because a comparator is required.
+func (s *SortedSet[T]) UnmarshalJSON(data []byte) error {
+	if s.comparator == nil {
+		return errors.New("sorted set: comparator is nil, create the set with NewSortedSet before unmarshal")
+	}
+
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	s.Add(items...)
+
+	return nil
+}
+
+func (s *SortedSet[T]) insert(node *avlNode[T], item T) (*avlNode[T], bool) {
+	if node == nil {
+		return &avlNode[T]{value: item, height: 1, size: 1}, true
+	}
+
+	var added bool
+	c := s.comparator.Compare(item, node.value)
+	switch {
+	case c < 0:
+		node.left, added = s.insert(node.left, item)
+	case c > 0:
+		node.right, added = s.insert(node.right, item)
+	default:
+		return node, false
+	}
+
+	return rebalance(node), added
+}
+
+func (s *SortedSet[T]) remove(node *avlNode[T], item T) (*avlNode[T], bool) {
+	if node == nil {
+		return nil, false
+	}
+
+	var removed bool
+	c := s.comparator.Compare(item, node.value)
+	switch {
+	case c < 0:
+		node.left, removed = s.remove(node.left, item)
+	case c > 0:
+		node.right, removed = s.remove(node.right, item)
+	default:
+		if node.left == nil {
+			return node.right, true
+		}
+		if node.right == nil {
+			return node.left, true
+		}
+
+		successor := node.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		node.value = successor.value
+		node.right, _ = s.remove(node.right, successor.value)
+		removed = true
+	}
+
+	return rebalance(node), removed
+}
+
+func (s *SortedSet[T]) rangeNode(node *avlNode[T], from, to T, result *[]T) {
+	if node == nil {
+		return
+	}
+
+	lowerOk := s.comparator.Compare(node.value, from) >= 0
+	upperOk := s.comparator.Compare(node.value, to) <= 0
+
+	if lowerOk {
+		s.rangeNode(node.left, from, to, result)
+	}
+	if lowerOk && upperOk {
+		*result = append(*result, node.value)
+	}
+	if upperOk {
+		s.rangeNode(node.right, from, to, result)
+	}
+}
+
+func inOrder[T any](node *avlNode[T], iteratee func(item T) bool) bool {
+	if node == nil {
+		return true
+	}
+	if !inOrder(node.left, iteratee) {
+		return false
+	}
+	if !iteratee(node.value) {
+		return false
+	}
+	return inOrder(node.right, iteratee)
+}
+
+func cloneNode[T any](node *avlNode[T]) *avlNode[T] {
+	if node == nil {
+		return nil
+	}
+
+	n := *node
+	n.left = cloneNode(node.left)
+	n.right = cloneNode(node.right)
+
+	return &n
+}
+
+func nodeHeight[T any](node *avlNode[T]) int {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+func nodeSize[T any](node *avlNode[T]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+func updateNode[T any](node *avlNode[T]) {
+	lh, rh := nodeHeight(node.left), nodeHeight(node.right)
+	if lh > rh {
+		node.height = lh + 1
+	} else {
+		node.height = rh + 1
+	}
+	node.size = nodeSize(node.left) + nodeSize(node.right) + 1
+}
+
+func rotateLeft[T any](node *avlNode[T]) *avlNode[T] {
+	right := node.right
+	node.right = right.left
+	right.left = node
+	updateNode(node)
+	updateNode(right)
+	return right
+}
+
+func rotateRight[T any](node *avlNode[T]) *avlNode[T] {
+	left := node.left
+	node.left = left.right
+	left.right = node
+	updateNode(node)
+	updateNode(left)
+	return left
+}
+
+func rebalance[T any](node *avlNode[T]) *avlNode[T] {
+	updateNode(node)
+
+	balance := nodeHeight(node.left) - nodeHeight(node.right)
+	if balance > 1 {
+		if nodeHeight(node.left.left) < nodeHeight(node.left.right) {
+			node.left = rotateLeft(node.left)
+		}
+		return rotateRight(node)
+	}
+	if balance < -1 {
+		if nodeHeight(node.right.right) < nodeHeight(node.right.left) {
+			node.right = rotateRight(node.right)
+		}
+		return rotateLeft(node)
+	}
+
+	return node
+}
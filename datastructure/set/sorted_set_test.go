@@ -0,0 +1,170 @@
+package datastructure
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+type intComparator struct{}
+
+func (c *intComparator) Compare(v1, v2 any) int {
+	val1, _ := v1.(int)
+	val2, _ := v2.(int)
+
+	if val1 < val2 {
+		return -1
+	} else if val1 > val2 {
+		return 1
+	}
+	return 0
+}
+
+func TestSortedSet_Add(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSortedSet_Add")
+
+	set := NewSortedSet[int](&intComparator{}, 5, 1, 4, 2, 3, 3)
+
+	assert.Equal(5, set.Size())
+	assert.Equal([]int{1, 2, 3, 4, 5}, set.ToSlice())
+	assert.Equal(true, set.Contain(3))
+	assert.Equal(false, set.Contain(6))
+
+	assert.Equal(false, set.AddIfNotExist(1))
+	assert.Equal(true, set.AddIfNotExist(6))
+}
+
+func TestSortedSet_Delete(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSortedSet_Delete")
+
+	set := NewSortedSet[int](&intComparator{})
+	for i := 0; i < 100; i++ {
+		set.Add(i)
+	}
+	for i := 0; i < 100; i += 2 {
+		set.Delete(i)
+	}
+
+	assert.Equal(50, set.Size())
+	assert.Equal(false, set.Contain(0))
+	assert.Equal(true, set.Contain(99))
+	assert.Equal(true, set.root.height <= 8)
+
+	set.Clear()
+	assert.Equal(true, set.IsEmpty())
+}
+
+func TestSortedSet_MinMax(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSortedSet_MinMax")
+
+	set := NewSortedSet[int](&intComparator{})
+
+	_, ok := set.Min()
+	assert.Equal(false, ok)
+
+	set.Add(3, 9, 1)
+
+	min, _ := set.Min()
+	max, _ := set.Max()
+	assert.Equal(1, min)
+	assert.Equal(9, max)
+}
+
+func TestSortedSet_FloorCeiling(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSortedSet_FloorCeiling")
+
+	set := NewSortedSet[int](&intComparator{}, 10, 20, 30)
+
+	v, ok := set.Floor(25)
+	assert.Equal(20, v)
+	assert.Equal(true, ok)
+
+	v, _ = set.Floor(20)
+	assert.Equal(20, v)
+
+	_, ok = set.Floor(5)
+	assert.Equal(false, ok)
+
+	v, ok = set.Ceiling(25)
+	assert.Equal(30, v)
+	assert.Equal(true, ok)
+
+	_, ok = set.Ceiling(35)
+	assert.Equal(false, ok)
+}
+
+func TestSortedSet_RankAt(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSortedSet_RankAt")
+
+	set := NewSortedSet[int](&intComparator{}, 10, 20, 30, 40)
+
+	assert.Equal(0, set.Rank(10))
+	assert.Equal(2, set.Rank(30))
+	assert.Equal(2, set.Rank(25))
+	assert.Equal(4, set.Rank(50))
+
+	v, ok := set.At(1)
+	assert.Equal(20, v)
+	assert.Equal(true, ok)
+
+	_, ok = set.At(4)
+	assert.Equal(false, ok)
+}
+
+func TestSortedSet_Range(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSortedSet_Range")
+
+	set := NewSortedSet[int](&intComparator{}, 1, 3, 5, 7, 9)
+
+	assert.Equal([]int{3, 5, 7}, set.Range(2, 7))
+	assert.Equal([]int{}, set.Range(10, 20))
+}
+
+func TestSortedSet_SetOperations(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSortedSet_SetOperations")
+
+	set1 := NewSortedSet[int](&intComparator{}, 1, 2, 3)
+	set2 := NewSortedSet[int](&intComparator{}, 2, 3, 4)
+
+	assert.Equal([]int{1, 2, 3, 4}, set1.Union(set2).ToSlice())
+	assert.Equal([]int{2, 3}, set1.Intersection(set2).ToSlice())
+	assert.Equal([]int{1}, set1.Minus(set2).ToSlice())
+	assert.Equal(true, set1.Equal(set1.Clone()))
+	assert.Equal(false, set1.Equal(set2))
+}
+
+func TestSortedSet_JSON(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSortedSet_JSON")
+
+	set := NewSortedSet[int](&intComparator{}, 3, 1, 2)
+
+	data, err := json.Marshal(set)
+	assert.IsNil(err)
+	assert.Equal("[1,2,3]", string(data))
+
+	decoded := NewSortedSet[int](&intComparator{})
+	err = json.Unmarshal(data, decoded)
+	assert.IsNil(err)
+	assert.Equal(true, set.Equal(decoded))
+
+	var noComparator SortedSet[int]
+	err = json.Unmarshal(data, &noComparator)
+	assert.IsNotNil(err)
+}
@@ -0,0 +1,293 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+// Package datastructure contains some data structure. SkipList is an ordered map which is safe for concurrent use.
+package datastructure
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/duke-git/lancet/v2/constraints"
+)
+
+const (
+	skipListMaxLevel    = 32
+	skipListProbability = 0.25
+)
+
+type skipListNode[K any, V any] struct {
+	key   K
+	value V
+	next  []*skipListNode[K, V]
+}
+
+// SkipList is an ordered key-value map implemented with skip list, it's safe for concurrent use.
+// Get, Put and Delete are O(log n) on average, and keys can be scanned in order by range.
+// type K should implements Compare function in constraints.Comparator interface.
+type SkipList[K any, V any] struct {
+	mu         sync.RWMutex
+	head       *skipListNode[K, V]
+	level      int
+	size       int
+	comparator constraints.Comparator
+	random     *rand.Rand
+}
+
+// NewSkipList return a empty SkipList pointer
+// param `comparator` is used to compare keys in the skip list
+func NewSkipList[K any, V any](comparator constraints.Comparator) *SkipList[K, V] {
+	return &SkipList[K, V]{
+		head:       &skipListNode[K, V]{next: make([]*skipListNode[K, V], skipListMaxLevel)},
+		level:      1,
+		comparator: comparator,
+		random:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Put insert key and value into skip list, if key already exists, its value will be replaced.
+func (s *SkipList[K, V]) Put(key K, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var update [skipListMaxLevel]*skipListNode[K, V]
+	node := s.findPredecessors(key, &update)
+
+	if next := node.next[0]; next != nil && s.comparator.Compare(next.key, key) == 0 {
+		next.value = value
+		return
+	}
+
+	level := s.randomLevel()
+	if level > s.level {
+		for i := s.level; i < level; i++ {
+			update[i] = s.head
+		}
+		s.level = level
+	}
+
+	newNode := &skipListNode[K, V]{key: key, value: value, next: make([]*skipListNode[K, V], level)}
+	for i := 0; i < level; i++ {
+		newNode.next[i] = update[i].next[i]
+		update[i].next[i] = newNode
+	}
+	s.size++
+}
+
+// Get return the value of given key, if key is not found, return zero value of V and false.
+func (s *SkipList[K, V]) Get(key K) (V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	node := s.lowerBound(key)
+	if node != nil && s.comparator.Compare(node.key, key) == 0 {
+		return node.value, true
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Contain checks if key is in skip list or not
+func (s *SkipList[K, V]) Contain(key K) bool {
+	_, ok := s.Get(key)
+	return ok
+}
+
+// Delete remove the key from skip list, return true if the key existed.
+func (s *SkipList[K, V]) Delete(key K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var update [skipListMaxLevel]*skipListNode[K, V]
+	node := s.findPredecessors(key, &update).next[0]
+
+	if node == nil || s.comparator.Compare(node.key, key) != 0 {
+		return false
+	}
+
+	for i := 0; i < len(node.next); i++ {
+		update[i].next[i] = node.next[i]
+	}
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+	s.size--
+
+	return true
+}
+
+// Size return the number of keys in skip list
+func (s *SkipList[K, V]) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.size
+}
+
+// IsEmpty checks if skip list is empty or not
+func (s *SkipList[K, V]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+// Clear remove all keys of skip list
+func (s *SkipList[K, V]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.head = &skipListNode[K, V]{next: make([]*skipListNode[K, V], skipListMaxLevel)}
+	s.level = 1
+	s.size = 0
+}
+
+// First return the smallest key and its value, if skip list is empty, return false.
+func (s *SkipList[K, V]) First() (K, V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return entryOf(s.head.next[0])
+}
+
+// Last return the largest key and its value, if skip list is empty, return false.
+func (s *SkipList[K, V]) Last() (K, V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	node := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for node.next[i] != nil {
+			node = node.next[i]
+		}
+	}
+
+	if node == s.head {
+		return entryOf[K, V](nil)
+	}
+	return entryOf(node)
+}
+
+// Floor return the largest key which is less than or equal to given key, and its value.
+// if there is no such key, return false.
+func (s *SkipList[K, V]) Floor(key K) (K, V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	node := s.lowerBound(key)
+	if node != nil && s.comparator.Compare(node.key, key) == 0 {
+		return entryOf(node)
+	}
+
+	var update [skipListMaxLevel]*skipListNode[K, V]
+	prev := s.findPredecessors(key, &update)
+	if prev == s.head {
+		return entryOf[K, V](nil)
+	}
+	return entryOf(prev)
+}
+
+// Ceiling return the smallest key which is greater than or equal to given key, and its value.
+// if there is no such key, return false.
+func (s *SkipList[K, V]) Ceiling(key K) (K, V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return entryOf(s.lowerBound(key))
+}
+
+// Range call function by every key and value in the closed interval [from, to] in ascending order,
+// when iteratee return false, will break the loop.
+// iteratee is called while holding the read lock, so it should not modify the skip list.
+func (s *SkipList[K, V]) Range(from, to K, iteratee func(key K, value V) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for node := s.lowerBound(from); node != nil; node = node.next[0] {
+		if s.comparator.Compare(node.key, to) > 0 {
+			return
+		}
+		if !iteratee(node.key, node.value) {
+			return
+		}
+	}
+}
+
+// Iterate call function by every key and value of skip list in ascending order,
+// when iteratee return false, will break the loop.
+// iteratee is called while holding the read lock, so it should not modify the skip list.
+func (s *SkipList[K, V]) Iterate(iteratee func(key K, value V) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for node := s.head.next[0]; node != nil; node = node.next[0] {
+		if !iteratee(node.key, node.value) {
+			return
+		}
+	}
+}
+
+// Keys return all keys of skip list in ascending order
+func (s *SkipList[K, V]) Keys() []K {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]K, 0, s.size)
+	for node := s.head.next[0]; node != nil; node = node.next[0] {
+		keys = append(keys, node.key)
+	}
+	return keys
+}
+
+// Values return all values of skip list in ascending order of keys
+func (s *SkipList[K, V]) Values() []V {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	values := make([]V, 0, s.size)
+	for node := s.head.next[0]; node != nil; node = node.next[0] {
+		values = append(values, node.value)
+	}
+	return values
+}
+
+// findPredecessors find the last node whose key is less than given key on every level,
+// and return the one on the bottom level.
+func (s *SkipList[K, V]) findPredecessors(key K, update *[skipListMaxLevel]*skipListNode[K, V]) *skipListNode[K, V] {
+	node := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && s.comparator.Compare(node.next[i].key, key) < 0 {
+			node = node.next[i]
+		}
+		update[i] = node
+	}
+	return node
+}
+
+// lowerBound return the first node whose key is greater than or equal to given key.
+func (s *SkipList[K, V]) lowerBound(key K) *skipListNode[K, V] {
+	node := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && s.comparator.Compare(node.next[i].key, key) < 0 {
+			node = node.next[i]
+		}
+	}
+	return node.next[0]
+}
+
+// randomLevel should be called with write lock held.
+func (s *SkipList[K, V]) randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && s.random.Float64() < skipListProbability {
+		level++
+	}
+	return level
+}
+
+func entryOf[K any, V any](node *skipListNode[K, V]) (K, V, bool) {
+	if node == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return node.key, node.value, true
+}
@@ -0,0 +1,176 @@
+package datastructure
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+type intComparator struct{}
+
+func (c *intComparator) Compare(v1, v2 any) int {
+	val1, _ := v1.(int)
+	val2, _ := v2.(int)
+
+	if val1 < val2 {
+		return -1
+	} else if val1 > val2 {
+		return 1
+	}
+	return 0
+}
+
+func TestSkipList_PutGet(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSkipList_PutGet")
+
+	sl := NewSkipList[int, string](&intComparator{})
+	sl.Put(3, "c")
+	sl.Put(1, "a")
+	sl.Put(2, "b")
+	sl.Put(2, "bb")
+
+	assert.Equal(3, sl.Size())
+	assert.Equal([]int{1, 2, 3}, sl.Keys())
+	assert.Equal([]string{"a", "bb", "c"}, sl.Values())
+
+	v, ok := sl.Get(2)
+	assert.Equal("bb", v)
+	assert.Equal(true, ok)
+
+	_, ok = sl.Get(4)
+	assert.Equal(false, ok)
+	assert.Equal(true, sl.Contain(1))
+}
+
+func TestSkipList_Delete(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSkipList_Delete")
+
+	sl := NewSkipList[int, int](&intComparator{})
+	for i := 0; i < 100; i++ {
+		sl.Put(i, i*i)
+	}
+
+	for i := 0; i < 100; i += 2 {
+		assert.Equal(true, sl.Delete(i))
+	}
+	assert.Equal(false, sl.Delete(0))
+	assert.Equal(50, sl.Size())
+
+	v, ok := sl.Get(9)
+	assert.Equal(81, v)
+	assert.Equal(true, ok)
+
+	sl.Clear()
+	assert.Equal(true, sl.IsEmpty())
+}
+
+func TestSkipList_FirstLast(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSkipList_FirstLast")
+
+	sl := NewSkipList[int, string](&intComparator{})
+
+	_, _, ok := sl.First()
+	assert.Equal(false, ok)
+	_, _, ok = sl.Last()
+	assert.Equal(false, ok)
+
+	sl.Put(5, "e")
+	sl.Put(1, "a")
+	sl.Put(9, "i")
+
+	k, v, _ := sl.First()
+	assert.Equal(1, k)
+	assert.Equal("a", v)
+
+	k, v, _ = sl.Last()
+	assert.Equal(9, k)
+	assert.Equal("i", v)
+}
+
+func TestSkipList_FloorCeiling(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSkipList_FloorCeiling")
+
+	sl := NewSkipList[int, int](&intComparator{})
+	sl.Put(10, 1)
+	sl.Put(20, 2)
+	sl.Put(30, 3)
+
+	k, _, ok := sl.Floor(25)
+	assert.Equal(20, k)
+	assert.Equal(true, ok)
+
+	k, _, _ = sl.Floor(30)
+	assert.Equal(30, k)
+
+	_, _, ok = sl.Floor(5)
+	assert.Equal(false, ok)
+
+	k, _, ok = sl.Ceiling(25)
+	assert.Equal(30, k)
+	assert.Equal(true, ok)
+
+	_, _, ok = sl.Ceiling(31)
+	assert.Equal(false, ok)
+}
+
+func TestSkipList_Range(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSkipList_Range")
+
+	sl := NewSkipList[int, int](&intComparator{})
+	for i := 0; i < 10; i++ {
+		sl.Put(i, i)
+	}
+
+	keys := []int{}
+	sl.Range(3, 6, func(key int, value int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal([]int{3, 4, 5, 6}, keys)
+
+	keys = []int{}
+	sl.Iterate(func(key int, value int) bool {
+		keys = append(keys, key)
+		return key < 2
+	})
+	assert.Equal([]int{0, 1, 2}, keys)
+}
+
+func TestSkipList_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSkipList_Concurrent")
+
+	sl := NewSkipList[int, int](&intComparator{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				sl.Put(n*100+j, j)
+				sl.Get(j)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(800, sl.Size())
+
+	keys := sl.Keys()
+	for i := 1; i < len(keys); i++ {
+		assert.Equal(true, keys[i-1] < keys[i])
+	}
+}
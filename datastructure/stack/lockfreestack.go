@@ -0,0 +1,100 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package datastructure
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/duke-git/lancet/v2/datastructure"
+)
+
+// LockFreeStack implements a lock-free stack with link list (Treiber stack),
+// it's safe for concurrent use by multiple goroutines.
+type LockFreeStack[T any] struct {
+	top    atomic.Pointer[datastructure.StackNode[T]]
+	length atomic.Int64
+}
+
+// NewLockFreeStack return a empty LockFreeStack pointer
+func NewLockFreeStack[T any]() *LockFreeStack[T] {
+	return &LockFreeStack[T]{}
+}
+
+// Push element into stack
+func (s *LockFreeStack[T]) Push(value T) {
+	newNode := datastructure.NewStackNode(value)
+	for {
+		top := s.top.Load()
+		newNode.Next = top
+		if s.top.CompareAndSwap(top, newNode) {
+			s.length.Add(1)
+			return
+		}
+	}
+}
+
+// Pop delete the top element of stack then return it, if stack is empty, return nil and error
+func (s *LockFreeStack[T]) Pop() (*T, error) {
+	for {
+		top := s.top.Load()
+		if top == nil {
+			return nil, errors.New("stack is empty")
+		}
+		if s.top.CompareAndSwap(top, top.Next) {
+			s.length.Add(-1)
+			return &top.Value, nil
+		}
+	}
+}
+
+// Peak return the top element of stack
+func (s *LockFreeStack[T]) Peak() (*T, error) {
+	top := s.top.Load()
+	if top == nil {
+		return nil, errors.New("stack is empty")
+	}
+	return &top.Value, nil
+}
+
+// Data return a snapshot of stack data
+func (s *LockFreeStack[T]) Data() []T {
+	res := []T{}
+	for current := s.top.Load(); current != nil; current = current.Next {
+		res = append(res, current.Value)
+	}
+	return res
+}
+
+// Size return length of stack data,
+// the result is approximate when there are concurrent Push or Pop calls.
+func (s *LockFreeStack[T]) Size() int {
+	if length := s.length.Load(); length > 0 {
+		return int(length)
+	}
+	return 0
+}
+
+// IsEmpty checks if stack is empty or not
+func (s *LockFreeStack[T]) IsEmpty() bool {
+	return s.top.Load() == nil
+}
+
+// Clear clear the stack data
+func (s *LockFreeStack[T]) Clear() {
+	for {
+		top := s.top.Load()
+		if top == nil {
+			return
+		}
+		if s.top.CompareAndSwap(top, nil) {
+			count := int64(0)
+			for current := top; current != nil; current = current.Next {
+				count++
+			}
+			s.length.Add(-count)
+			return
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package datastructure
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestLockFreeStack_PushPop(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestLockFreeStack_PushPop")
+
+	stack := NewLockFreeStack[int]()
+
+	_, err := stack.Pop()
+	assert.IsNotNil(err)
+
+	stack.Push(1)
+	stack.Push(2)
+	stack.Push(3)
+
+	assert.Equal([]int{3, 2, 1}, stack.Data())
+	assert.Equal(3, stack.Size())
+
+	top, err := stack.Peak()
+	assert.IsNil(err)
+	assert.Equal(3, *top)
+
+	top, err = stack.Pop()
+	assert.IsNil(err)
+	assert.Equal(3, *top)
+	assert.Equal([]int{2, 1}, stack.Data())
+}
+
+func TestLockFreeStack_Clear(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestLockFreeStack_Clear")
+
+	stack := NewLockFreeStack[int]()
+	stack.Push(1)
+	stack.Push(2)
+	stack.Clear()
+
+	assert.Equal(true, stack.IsEmpty())
+	assert.Equal(0, stack.Size())
+
+	_, err := stack.Peak()
+	assert.IsNotNil(err)
+}
+
+func TestLockFreeStack_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestLockFreeStack_Concurrent")
+
+	stack := NewLockFreeStack[int]()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				stack.Push(n*500 + j)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(4000, stack.Size())
+
+	results := make(chan int, 4000)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				v, err := stack.Pop()
+				if err == nil {
+					results <- *v
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	seen := map[int]bool{}
+	for v := range results {
+		seen[v] = true
+	}
+	assert.Equal(4000, len(seen))
+	assert.Equal(true, stack.IsEmpty())
+}
+
+func BenchmarkLockFreeStack(b *testing.B) {
+	stack := NewLockFreeStack[int]()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			stack.Push(1)
+			stack.Pop()
+		}
+	})
+}
+
+func BenchmarkMutexLinkedStack(b *testing.B) {
+	stack := NewLinkedStack[int]()
+	var mu sync.Mutex
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			mu.Lock()
+			stack.Push(1)
+			mu.Unlock()
+
+			mu.Lock()
+			stack.Pop()
+			mu.Unlock()
+		}
+	})
+}
@@ -0,0 +1,301 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+// Package datastructure contains some data structure. IntervalTree is a tree for overlap and point-stabbing queries.
+package datastructure
+
+import (
+	"errors"
+	"time"
+
+	"github.com/duke-git/lancet/v2/constraints"
+)
+
+// Interval is a closed interval [Start, End] with a value attached.
+type Interval[K any, V any] struct {
+	Start K
+	End   K
+	Value V
+}
+
+// IntervalTree is an augmented balanced (AVL) binary search tree of closed intervals,
+// it finds all intervals overlapping a given interval or containing a given point in O(log n + m) time.
+// type K should implements Compare function in constraints.Comparator interface.
+type IntervalTree[K any, V any] struct {
+	root       *intervalNode[K, V]
+	size       int
+	comparator constraints.Comparator
+}
+
+// intervalNode holds all intervals which have same Start and End,
+// maxEnd is the max End of intervals in the subtree rooted at this node.
+type intervalNode[K any, V any] struct {
+	start  K
+	end    K
+	values []V
+	maxEnd K
+	height int
+	left   *intervalNode[K, V]
+	right  *intervalNode[K, V]
+}
+
+// NewIntervalTree return a empty IntervalTree pointer
+// param `comparator` is used to compare endpoints of intervals
+func NewIntervalTree[K any, V any](comparator constraints.Comparator) *IntervalTree[K, V] {
+	return &IntervalTree[K, V]{comparator: comparator}
+}
+
+// NewTimeIntervalTree return a empty IntervalTree pointer whose endpoints are time.Time,
+// it works well with time ranges built by datetime functions, eg, datetime.BeginOfDay and datetime.EndOfDay.
+func NewTimeIntervalTree[V any]() *IntervalTree[time.Time, V] {
+	return NewIntervalTree[time.Time, V](&timeComparator{})
+}
+
+// Insert add interval [start, end] with value into tree,
+// it returns error if start is greater than end.
+func (t *IntervalTree[K, V]) Insert(start, end K, value V) error {
+	if t.comparator.Compare(start, end) > 0 {
+		return errors.New("interval tree: start of interval is greater than end")
+	}
+
+	t.root = t.insert(t.root, start, end, value)
+	t.size++
+
+	return nil
+}
+
+// Delete remove all intervals which are exactly [start, end], return the number of removed intervals.
+func (t *IntervalTree[K, V]) Delete(start, end K) int {
+	var removed int
+	t.root, removed = t.delete(t.root, start, end)
+	t.size -= removed
+
+	return removed
+}
+
+// Overlap return all intervals which overlap the closed interval [start, end], ordered by start.
+func (t *IntervalTree[K, V]) Overlap(start, end K) []Interval[K, V] {
+	result := []Interval[K, V]{}
+	t.overlap(t.root, start, end, &result)
+	return result
+}
+
+// Stab return all intervals which contain the point, ordered by start.
+func (t *IntervalTree[K, V]) Stab(point K) []Interval[K, V] {
+	return t.Overlap(point, point)
+}
+
+// HasOverlap checks if there is any interval overlapping the closed interval [start, end].
+func (t *IntervalTree[K, V]) HasOverlap(start, end K) bool {
+	node := t.root
+	for node != nil {
+		if t.isOverlap(node, start, end) {
+			return true
+		}
+		if node.left != nil && t.comparator.Compare(node.left.maxEnd, start) >= 0 {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	return false
+}
+
+// Intervals return all intervals in tree, ordered by start.
+func (t *IntervalTree[K, V]) Intervals() []Interval[K, V] {
+	result := make([]Interval[K, V], 0, t.size)
+	collectIntervals(t.root, &result)
+	return result
+}
+
+// Size return the number of intervals in tree
+func (t *IntervalTree[K, V]) Size() int {
+	return t.size
+}
+
+// IsEmpty checks if tree is empty or not
+func (t *IntervalTree[K, V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Clear remove all intervals of tree
+func (t *IntervalTree[K, V]) Clear() {
+	t.root = nil
+	t.size = 0
+}
+
+func (t *IntervalTree[K, V]) compareInterval(node *intervalNode[K, V], start, end K) int {
+	if c := t.comparator.Compare(start, node.start); c != 0 {
+		return c
+	}
+	return t.comparator.Compare(end, node.end)
+}
+
+func (t *IntervalTree[K, V]) isOverlap(node *intervalNode[K, V], start, end K) bool {
+	return t.comparator.Compare(node.start, end) <= 0 && t.comparator.Compare(start, node.end) <= 0
+}
+
+func (t *IntervalTree[K, V]) insert(node *intervalNode[K, V], start, end K, value V) *intervalNode[K, V] {
+	if node == nil {
+		return &intervalNode[K, V]{start: start, end: end, values: []V{value}, maxEnd: end, height: 1}
+	}
+
+	c := t.compareInterval(node, start, end)
+	switch {
+	case c < 0:
+		node.left = t.insert(node.left, start, end, value)
+	case c > 0:
+		node.right = t.insert(node.right, start, end, value)
+	default:
+		node.values = append(node.values, value)
+		return node
+	}
+
+	return t.rebalance(node)
+}
+
+func (t *IntervalTree[K, V]) delete(node *intervalNode[K, V], start, end K) (*intervalNode[K, V], int) {
+	if node == nil {
+		return nil, 0
+	}
+
+	var removed int
+	c := t.compareInterval(node, start, end)
+	switch {
+	case c < 0:
+		node.left, removed = t.delete(node.left, start, end)
+	case c > 0:
+		node.right, removed = t.delete(node.right, start, end)
+	default:
+		removed = len(node.values)
+		if node.left == nil {
+			return node.right, removed
+		}
+		if node.right == nil {
+			return node.left, removed
+		}
+
+		successor := node.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		node.start, node.end, node.values = successor.start, successor.end, successor.values
+		node.right = t.deleteMin(node.right)
+	}
+
+	return t.rebalance(node), removed
+}
+
+func (t *IntervalTree[K, V]) deleteMin(node *intervalNode[K, V]) *intervalNode[K, V] {
+	if node.left == nil {
+		return node.right
+	}
+	node.left = t.deleteMin(node.left)
+	return t.rebalance(node)
+}
+
+func (t *IntervalTree[K, V]) overlap(node *intervalNode[K, V], start, end K, result *[]Interval[K, V]) {
+	// no interval in this subtree ends after start
+	if node == nil || t.comparator.Compare(node.maxEnd, start) < 0 {
+		return
+	}
+
+	t.overlap(node.left, start, end, result)
+
+	if t.isOverlap(node, start, end) {
+		for _, v := range node.values {
+			*result = append(*result, Interval[K, V]{Start: node.start, End: node.end, Value: v})
+		}
+	}
+
+	// intervals in right subtree start after this node, skip them if this node starts after end
+	if t.comparator.Compare(node.start, end) <= 0 {
+		t.overlap(node.right, start, end, result)
+	}
+}
+
+func (t *IntervalTree[K, V]) update(node *intervalNode[K, V]) {
+	node.height = 1 + intervalNodeHeight(node.left)
+	if h := 1 + intervalNodeHeight(node.right); h > node.height {
+		node.height = h
+	}
+
+	node.maxEnd = node.end
+	if node.left != nil && t.comparator.Compare(node.left.maxEnd, node.maxEnd) > 0 {
+		node.maxEnd = node.left.maxEnd
+	}
+	if node.right != nil && t.comparator.Compare(node.right.maxEnd, node.maxEnd) > 0 {
+		node.maxEnd = node.right.maxEnd
+	}
+}
+
+func (t *IntervalTree[K, V]) rotateLeft(node *intervalNode[K, V]) *intervalNode[K, V] {
+	right := node.right
+	node.right = right.left
+	right.left = node
+	t.update(node)
+	t.update(right)
+	return right
+}
+
+func (t *IntervalTree[K, V]) rotateRight(node *intervalNode[K, V]) *intervalNode[K, V] {
+	left := node.left
+	node.left = left.right
+	left.right = node
+	t.update(node)
+	t.update(left)
+	return left
+}
+
+func (t *IntervalTree[K, V]) rebalance(node *intervalNode[K, V]) *intervalNode[K, V] {
+	t.update(node)
+
+	balance := intervalNodeHeight(node.left) - intervalNodeHeight(node.right)
+	if balance > 1 {
+		if intervalNodeHeight(node.left.left) < intervalNodeHeight(node.left.right) {
+			node.left = t.rotateLeft(node.left)
+		}
+		return t.rotateRight(node)
+	}
+	if balance < -1 {
+		if intervalNodeHeight(node.right.right) < intervalNodeHeight(node.right.left) {
+			node.right = t.rotateRight(node.right)
+		}
+		return t.rotateLeft(node)
+	}
+
+	return node
+}
+
+func intervalNodeHeight[K any, V any](node *intervalNode[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+func collectIntervals[K any, V any](node *intervalNode[K, V], result *[]Interval[K, V]) {
+	if node == nil {
+		return
+	}
+	collectIntervals(node.left, result)
+	for _, v := range node.values {
+		*result = append(*result, Interval[K, V]{Start: node.start, End: node.end, Value: v})
+	}
+	collectIntervals(node.right, result)
+}
+
+// timeComparator compares two time.Time values.
+type timeComparator struct{}
+
+func (c *timeComparator) Compare(v1, v2 any) int {
+	t1, _ := v1.(time.Time)
+	t2, _ := v2.(time.Time)
+
+	if t1.Before(t2) {
+		return -1
+	} else if t1.After(t2) {
+		return 1
+	}
+	return 0
+}
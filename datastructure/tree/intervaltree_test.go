@@ -0,0 +1,150 @@
+package datastructure
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/datetime"
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestIntervalTree_Insert(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestIntervalTree_Insert")
+
+	tree := NewIntervalTree[int, string](&intComparator{})
+
+	assert.IsNil(tree.Insert(15, 20, "a"))
+	assert.IsNil(tree.Insert(10, 30, "b"))
+	assert.IsNil(tree.Insert(17, 19, "c"))
+	assert.IsNil(tree.Insert(5, 20, "d"))
+	assert.IsNil(tree.Insert(12, 15, "e"))
+	assert.IsNil(tree.Insert(30, 40, "f"))
+	assert.IsNotNil(tree.Insert(3, 1, "g"))
+
+	assert.Equal(6, tree.Size())
+
+	intervals := tree.Intervals()
+	starts := []int{}
+	for _, interval := range intervals {
+		starts = append(starts, interval.Start)
+	}
+	assert.Equal([]int{5, 10, 12, 15, 17, 30}, starts)
+}
+
+func TestIntervalTree_Overlap(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestIntervalTree_Overlap")
+
+	tree := NewIntervalTree[int, string](&intComparator{})
+	tree.Insert(15, 20, "a")
+	tree.Insert(10, 30, "b")
+	tree.Insert(17, 19, "c")
+	tree.Insert(5, 20, "d")
+	tree.Insert(12, 15, "e")
+	tree.Insert(30, 40, "f")
+
+	values := func(intervals []Interval[int, string]) []string {
+		result := []string{}
+		for _, interval := range intervals {
+			result = append(result, interval.Value)
+		}
+		return result
+	}
+
+	assert.Equal([]string{"b", "f"}, values(tree.Overlap(25, 35)))
+	assert.Equal([]string{"d", "b", "e", "a"}, values(tree.Overlap(15, 16)))
+	assert.Equal([]string{}, values(tree.Overlap(41, 50)))
+
+	assert.Equal([]string{"b", "f"}, values(tree.Stab(30)))
+	assert.Equal([]string{"d"}, values(tree.Stab(5)))
+
+	assert.Equal(true, tree.HasOverlap(0, 5))
+	assert.Equal(false, tree.HasOverlap(0, 4))
+}
+
+func TestIntervalTree_Delete(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestIntervalTree_Delete")
+
+	tree := NewIntervalTree[int, int](&intComparator{})
+	tree.Insert(1, 5, 1)
+	tree.Insert(1, 5, 2)
+	tree.Insert(3, 8, 3)
+
+	assert.Equal(2, tree.Delete(1, 5))
+	assert.Equal(0, tree.Delete(1, 5))
+	assert.Equal(1, tree.Size())
+	assert.Equal(0, len(tree.Stab(2)))
+	assert.Equal(1, len(tree.Stab(4)))
+
+	tree.Clear()
+	assert.Equal(true, tree.IsEmpty())
+}
+
+func TestIntervalTree_Random(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestIntervalTree_Random")
+
+	r := rand.New(rand.NewSource(1))
+	tree := NewIntervalTree[int, int](&intComparator{})
+
+	type pair struct{ start, end int }
+	all := []pair{}
+	for i := 0; i < 300; i++ {
+		start := r.Intn(1000)
+		end := start + r.Intn(50)
+		tree.Insert(start, end, i)
+		all = append(all, pair{start, end})
+	}
+	for i := 0; i < 100; i++ {
+		p := all[i]
+		tree.Delete(p.start, p.end)
+	}
+
+	remaining := map[pair]int{}
+	for _, interval := range tree.Intervals() {
+		remaining[pair{interval.Start, interval.End}]++
+	}
+
+	for i := 0; i < 100; i++ {
+		start := r.Intn(1000)
+		end := start + r.Intn(30)
+
+		expected := 0
+		for p, count := range remaining {
+			if p.start <= end && start <= p.end {
+				expected += count
+			}
+		}
+		assert.Equal(expected, len(tree.Overlap(start, end)))
+		assert.Equal(expected > 0, tree.HasOverlap(start, end))
+	}
+}
+
+func TestIntervalTree_TimeRange(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestIntervalTree_TimeRange")
+
+	day := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tree := NewTimeIntervalTree[string]()
+	tree.Insert(datetime.BeginOfDay(day), datetime.EndOfDay(day), "today")
+	tree.Insert(datetime.BeginOfWeek(day, time.Monday), datetime.EndOfWeek(day, time.Sunday), "this week")
+	tree.Insert(datetime.BeginOfMonth(day.AddDate(0, 1, 0)), datetime.EndOfMonth(day.AddDate(0, 1, 0)), "next month")
+
+	result := tree.Stab(day.Add(time.Hour))
+	assert.Equal(2, len(result))
+	assert.Equal("today", result[0].Value)
+	assert.Equal("this week", result[1].Value)
+
+	result = tree.Overlap(datetime.EndOfMonth(day), datetime.AddDay(datetime.EndOfMonth(day), 1))
+	assert.Equal(1, len(result))
+	assert.Equal("next month", result[0].Value)
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package enum
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// FlagSeparator is the separator of flag names in the string form of flags, eg: "READ|WRITE".
+const FlagSeparator = "|"
+
+// FlagRegistry is a registry of bit flag enum items, flags can be combined into one value and split back.
+type FlagRegistry[T constraints.Integer] struct {
+	*Registry[T]
+}
+
+// NewFlagRegistry creates a new flag registry, value of item should be a single bit or a combination of bits.
+func NewFlagRegistry[T constraints.Integer](items ...*Item[T]) *FlagRegistry[T] {
+	return &FlagRegistry[T]{Registry: NewRegistry(items...)}
+}
+
+// Combine returns the value of all flags combined.
+func (r *FlagRegistry[T]) Combine(flags ...*Item[T]) T {
+	var result T
+	for _, flag := range flags {
+		result |= flag.value
+	}
+	return result
+}
+
+// CombineNames returns the value of flags combined by their names, it returns error if a name is not registered.
+func (r *FlagRegistry[T]) CombineNames(names ...string) (T, error) {
+	var result T
+	for _, name := range names {
+		item, ok := r.GetByName(name)
+		if !ok {
+			return 0, fmt.Errorf("enum: unknown flag name %q", name)
+		}
+		result |= item.value
+	}
+	return result, nil
+}
+
+// Has checks if value contains all bits of flag.
+func (r *FlagRegistry[T]) Has(value T, flag T) bool {
+	return value&flag == flag
+}
+
+// Mask returns the combined value of all registered flags.
+func (r *FlagRegistry[T]) Mask() T {
+	var mask T
+	r.Range(func(item *Item[T]) bool {
+		mask |= item.value
+		return true
+	})
+	return mask
+}
+
+// IsValid checks if all bits of value are registered flags.
+func (r *FlagRegistry[T]) IsValid(value T) bool {
+	return value&^r.Mask() == 0
+}
+
+// Split returns the flags contained in value in registration order,
+// a flag is skipped if all its bits are already covered by the flags before it.
+func (r *FlagRegistry[T]) Split(value T) []*Item[T] {
+	result := []*Item[T]{}
+
+	var covered T
+	r.Range(func(item *Item[T]) bool {
+		if item.value != 0 && value&item.value == item.value && item.value&^covered != 0 {
+			result = append(result, item)
+			covered |= item.value
+		}
+		return true
+	})
+
+	return result
+}
+
+// Format returns the string form of value, names of flags are joined by "|", eg: "READ|WRITE".
+// bits which are not registered are formatted in hex, zero is formatted as the name of zero flag or "0".
+func (r *FlagRegistry[T]) Format(value T) string {
+	if value == 0 {
+		if item, ok := r.GetByValue(0); ok {
+			return item.name
+		}
+		return "0"
+	}
+
+	flags := r.Split(value)
+	names := make([]string, 0, len(flags)+1)
+
+	var covered T
+	for _, flag := range flags {
+		names = append(names, flag.name)
+		covered |= flag.value
+	}
+
+	if rest := value &^ covered; rest != 0 {
+		names = append(names, fmt.Sprintf("0x%x", uint64(rest)))
+	}
+
+	return strings.Join(names, FlagSeparator)
+}
+
+// Parse parses the string form of flags, eg: "READ|WRITE", it's the inverse of Format.
+// spaces around names are ignored, numbers are accepted as well.
+func (r *FlagRegistry[T]) Parse(s string) (T, error) {
+	var result T
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return result, nil
+	}
+
+	for _, part := range strings.Split(s, FlagSeparator) {
+		name := strings.TrimSpace(part)
+		if item, ok := r.GetByName(name); ok {
+			result |= item.value
+			continue
+		}
+
+		n, err := strconv.ParseUint(name, 0, 64)
+		if err != nil {
+			return 0, fmt.Errorf("enum: unknown flag name %q", name)
+		}
+		if uint64(T(n)) != n {
+			return 0, fmt.Errorf("enum: flag value %s overflows %T", name, result)
+		}
+		result |= T(n)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,96 @@
+package enum
+
+import (
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+type Permission uint8
+
+const (
+	Read Permission = 1 << iota
+	Write
+	Execute
+)
+
+func newPermissionRegistry() *FlagRegistry[Permission] {
+	return NewFlagRegistry(
+		NewItem(Read, "READ"),
+		NewItem(Write, "WRITE"),
+		NewItem(Execute, "EXECUTE"),
+	)
+}
+
+func TestFlagRegistry_Combine_Split(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestFlagRegistry_Combine_Split")
+
+	registry := newPermissionRegistry()
+	read, _ := registry.GetByName("READ")
+	write, _ := registry.GetByName("WRITE")
+
+	value := registry.Combine(read, write)
+	assert.Equal(Read|Write, value)
+
+	value, err := registry.CombineNames("READ", "EXECUTE")
+	assert.IsNil(err)
+	assert.Equal(Read|Execute, value)
+
+	_, err = registry.CombineNames("DELETE")
+	assert.IsNotNil(err)
+
+	flags := registry.Split(Read | Execute)
+	assert.Equal(2, len(flags))
+	assert.Equal("READ", flags[0].Name())
+	assert.Equal("EXECUTE", flags[1].Name())
+
+	assert.Equal(true, registry.Has(Read|Write, Write))
+	assert.Equal(false, registry.Has(Read, Read|Write))
+	assert.Equal(Read|Write|Execute, registry.Mask())
+	assert.Equal(true, registry.IsValid(Read|Execute))
+	assert.Equal(false, registry.IsValid(Permission(8)))
+}
+
+func TestFlagRegistry_Format_Parse(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestFlagRegistry_Format_Parse")
+
+	registry := newPermissionRegistry()
+
+	assert.Equal("READ|WRITE", registry.Format(Read|Write))
+	assert.Equal("EXECUTE", registry.Format(Execute))
+	assert.Equal("READ|0x10", registry.Format(Read|16))
+	assert.Equal("0", registry.Format(0))
+
+	value, err := registry.Parse("READ|WRITE")
+	assert.IsNil(err)
+	assert.Equal(Read|Write, value)
+
+	value, err = registry.Parse(" WRITE | EXECUTE ")
+	assert.IsNil(err)
+	assert.Equal(Write|Execute, value)
+
+	value, err = registry.Parse(registry.Format(Read | 16))
+	assert.IsNil(err)
+	assert.Equal(Read|16, value)
+
+	value, err = registry.Parse("")
+	assert.IsNil(err)
+	assert.Equal(Permission(0), value)
+
+	_, err = registry.Parse("READ|DELETE")
+	assert.IsNotNil(err)
+
+	// 0x101 overflows uint8.
+	_, err = registry.Parse("READ|0x101")
+	assert.IsNotNil(err)
+
+	registry.Add(NewItem(Permission(0), "NONE"), NewItem(Read|Write, "READ_WRITE"))
+	assert.Equal("NONE", registry.Format(0))
+	assert.Equal("READ|WRITE", registry.Format(Read|Write))
+
+	value, err = registry.Parse("READ_WRITE|EXECUTE")
+	assert.IsNil(err)
+	assert.Equal(Read|Write|Execute, value)
+}
@@ -0,0 +1,192 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package enum
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+var defaultRegistries sync.Map // map[reflect.Type]any
+
+// SetDefaultRegistry sets the default registry of enum type T,
+// it is used by Item.Scan and Item.UnmarshalText to restore the item by its value or name.
+func SetDefaultRegistry[T comparable](r *Registry[T]) {
+	defaultRegistries.Store(reflect.TypeOf((*T)(nil)).Elem(), r)
+}
+
+// DefaultRegistry returns the default registry of enum type T.
+func DefaultRegistry[T comparable]() (*Registry[T], bool) {
+	r, ok := defaultRegistries.Load(reflect.TypeOf((*T)(nil)).Elem())
+	if !ok {
+		return nil, false
+	}
+	return r.(*Registry[T]), true
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, the item is encoded as its name.
+// it makes items work with yaml, toml and other text based formats.
+func (it *Item[T]) MarshalText() ([]byte, error) {
+	if it.name == "" {
+		return []byte(fmt.Sprint(it.value)), nil
+	}
+	return []byte(it.name), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, text can be the name or value of item.
+// the item is looked up in the default registry of T, see SetDefaultRegistry.
+// if there is no default registry, text is decoded as value and the name is empty.
+func (it *Item[T]) UnmarshalText(text []byte) error {
+	registry, ok := DefaultRegistry[T]()
+	if ok {
+		if item, found := registry.GetByName(string(text)); found {
+			it.value, it.name = item.value, item.name
+			return nil
+		}
+	}
+
+	value, err := convertValue[T](string(text))
+	if err != nil {
+		return err
+	}
+
+	return it.setValue(value, registry)
+}
+
+// Scan implements the sql.Scanner interface, the value read from database is converted to T.
+// the name of item is looked up in the default registry of T, see SetDefaultRegistry.
+func (it *Item[T]) Scan(src any) error {
+	if src == nil {
+		return fmt.Errorf("enum: can not scan nil into Item[%T]", it.value)
+	}
+
+	value, err := convertValue[T](src)
+	if err != nil {
+		return err
+	}
+
+	registry, _ := DefaultRegistry[T]()
+	return it.setValue(value, registry)
+}
+
+// Valuer returns a driver.Valuer of item, which stores the value of item into database.
+// Item can not implement driver.Valuer directly because its Value method returns T.
+func (it *Item[T]) Valuer() driver.Valuer {
+	return itemValuer[T]{item: it}
+}
+
+type itemValuer[T comparable] struct {
+	item *Item[T]
+}
+
+// Value implements the driver.Valuer interface.
+func (v itemValuer[T]) Value() (driver.Value, error) {
+	if v.item == nil {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v.item.value)
+}
+
+func (it *Item[T]) setValue(value T, registry *Registry[T]) error {
+	if registry == nil {
+		it.value, it.name = value, ""
+		return nil
+	}
+
+	item, ok := registry.GetByValue(value)
+	if !ok {
+		return fmt.Errorf("enum: value %v is not registered", value)
+	}
+	it.value, it.name = item.value, item.name
+
+	return nil
+}
+
+// convertValue converts src read from database or text to T.
+func convertValue[T comparable](src any) (T, error) {
+	var zero T
+	target := reflect.TypeOf(zero)
+
+	if b, ok := src.([]byte); ok {
+		src = string(b)
+	}
+
+	if v, ok := src.(T); ok {
+		return v, nil
+	}
+
+	if s, ok := src.(string); ok {
+		var parsed any
+		var err error
+
+		switch target.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			parsed, err = strconv.ParseInt(s, 10, 64)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			parsed, err = strconv.ParseUint(s, 10, 64)
+		case reflect.Float32, reflect.Float64:
+			parsed, err = strconv.ParseFloat(s, 64)
+		case reflect.Bool:
+			parsed, err = strconv.ParseBool(s)
+		default:
+			parsed = s
+		}
+		if err != nil {
+			return zero, fmt.Errorf("enum: can not convert %q to %v: %w", s, target, err)
+		}
+		src = parsed
+	}
+
+	rv := reflect.ValueOf(src)
+	if !rv.IsValid() || !rv.Type().ConvertibleTo(target) {
+		return zero, fmt.Errorf("enum: can not convert %T to %v", src, target)
+	}
+	if (rv.Kind() == reflect.String) != (target.Kind() == reflect.String) {
+		return zero, fmt.Errorf("enum: can not convert %T to %v", src, target)
+	}
+	if !representable(rv, target) {
+		return zero, fmt.Errorf("enum: %v can not be represented by %v", src, target)
+	}
+
+	return rv.Convert(target).Interface().(T), nil
+}
+
+// representable checks if the numeric value rv can be converted to target type without truncation.
+func representable(rv reflect.Value, target reflect.Type) bool {
+	converted := reflect.New(target).Elem()
+
+	switch target.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return !converted.OverflowInt(rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return rv.Uint() <= math.MaxInt64 && !converted.OverflowInt(int64(rv.Uint()))
+		case reflect.Float32, reflect.Float64:
+			f := rv.Float()
+			return f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 && !converted.OverflowInt(int64(f))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return rv.Int() >= 0 && !converted.OverflowUint(uint64(rv.Int()))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return !converted.OverflowUint(rv.Uint())
+		case reflect.Float32, reflect.Float64:
+			f := rv.Float()
+			return f == math.Trunc(f) && f >= 0 && f < math.MaxUint64 && !converted.OverflowUint(uint64(f))
+		}
+	case reflect.Float32, reflect.Float64:
+		switch rv.Kind() {
+		case reflect.Float32, reflect.Float64:
+			return !converted.OverflowFloat(rv.Float())
+		}
+	}
+
+	return true
+}
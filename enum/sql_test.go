@@ -0,0 +1,120 @@
+package enum
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+var (
+	_ sql.Scanner              = (*Item[Status])(nil)
+	_ encoding.TextMarshaler   = (*Item[Status])(nil)
+	_ encoding.TextUnmarshaler = (*Item[Status])(nil)
+)
+
+type Level string
+
+const (
+	Debug Level = "debug"
+	Info  Level = "info"
+)
+
+func init() {
+	SetDefaultRegistry(NewRegistry(
+		NewItem(Debug, "DEBUG"),
+		NewItem(Info, "INFO"),
+	))
+}
+
+func TestItem_Text(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestItem_Text")
+
+	text, err := NewItem(Info, "INFO").MarshalText()
+	assert.IsNil(err)
+	assert.Equal("INFO", string(text))
+
+	text, err = NewItem(Info, "").MarshalText()
+	assert.IsNil(err)
+	assert.Equal("info", string(text))
+
+	var item Item[Level]
+	assert.IsNil(item.UnmarshalText([]byte("DEBUG")))
+	assert.Equal(Debug, item.Value())
+	assert.Equal("DEBUG", item.Name())
+
+	assert.IsNil(item.UnmarshalText([]byte("info")))
+	assert.Equal(Info, item.Value())
+	assert.Equal("INFO", item.Name())
+
+	assert.IsNotNil(item.UnmarshalText([]byte("trace")))
+
+	// without default registry, only the value is restored.
+	var status Item[Status]
+	assert.IsNil(status.UnmarshalText([]byte("1")))
+	assert.Equal(Active, status.Value())
+	assert.Equal("", status.Name())
+	assert.IsNotNil(status.UnmarshalText([]byte("Active")))
+}
+
+func TestItem_SQL(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestItem_SQL")
+
+	value, err := NewItem(Active, "Active").Valuer().Value()
+	assert.IsNil(err)
+	assert.Equal(int64(1), value)
+
+	value, err = NewItem(Debug, "DEBUG").Valuer().Value()
+	assert.IsNil(err)
+	assert.Equal("debug", value)
+
+	assert.Equal(true, driver.IsValue(value))
+
+	var status Item[Status]
+	assert.IsNil(status.Scan(int64(2)))
+	assert.Equal(Inactive, status.Value())
+
+	assert.IsNil(status.Scan([]byte("1")))
+	assert.Equal(Active, status.Value())
+
+	assert.IsNotNil(status.Scan(nil))
+	assert.IsNotNil(status.Scan("abc"))
+
+	// values are not truncated.
+	assert.IsNil(status.Scan(2.0))
+	assert.Equal(Inactive, status.Value())
+	assert.IsNotNil(status.Scan(1.9))
+	assert.IsNotNil(status.Scan("1.5"))
+
+	var permission Item[Permission]
+	assert.IsNil(permission.Scan(int64(4)))
+	assert.Equal(Execute, permission.Value())
+	assert.IsNotNil(permission.Scan(int64(300)))
+	assert.IsNotNil(permission.Scan(int64(-1)))
+	assert.IsNotNil(permission.Scan("256"))
+	assert.Equal(Execute, permission.Value())
+
+	var level Item[Level]
+	assert.IsNil(level.Scan([]byte("info")))
+	assert.Equal(Info, level.Value())
+	assert.Equal("INFO", level.Name())
+
+	assert.IsNotNil(level.Scan("trace"))
+	assert.IsNotNil(level.Scan(int64(1)))
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestDefaultRegistry")
+
+	registry, ok := DefaultRegistry[Level]()
+	assert.Equal(true, ok)
+	assert.Equal(2, registry.Size())
+
+	_, ok = DefaultRegistry[Permission]()
+	assert.Equal(false, ok)
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+
+	"github.com/duke-git/lancet/v2/xerror"
 )
 
 // Event is the struct that is passed to the event listener, now it directly uses the generic Payload type.
@@ -117,14 +119,15 @@ func (eb *EventBus[T]) Publish(event Event[T]) {
 func (eb *EventBus[T]) publishToListener(listener *EventListener[T], event Event[T]) {
 	defer func() {
 		if r := recover(); r != nil && eb.errorHandler != nil {
-			eb.errorHandler(event.Topic, fmt.Errorf("%v", r))
+			eb.errorHandler(event.Topic, xerror.FromPanic(r))
 		}
 	}()
 
 	listener.listener(event.Payload)
 }
 
-// SetErrorHandler sets the error handler function.
+// SetErrorHandler sets the error handler function, panics in listeners are passed to it as *xerror.XError
+// with the stack trace of the panic site.
 // Play: https://go.dev/play/p/gmB0gnFe5mc
 func (eb *EventBus[T]) SetErrorHandler(handler func(topic string, err error)) {
 	eb.errorHandler = handler
@@ -329,7 +329,7 @@ func TestListFileNames(t *testing.T) {
 	filesInPath, err := ListFileNames("../internal")
 	assert.IsNil(err)
 
-	expected := []string{"assert.go", "assert_test.go", "error_join.go"}
+	expected := []string{"assert.go", "assert_test.go", "error_join.go", "hash.go", "hash_test.go"}
 	assert.Equal(expected, filesInPath)
 }
 
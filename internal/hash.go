@@ -0,0 +1,201 @@
+package internal
+
+import (
+	"math"
+	"reflect"
+	"sync"
+)
+
+// Hash returns a 64-bit hash value of comparable value, common types are hashed directly,
+// pointers and channels are hashed by address, structs and arrays are hashed field by field.
+// equal values always have same hash value. do not use it outside lancet lib.
+func Hash[T comparable](value T) uint64 {
+	switch v := any(value).(type) {
+	case int:
+		return mixHash(uint64(v))
+	case int8:
+		return mixHash(uint64(v))
+	case int16:
+		return mixHash(uint64(v))
+	case int32:
+		return mixHash(uint64(v))
+	case int64:
+		return mixHash(uint64(v))
+	case uint:
+		return mixHash(uint64(v))
+	case uint8:
+		return mixHash(uint64(v))
+	case uint16:
+		return mixHash(uint64(v))
+	case uint32:
+		return mixHash(uint64(v))
+	case uint64:
+		return mixHash(v)
+	case uintptr:
+		return mixHash(uint64(v))
+	case float32:
+		return hashFloat(float64(v))
+	case float64:
+		return hashFloat(v)
+	case bool:
+		if v {
+			return mixHash(1)
+		}
+		return mixHash(0)
+	case string:
+		return HashString(v)
+	default:
+		rv := reflect.ValueOf(&value).Elem()
+		return hasherOf(rv.Type())(rv)
+	}
+}
+
+// NewHasher returns the hash function of type T, it returns same hash value as Hash,
+// but the hash function of struct, array and pointer types is resolved once instead of every call.
+func NewHasher[T comparable]() func(value T) uint64 {
+	var zero T
+	switch any(zero).(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr,
+		float32, float64, bool, string:
+		return Hash[T]
+	}
+
+	hasher := hasherOf(reflect.TypeOf(&zero).Elem())
+	return func(value T) uint64 {
+		return hasher(reflect.ValueOf(&value).Elem())
+	}
+}
+
+// HashString returns the 64-bit FNV-1a hash value of string.
+func HashString(s string) uint64 {
+	const (
+		offset64 = 14695981039346656037
+		prime64  = 1099511628211
+	)
+
+	h := uint64(offset64)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= prime64
+	}
+	return h
+}
+
+// valueHasher is the hash function of a reflect type.
+type valueHasher func(v reflect.Value) uint64
+
+// hasherCache caches the valueHasher of reflect types, map[reflect.Type]valueHasher.
+var hasherCache sync.Map
+
+// hasherOf returns the valueHasher of type, the hasher is built once for each type.
+func hasherOf(typ reflect.Type) valueHasher {
+	if h, ok := hasherCache.Load(typ); ok {
+		return h.(valueHasher)
+	}
+
+	h := newHasher(typ)
+	hasherCache.Store(typ, h)
+
+	return h
+}
+
+func newHasher(typ reflect.Type) valueHasher {
+	switch typ.Kind() {
+	case reflect.Bool:
+		return func(v reflect.Value) uint64 {
+			if v.Bool() {
+				return mixHash(1)
+			}
+			return mixHash(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(v reflect.Value) uint64 {
+			return mixHash(uint64(v.Int()))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(v reflect.Value) uint64 {
+			return mixHash(v.Uint())
+		}
+	case reflect.Float32, reflect.Float64:
+		return func(v reflect.Value) uint64 {
+			return hashFloat(v.Float())
+		}
+	case reflect.Complex64, reflect.Complex128:
+		return func(v reflect.Value) uint64 {
+			c := v.Complex()
+			return combineHash(hashFloat(real(c)), hashFloat(imag(c)))
+		}
+	case reflect.String:
+		return func(v reflect.Value) uint64 {
+			return HashString(v.String())
+		}
+	case reflect.Ptr, reflect.Chan, reflect.UnsafePointer, reflect.Func:
+		// pointers are equal if they point to the same variable, the pointee may change.
+		return func(v reflect.Value) uint64 {
+			return mixHash(uint64(v.Pointer()))
+		}
+	case reflect.Interface:
+		return func(v reflect.Value) uint64 {
+			if v.IsNil() {
+				return mixHash(0)
+			}
+			elem := v.Elem()
+			return hasherOf(elem.Type())(elem)
+		}
+	case reflect.Array:
+		elemHasher := hasherOf(typ.Elem())
+		return func(v reflect.Value) uint64 {
+			h := mixHash(uint64(v.Len()))
+			for i := 0; i < v.Len(); i++ {
+				h = combineHash(h, elemHasher(v.Index(i)))
+			}
+			return h
+		}
+	case reflect.Struct:
+		// blank fields are ignored by ==, so they aren't hashed.
+		var fields []int
+		var fieldHashers []valueHasher
+		for i := 0; i < typ.NumField(); i++ {
+			if typ.Field(i).Name == "_" {
+				continue
+			}
+			fields = append(fields, i)
+			fieldHashers = append(fieldHashers, hasherOf(typ.Field(i).Type))
+		}
+		return func(v reflect.Value) uint64 {
+			h := mixHash(uint64(len(fieldHashers)))
+			for i, fieldHasher := range fieldHashers {
+				h = combineHash(h, fieldHasher(v.Field(fields[i])))
+			}
+			return h
+		}
+	default:
+		// slice and map are not comparable, an interface holding them panics when compared by ==.
+		return func(v reflect.Value) uint64 {
+			return 0
+		}
+	}
+}
+
+// combineHash combines two hash values, the order matters.
+func combineHash(h, x uint64) uint64 {
+	return mixHash(h ^ (x + 0x9e3779b97f4a7c15 + (h << 6) + (h >> 2)))
+}
+
+// hashFloat hashes float value, +0 and -0 are equal keys of map, so they must be hashed to same value.
+func hashFloat(f float64) uint64 {
+	if f == 0 {
+		return mixHash(0)
+	}
+	return mixHash(math.Float64bits(f))
+}
+
+// mixHash scrambles the bits of integer (splitmix64 finalizer), so that sequential keys are spread evenly.
+func mixHash(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+)
+
+func TestHash(t *testing.T) {
+	assert := NewAssert(t, "TestHash")
+
+	negZero := 0.0
+	negZero = -negZero
+
+	assert.Equal(Hash(1), Hash(1))
+	assert.NotEqual(Hash(1), Hash(2))
+	assert.Equal(Hash(0.0), Hash(negZero))
+	assert.Equal(Hash("abc"), HashString("abc"))
+	assert.NotEqual(Hash("abc"), Hash("abd"))
+
+	type point struct{ x, y int }
+	assert.Equal(Hash(point{1, 2}), Hash(point{1, 2}))
+	assert.NotEqual(Hash(point{1, 2}), Hash(point{2, 1}))
+}
+
+func TestHash_Reflect(t *testing.T) {
+	assert := NewAssert(t, "TestHash_Reflect")
+
+	// pointers are hashed by address, not by the value they point to.
+	p := &struct{ name string }{"a"}
+	h := Hash(p)
+	p.name = "b"
+	assert.Equal(h, Hash(p))
+	assert.NotEqual(Hash(p), Hash(&struct{ name string }{"b"}))
+
+	type id int
+	assert.Equal(Hash(id(1)), Hash(id(1)))
+	assert.NotEqual(Hash(id(1)), Hash(id(2)))
+
+	negZero := 0.0
+	negZero = -negZero
+
+	type key struct {
+		name  string
+		value float64
+		ptr   *int
+		tags  [2]string
+	}
+	n := 1
+	assert.Equal(Hash(key{"a", 0, &n, [2]string{"x", "y"}}), Hash(key{"a", negZero, &n, [2]string{"x", "y"}}))
+	assert.NotEqual(Hash(key{"a", 0, &n, [2]string{"x", "y"}}), Hash(key{"a", 0, &n, [2]string{"y", "x"}}))
+	assert.NotEqual(Hash(key{"a", 0, &n, [2]string{"x", "y"}}), Hash(key{"b", 0, &n, [2]string{"x", "y"}}))
+	assert.Equal(Hash(key{}), Hash(key{}))
+
+	hashAny := func(x any) uint64 {
+		v := reflect.ValueOf(&x).Elem()
+		return hasherOf(v.Type())(v)
+	}
+	assert.Equal(Hash("abc"), hashAny("abc"))
+	assert.Equal(Hash(key{}), hashAny(key{}))
+	assert.Equal(hashAny(nil), hashAny(nil))
+	assert.Equal(Hash(complex(1, 2)), Hash(complex(1, 2)))
+	assert.NotEqual(Hash(complex(1, 2)), Hash(complex(2, 1)))
+
+	// blank fields are ignored by ==.
+	type padded struct {
+		value int
+		_     int32
+	}
+	x, y := padded{value: 1}, padded{value: 1}
+	blank := reflect.TypeOf(y).Field(1).Offset
+	*(*int32)(unsafe.Add(unsafe.Pointer(&y), blank)) = 7
+	assert.Equal(true, x == y)
+	assert.Equal(Hash(x), Hash(y))
+}
+
+func TestNewHasher(t *testing.T) {
+	assert := NewAssert(t, "TestNewHasher")
+
+	type point struct{ x, y int }
+	hasher := NewHasher[point]()
+	assert.Equal(Hash(point{1, 2}), hasher(point{1, 2}))
+	assert.NotEqual(hasher(point{1, 2}), hasher(point{2, 1}))
+
+	assert.Equal(Hash("abc"), NewHasher[string]()("abc"))
+
+	n := 1
+	assert.Equal(Hash(&n), NewHasher[*int]()(&n))
+}
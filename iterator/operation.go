@@ -10,6 +10,11 @@
 // Hope that Go can support iterator in future. see https://github.com/golang/go/discussions/54245 and https://github.com/golang/go/discussions/56413
 package iterator
 
+import (
+	"github.com/duke-git/lancet/v2/datastructure/either"
+	"github.com/duke-git/lancet/v2/datastructure/result"
+)
+
 // Map creates a new iterator which applies a function to all items of input iterator.
 func Map[T any, U any](iter Iterator[T], iteratee func(item T) U) Iterator[U] {
 	return &mapIterator[T, U]{
@@ -137,3 +142,54 @@ func (iter *takeIterator[T]) Next() (T, bool) {
 func (iter *takeIterator[T]) HasNext() bool {
 	return iter.num > 0
 }
+
+// TryMap creates a new iterator which applies a fallible function to items of input iterator and yields Result of it.
+// the iteration stops after the first failed Result is yielded.
+func TryMap[T any, U any](iter Iterator[T], iteratee func(item T) (U, error)) Iterator[result.Result[U]] {
+	return &tryMapIterator[T, U]{iter: iter, iteratee: iteratee}
+}
+
+type tryMapIterator[T any, U any] struct {
+	iter     Iterator[T]
+	iteratee func(T) (U, error)
+	failed   bool
+}
+
+func (iter *tryMapIterator[T, U]) HasNext() bool {
+	return !iter.failed && iter.iter.HasNext()
+}
+
+func (iter *tryMapIterator[T, U]) Next() (result.Result[U], bool) {
+	if iter.failed {
+		return result.Result[U]{}, false
+	}
+
+	item, ok := iter.iter.Next()
+	if !ok {
+		return result.Result[U]{}, false
+	}
+
+	r := result.From(iter.iteratee(item))
+	iter.failed = r.IsErr()
+
+	return r, true
+}
+
+// CollectResults consumes iter and returns a successful Result of all values,
+// it stops at the first failed Result and returns it.
+func CollectResults[T any](iter Iterator[result.Result[T]]) result.Result[[]T] {
+	values := []T{}
+	for item, ok := iter.Next(); ok; item, ok = iter.Next() {
+		value, err := item.Get()
+		if err != nil {
+			return result.Err[[]T](err)
+		}
+		values = append(values, value)
+	}
+	return result.Ok(values)
+}
+
+// PartitionEither consumes iter and splits it into left values and right values.
+func PartitionEither[L any, R any](iter Iterator[either.Either[L, R]]) ([]L, []R) {
+	return either.Partition(ToSlice(iter))
+}
@@ -11,8 +11,12 @@
 package iterator
 
 import (
+	"errors"
+	"strconv"
 	"testing"
 
+	"github.com/duke-git/lancet/v2/datastructure/either"
+	"github.com/duke-git/lancet/v2/datastructure/result"
 	"github.com/duke-git/lancet/v2/internal"
 )
 
@@ -81,3 +85,57 @@ func TestTakeIterator(t *testing.T) {
 	result := ToSlice(iter)
 	assert.Equal([]int{1, 2, 3}, result)
 }
+
+func TestTryMapIterator(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestTryMapIterator")
+
+	iter := TryMap[string, int](FromSlice([]string{"1", "2"}), strconv.Atoi)
+	assert.Equal([]int{1, 2}, CollectResults(iter).Unwrap())
+
+	source := FromSlice([]string{"1", "a", "3"})
+	iter = TryMap[string, int](source, strconv.Atoi)
+
+	r, ok := iter.Next()
+	assert.Equal(true, ok)
+	assert.Equal(1, r.Unwrap())
+
+	r, ok = iter.Next()
+	assert.Equal(true, ok)
+	assert.Equal(true, r.IsErr())
+
+	assert.Equal(false, iter.HasNext())
+	_, ok = iter.Next()
+	assert.Equal(false, ok)
+
+	// the source is not consumed after the failure.
+	item, _ := source.Next()
+	assert.Equal("3", item)
+}
+
+func TestCollectResults(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCollectResults")
+
+	errFoo := errors.New("foo")
+	source := FromSlice([]result.Result[int]{result.Ok(1), result.Err[int](errFoo), result.Ok(3)})
+
+	r := CollectResults[int](source)
+	assert.Equal(errFoo, r.Error())
+	assert.Equal(true, source.HasNext())
+}
+
+func TestPartitionEither(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPartitionEither")
+
+	lefts, rights := PartitionEither[string, int](FromSlice([]either.Either[string, int]{
+		either.Right[string](1),
+		either.Left[string, int]("a"),
+	}))
+	assert.Equal([]string{"a"}, lefts)
+	assert.Equal([]int{1}, rights)
+}
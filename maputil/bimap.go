@@ -0,0 +1,174 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+// Package maputil includes some functions to manipulate map.
+package maputil
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// BiMap is a bidirectional map, both keys and values are unique,
+// so a key can be looked up by its value as fast as a value by its key.
+type BiMap[K comparable, V comparable] struct {
+	forward map[K]V
+	inverse map[V]K
+}
+
+// NewBiMap creates a new BiMap.
+func NewBiMap[K comparable, V comparable]() *BiMap[K, V] {
+	return &BiMap[K, V]{
+		forward: make(map[K]V),
+		inverse: make(map[V]K),
+	}
+}
+
+// NewBiMapFromMap creates a new BiMap from map, it returns error if values of map are not unique.
+func NewBiMapFromMap[K comparable, V comparable](m map[K]V) (*BiMap[K, V], error) {
+	bm := NewBiMap[K, V]()
+	for key, value := range m {
+		if err := bm.Put(key, value); err != nil {
+			return nil, err
+		}
+	}
+	return bm, nil
+}
+
+// Put sets the given key-value pair, if key already exists, its old value is replaced.
+// it returns error if value is already bound to another key.
+func (bm *BiMap[K, V]) Put(key K, value V) error {
+	if existingKey, ok := bm.inverse[value]; ok && existingKey != key {
+		return fmt.Errorf("value %v is already bound to key %v", value, existingKey)
+	}
+
+	bm.ForcePut(key, value)
+	return nil
+}
+
+// ForcePut sets the given key-value pair, any existing pair with the same key or value is removed.
+func (bm *BiMap[K, V]) ForcePut(key K, value V) {
+	if oldValue, ok := bm.forward[key]; ok {
+		delete(bm.inverse, oldValue)
+	}
+	if oldKey, ok := bm.inverse[value]; ok {
+		delete(bm.forward, oldKey)
+	}
+
+	bm.forward[key] = value
+	bm.inverse[value] = key
+}
+
+// Get returns the value of key.
+func (bm *BiMap[K, V]) Get(key K) (V, bool) {
+	value, ok := bm.forward[key]
+	return value, ok
+}
+
+// GetKey returns the key of value.
+func (bm *BiMap[K, V]) GetKey(value V) (K, bool) {
+	key, ok := bm.inverse[value]
+	return key, ok
+}
+
+// ContainsKey checks if key exists.
+func (bm *BiMap[K, V]) ContainsKey(key K) bool {
+	_, ok := bm.forward[key]
+	return ok
+}
+
+// ContainsValue checks if value exists.
+func (bm *BiMap[K, V]) ContainsValue(value V) bool {
+	_, ok := bm.inverse[value]
+	return ok
+}
+
+// Delete removes key and its value, return true if key existed.
+func (bm *BiMap[K, V]) Delete(key K) bool {
+	value, ok := bm.forward[key]
+	if !ok {
+		return false
+	}
+
+	delete(bm.forward, key)
+	delete(bm.inverse, value)
+	return true
+}
+
+// DeleteValue removes value and its key, return true if value existed.
+func (bm *BiMap[K, V]) DeleteValue(value V) bool {
+	key, ok := bm.inverse[value]
+	if !ok {
+		return false
+	}
+
+	delete(bm.inverse, value)
+	delete(bm.forward, key)
+	return true
+}
+
+// Inverse returns the inverse view of the BiMap, which maps values to keys.
+// the view shares data with the BiMap, changes to either one are visible in the other.
+func (bm *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	return &BiMap[V, K]{forward: bm.inverse, inverse: bm.forward}
+}
+
+// Keys returns all keys (random order).
+func (bm *BiMap[K, V]) Keys() []K {
+	return Keys(bm.forward)
+}
+
+// Values returns all values (random order).
+func (bm *BiMap[K, V]) Values() []V {
+	return Values(bm.forward)
+}
+
+// Entries returns all key/value pairs.
+func (bm *BiMap[K, V]) Entries() []Entry[K, V] {
+	return Entries(bm.forward)
+}
+
+// Len returns the number of key/value pairs.
+func (bm *BiMap[K, V]) Len() int {
+	return len(bm.forward)
+}
+
+// Clear removes all key/value pairs.
+func (bm *BiMap[K, V]) Clear() {
+	for key := range bm.forward {
+		delete(bm.forward, key)
+	}
+	for value := range bm.inverse {
+		delete(bm.inverse, value)
+	}
+}
+
+// ToMap returns a copy of key to value map.
+func (bm *BiMap[K, V]) ToMap() map[K]V {
+	result := make(map[K]V, len(bm.forward))
+	for key, value := range bm.forward {
+		result[key] = value
+	}
+	return result
+}
+
+// MarshalJSON implements the json.Marshaler interface, it's encoded as an object of key to value.
+func (bm *BiMap[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bm.forward)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, it returns error if values are not unique.
+func (bm *BiMap[K, V]) UnmarshalJSON(data []byte) error {
+	temp := make(map[K]V)
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	result, err := NewBiMapFromMap(temp)
+	if err != nil {
+		return err
+	}
+
+	*bm = *result
+	return nil
+}
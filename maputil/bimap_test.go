@@ -0,0 +1,131 @@
+package maputil
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestBiMap_Put_Get(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestBiMap_Put_Get")
+
+	bm := NewBiMap[string, int]()
+	assert.IsNil(bm.Put("a", 1))
+	assert.IsNil(bm.Put("b", 2))
+
+	value, ok := bm.Get("a")
+	assert.Equal(1, value)
+	assert.Equal(true, ok)
+
+	key, ok := bm.GetKey(2)
+	assert.Equal("b", key)
+	assert.Equal(true, ok)
+
+	_, ok = bm.GetKey(3)
+	assert.Equal(false, ok)
+
+	assert.IsNotNil(bm.Put("c", 1))
+	assert.Equal(false, bm.ContainsKey("c"))
+
+	assert.IsNil(bm.Put("a", 3))
+	assert.Equal(false, bm.ContainsValue(1))
+	assert.Equal(true, bm.ContainsValue(3))
+	assert.Equal(2, bm.Len())
+
+	bm.ForcePut("c", 3)
+	assert.Equal(false, bm.ContainsKey("a"))
+	key, _ = bm.GetKey(3)
+	assert.Equal("c", key)
+	assert.Equal(2, bm.Len())
+}
+
+func TestBiMap_Delete(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestBiMap_Delete")
+
+	bm := NewBiMap[string, int]()
+	bm.ForcePut("a", 1)
+	bm.ForcePut("b", 2)
+
+	assert.Equal(true, bm.Delete("a"))
+	assert.Equal(false, bm.Delete("a"))
+	assert.Equal(false, bm.ContainsValue(1))
+
+	assert.Equal(true, bm.DeleteValue(2))
+	assert.Equal(false, bm.DeleteValue(2))
+	assert.Equal(false, bm.ContainsKey("b"))
+	assert.Equal(0, bm.Len())
+}
+
+func TestBiMap_Inverse(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestBiMap_Inverse")
+
+	bm := NewBiMap[string, int]()
+	bm.ForcePut("a", 1)
+
+	inverse := bm.Inverse()
+	key, ok := inverse.Get(1)
+	assert.Equal("a", key)
+	assert.Equal(true, ok)
+
+	inverse.ForcePut(2, "b")
+	value, ok := bm.Get("b")
+	assert.Equal(2, value)
+	assert.Equal(true, ok)
+
+	bm.Delete("a")
+	assert.Equal(false, inverse.ContainsKey(1))
+	assert.Equal(1, inverse.Len())
+}
+
+func TestBiMap_FromMap(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestBiMap_FromMap")
+
+	bm, err := NewBiMapFromMap(map[string]int{"a": 1, "b": 2})
+	assert.IsNil(err)
+
+	keys := bm.Keys()
+	sort.Strings(keys)
+	assert.Equal([]string{"a", "b"}, keys)
+
+	values := bm.Values()
+	sort.Ints(values)
+	assert.Equal([]int{1, 2}, values)
+
+	assert.Equal(map[string]int{"a": 1, "b": 2}, FromEntries(bm.Entries()))
+	assert.Equal(map[string]int{"a": 1, "b": 2}, bm.ToMap())
+
+	_, err = NewBiMapFromMap(map[string]int{"a": 1, "b": 1})
+	assert.IsNotNil(err)
+
+	bm.Clear()
+	assert.Equal(0, bm.Len())
+	assert.Equal(false, bm.ContainsValue(1))
+}
+
+func TestBiMap_JSON(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestBiMap_JSON")
+
+	bm := NewBiMap[string, int]()
+	bm.ForcePut("a", 1)
+	bm.ForcePut("b", 2)
+
+	data, err := json.Marshal(bm)
+	assert.IsNil(err)
+	assert.Equal(`{"a":1,"b":2}`, string(data))
+
+	other := NewBiMap[string, int]()
+	err = json.Unmarshal(data, other)
+	assert.IsNil(err)
+	key, _ := other.GetKey(2)
+	assert.Equal("b", key)
+
+	err = json.Unmarshal([]byte(`{"a":1,"b":1}`), other)
+	assert.IsNotNil(err)
+}
@@ -0,0 +1,363 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+// Package maputil includes some functions to manipulate map.
+package maputil
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// MultiMap is a map which associates a key with multiple values.
+type MultiMap[K comparable, V any] interface {
+	// Put add value to the values of key.
+	Put(key K, value V)
+	// PutAll add values to the values of key.
+	PutAll(key K, values ...V)
+	// PutEntries add every key/value pair of entries.
+	PutEntries(entries []Entry[K, V])
+	// Get return the values of key, return empty slice if key is not found.
+	Get(key K) []V
+	// Delete remove one pair of key and value, return true if the pair existed.
+	Delete(key K, value V) bool
+	// DeleteAll remove key and all of its values, return the removed values.
+	DeleteAll(key K) []V
+	// ContainsKey checks if there is at least one value of key.
+	ContainsKey(key K) bool
+	// ContainsEntry checks if the pair of key and value exists.
+	ContainsEntry(key K, value V) bool
+	// Keys return all distinct keys.
+	Keys() []K
+	// Values return all values of all keys.
+	Values() []V
+	// Entries return all key/value pairs.
+	Entries() []Entry[K, V]
+	// Size return the number of key/value pairs.
+	Size() int
+	// KeySize return the number of distinct keys.
+	KeySize() int
+	// IsEmpty checks if there is no key/value pair.
+	IsEmpty() bool
+	// Clear remove all key/value pairs.
+	Clear()
+	// ToMap return a map whose value is the values of key.
+	ToMap() map[K][]V
+}
+
+// ListMultiMap is a MultiMap which keeps values of a key in insertion order, duplicate values are allowed.
+type ListMultiMap[K comparable, V any] struct {
+	data map[K][]V
+	size int
+}
+
+// NewListMultiMap creates a new ListMultiMap.
+func NewListMultiMap[K comparable, V any]() *ListMultiMap[K, V] {
+	return &ListMultiMap[K, V]{data: make(map[K][]V)}
+}
+
+// Put add value to the values of key.
+func (m *ListMultiMap[K, V]) Put(key K, value V) {
+	m.data[key] = append(m.data[key], value)
+	m.size++
+}
+
+// PutAll add values to the values of key.
+func (m *ListMultiMap[K, V]) PutAll(key K, values ...V) {
+	if len(values) == 0 {
+		return
+	}
+	m.data[key] = append(m.data[key], values...)
+	m.size += len(values)
+}
+
+// PutEntries add every key/value pair of entries.
+func (m *ListMultiMap[K, V]) PutEntries(entries []Entry[K, V]) {
+	for _, entry := range entries {
+		m.Put(entry.Key, entry.Value)
+	}
+}
+
+// Get return a copy of values of key, return empty slice if key is not found.
+func (m *ListMultiMap[K, V]) Get(key K) []V {
+	values := m.data[key]
+	result := make([]V, len(values))
+	copy(result, values)
+	return result
+}
+
+// Delete remove the first pair of key and value, return true if the pair existed.
+func (m *ListMultiMap[K, V]) Delete(key K, value V) bool {
+	values := m.data[key]
+	for i, v := range values {
+		if reflect.DeepEqual(v, value) {
+			values = append(values[:i], values[i+1:]...)
+			if len(values) == 0 {
+				delete(m.data, key)
+			} else {
+				m.data[key] = values
+			}
+			m.size--
+			return true
+		}
+	}
+	return false
+}
+
+// DeleteAll remove key and all of its values, return the removed values.
+func (m *ListMultiMap[K, V]) DeleteAll(key K) []V {
+	values, ok := m.data[key]
+	if !ok {
+		return []V{}
+	}
+	delete(m.data, key)
+	m.size -= len(values)
+	return values
+}
+
+// ContainsKey checks if there is at least one value of key.
+func (m *ListMultiMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.data[key]
+	return ok
+}
+
+// ContainsEntry checks if the pair of key and value exists.
+func (m *ListMultiMap[K, V]) ContainsEntry(key K, value V) bool {
+	for _, v := range m.data[key] {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Keys return all distinct keys (random order).
+func (m *ListMultiMap[K, V]) Keys() []K {
+	return Keys(m.data)
+}
+
+// Values return all values of all keys.
+func (m *ListMultiMap[K, V]) Values() []V {
+	result := make([]V, 0, m.size)
+	for _, values := range m.data {
+		result = append(result, values...)
+	}
+	return result
+}
+
+// Entries return all key/value pairs.
+func (m *ListMultiMap[K, V]) Entries() []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, m.size)
+	for key, values := range m.data {
+		for _, value := range values {
+			entries = append(entries, Entry[K, V]{Key: key, Value: value})
+		}
+	}
+	return entries
+}
+
+// Size return the number of key/value pairs.
+func (m *ListMultiMap[K, V]) Size() int {
+	return m.size
+}
+
+// KeySize return the number of distinct keys.
+func (m *ListMultiMap[K, V]) KeySize() int {
+	return len(m.data)
+}
+
+// IsEmpty checks if there is no key/value pair.
+func (m *ListMultiMap[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Clear remove all key/value pairs.
+func (m *ListMultiMap[K, V]) Clear() {
+	m.data = make(map[K][]V)
+	m.size = 0
+}
+
+// ToMap return a map whose value is a copy of values of key.
+func (m *ListMultiMap[K, V]) ToMap() map[K][]V {
+	result := make(map[K][]V, len(m.data))
+	for key := range m.data {
+		result[key] = m.Get(key)
+	}
+	return result
+}
+
+// MarshalJSON implements the json.Marshaler interface, it's encoded as an object whose value is array.
+func (m *ListMultiMap[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.data)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (m *ListMultiMap[K, V]) UnmarshalJSON(data []byte) error {
+	temp := make(map[K][]V)
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	m.Clear()
+	for key, values := range temp {
+		m.PutAll(key, values...)
+	}
+
+	return nil
+}
+
+// SetMultiMap is a MultiMap whose values of a key are distinct, the order of values is unspecified.
+type SetMultiMap[K comparable, V comparable] struct {
+	data map[K]map[V]struct{}
+	size int
+}
+
+// NewSetMultiMap creates a new SetMultiMap.
+func NewSetMultiMap[K comparable, V comparable]() *SetMultiMap[K, V] {
+	return &SetMultiMap[K, V]{data: make(map[K]map[V]struct{})}
+}
+
+// Put add value to the values of key, duplicate value is ignored.
+func (m *SetMultiMap[K, V]) Put(key K, value V) {
+	values, ok := m.data[key]
+	if !ok {
+		values = make(map[V]struct{})
+		m.data[key] = values
+	}
+	if _, ok := values[value]; !ok {
+		values[value] = struct{}{}
+		m.size++
+	}
+}
+
+// PutAll add values to the values of key, duplicate values are ignored.
+func (m *SetMultiMap[K, V]) PutAll(key K, values ...V) {
+	for _, value := range values {
+		m.Put(key, value)
+	}
+}
+
+// PutEntries add every key/value pair of entries.
+func (m *SetMultiMap[K, V]) PutEntries(entries []Entry[K, V]) {
+	for _, entry := range entries {
+		m.Put(entry.Key, entry.Value)
+	}
+}
+
+// Get return the values of key, return empty slice if key is not found.
+func (m *SetMultiMap[K, V]) Get(key K) []V {
+	return Keys(m.data[key])
+}
+
+// Delete remove the pair of key and value, return true if the pair existed.
+func (m *SetMultiMap[K, V]) Delete(key K, value V) bool {
+	values := m.data[key]
+	if _, ok := values[value]; !ok {
+		return false
+	}
+
+	delete(values, value)
+	if len(values) == 0 {
+		delete(m.data, key)
+	}
+	m.size--
+
+	return true
+}
+
+// DeleteAll remove key and all of its values, return the removed values.
+func (m *SetMultiMap[K, V]) DeleteAll(key K) []V {
+	values := m.Get(key)
+	delete(m.data, key)
+	m.size -= len(values)
+	return values
+}
+
+// ContainsKey checks if there is at least one value of key.
+func (m *SetMultiMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.data[key]
+	return ok
+}
+
+// ContainsEntry checks if the pair of key and value exists.
+func (m *SetMultiMap[K, V]) ContainsEntry(key K, value V) bool {
+	_, ok := m.data[key][value]
+	return ok
+}
+
+// Keys return all distinct keys (random order).
+func (m *SetMultiMap[K, V]) Keys() []K {
+	return Keys(m.data)
+}
+
+// Values return all values of all keys.
+func (m *SetMultiMap[K, V]) Values() []V {
+	result := make([]V, 0, m.size)
+	for _, values := range m.data {
+		for value := range values {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// Entries return all key/value pairs.
+func (m *SetMultiMap[K, V]) Entries() []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, m.size)
+	for key, values := range m.data {
+		for value := range values {
+			entries = append(entries, Entry[K, V]{Key: key, Value: value})
+		}
+	}
+	return entries
+}
+
+// Size return the number of key/value pairs.
+func (m *SetMultiMap[K, V]) Size() int {
+	return m.size
+}
+
+// KeySize return the number of distinct keys.
+func (m *SetMultiMap[K, V]) KeySize() int {
+	return len(m.data)
+}
+
+// IsEmpty checks if there is no key/value pair.
+func (m *SetMultiMap[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Clear remove all key/value pairs.
+func (m *SetMultiMap[K, V]) Clear() {
+	m.data = make(map[K]map[V]struct{})
+	m.size = 0
+}
+
+// ToMap return a map whose value is the values of key.
+func (m *SetMultiMap[K, V]) ToMap() map[K][]V {
+	result := make(map[K][]V, len(m.data))
+	for key := range m.data {
+		result[key] = m.Get(key)
+	}
+	return result
+}
+
+// MarshalJSON implements the json.Marshaler interface, it's encoded as an object whose value is array.
+func (m *SetMultiMap[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.ToMap())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (m *SetMultiMap[K, V]) UnmarshalJSON(data []byte) error {
+	temp := make(map[K][]V)
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	m.Clear()
+	for key, values := range temp {
+		m.PutAll(key, values...)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package maputil
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+var (
+	_ MultiMap[string, int] = (*ListMultiMap[string, int])(nil)
+	_ MultiMap[string, int] = (*SetMultiMap[string, int])(nil)
+)
+
+func TestListMultiMap_Put_Get(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestListMultiMap_Put_Get")
+
+	m := NewListMultiMap[string, int]()
+	m.Put("a", 1)
+	m.Put("a", 1)
+	m.PutAll("a", 2, 3)
+	m.Put("b", 4)
+
+	assert.Equal([]int{1, 1, 2, 3}, m.Get("a"))
+	assert.Equal([]int{4}, m.Get("b"))
+	assert.Equal([]int{}, m.Get("c"))
+	assert.Equal(5, m.Size())
+	assert.Equal(2, m.KeySize())
+	assert.Equal(true, m.ContainsKey("a"))
+	assert.Equal(true, m.ContainsEntry("a", 2))
+	assert.Equal(false, m.ContainsEntry("b", 2))
+
+	values := m.Get("a")
+	values[0] = 100
+	assert.Equal([]int{1, 1, 2, 3}, m.Get("a"))
+}
+
+func TestListMultiMap_Delete(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestListMultiMap_Delete")
+
+	m := NewListMultiMap[string, int]()
+	m.PutAll("a", 1, 2, 1)
+	m.Put("b", 3)
+
+	assert.Equal(true, m.Delete("a", 1))
+	assert.Equal([]int{2, 1}, m.Get("a"))
+	assert.Equal(false, m.Delete("a", 5))
+	assert.Equal(3, m.Size())
+
+	assert.Equal([]int{2, 1}, m.DeleteAll("a"))
+	assert.Equal(false, m.ContainsKey("a"))
+	assert.Equal(1, m.Size())
+
+	assert.Equal(true, m.Delete("b", 3))
+	assert.Equal(false, m.ContainsKey("b"))
+	assert.Equal(true, m.IsEmpty())
+}
+
+func TestListMultiMap_Entries(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestListMultiMap_Entries")
+
+	m := NewListMultiMap[string, int]()
+	m.PutEntries(Entries(map[string]int{"a": 1, "b": 2}))
+	m.Put("a", 3)
+
+	entries := m.Entries()
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Key != entries[j].Key {
+			return entries[i].Key < entries[j].Key
+		}
+		return entries[i].Value < entries[j].Value
+	})
+	assert.Equal([]Entry[string, int]{{"a", 1}, {"a", 3}, {"b", 2}}, entries)
+
+	values := m.Values()
+	sort.Ints(values)
+	assert.Equal([]int{1, 2, 3}, values)
+
+	keys := m.Keys()
+	sort.Strings(keys)
+	assert.Equal([]string{"a", "b"}, keys)
+
+	assert.Equal(map[string][]int{"a": {1, 3}, "b": {2}}, m.ToMap())
+
+	m.Clear()
+	assert.Equal(0, m.Size())
+	assert.Equal(0, m.KeySize())
+}
+
+func TestListMultiMap_JSON(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestListMultiMap_JSON")
+
+	m := NewListMultiMap[string, int]()
+	m.PutAll("a", 1, 2)
+	m.Put("b", 3)
+
+	data, err := json.Marshal(m)
+	assert.IsNil(err)
+	assert.Equal(`{"a":[1,2],"b":[3]}`, string(data))
+
+	other := NewListMultiMap[string, int]()
+	err = json.Unmarshal(data, other)
+	assert.IsNil(err)
+	assert.Equal(3, other.Size())
+	assert.Equal([]int{1, 2}, other.Get("a"))
+}
+
+func TestSetMultiMap(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestSetMultiMap")
+
+	m := NewSetMultiMap[string, int]()
+	m.Put("a", 1)
+	m.Put("a", 1)
+	m.PutAll("a", 2, 3)
+	m.Put("b", 4)
+
+	values := m.Get("a")
+	sort.Ints(values)
+	assert.Equal([]int{1, 2, 3}, values)
+	assert.Equal(4, m.Size())
+	assert.Equal(2, m.KeySize())
+	assert.Equal(true, m.ContainsEntry("a", 3))
+
+	assert.Equal(true, m.Delete("a", 1))
+	assert.Equal(false, m.Delete("a", 1))
+	assert.Equal(3, m.Size())
+
+	values = m.DeleteAll("a")
+	sort.Ints(values)
+	assert.Equal([]int{2, 3}, values)
+	assert.Equal(1, m.Size())
+
+	assert.Equal(true, m.Delete("b", 4))
+	assert.Equal(false, m.ContainsKey("b"))
+	assert.Equal(true, m.IsEmpty())
+}
+
+func TestSetMultiMap_JSON(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestSetMultiMap_JSON")
+
+	m := NewSetMultiMap[string, int]()
+	m.Put("a", 1)
+
+	data, err := json.Marshal(m)
+	assert.IsNil(err)
+	assert.Equal(`{"a":[1]}`, string(data))
+
+	other := NewSetMultiMap[string, int]()
+	err = json.Unmarshal([]byte(`{"a":[1,1,2],"b":[3]}`), other)
+	assert.IsNil(err)
+	assert.Equal(3, other.Size())
+	assert.Equal(true, other.ContainsEntry("a", 2))
+}
@@ -0,0 +1,349 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+// Package maputil includes some functions to manipulate map.
+package maputil
+
+import (
+	"encoding/json"
+)
+
+// Cell is a value of Table with its row key and column key.
+type Cell[R comparable, C comparable, V any] struct {
+	Row    R
+	Column C
+	Value  V
+}
+
+// Table is a two-key map, a value is identified by a row key and a column key.
+// it's indexed by both row and column, so row and column lookups are fast.
+type Table[R comparable, C comparable, V any] struct {
+	rows    map[R]map[C]V
+	columns map[C]map[R]V
+	size    int
+}
+
+// NewTable creates a new Table.
+func NewTable[R comparable, C comparable, V any]() *Table[R, C, V] {
+	return &Table[R, C, V]{
+		rows:    make(map[R]map[C]V),
+		columns: make(map[C]map[R]V),
+	}
+}
+
+// Put sets value of the cell at row and column.
+func (t *Table[R, C, V]) Put(row R, column C, value V) {
+	rowMap, ok := t.rows[row]
+	if !ok {
+		rowMap = make(map[C]V)
+		t.rows[row] = rowMap
+	}
+	if _, ok := rowMap[column]; !ok {
+		t.size++
+	}
+	rowMap[column] = value
+
+	columnMap, ok := t.columns[column]
+	if !ok {
+		columnMap = make(map[R]V)
+		t.columns[column] = columnMap
+	}
+	columnMap[row] = value
+}
+
+// Get returns the value of cell at row and column.
+func (t *Table[R, C, V]) Get(row R, column C) (V, bool) {
+	value, ok := t.rows[row][column]
+	return value, ok
+}
+
+// Contains checks if the cell at row and column exists.
+func (t *Table[R, C, V]) Contains(row R, column C) bool {
+	_, ok := t.rows[row][column]
+	return ok
+}
+
+// ContainsRow checks if there is any cell in the row.
+func (t *Table[R, C, V]) ContainsRow(row R) bool {
+	_, ok := t.rows[row]
+	return ok
+}
+
+// ContainsColumn checks if there is any cell in the column.
+func (t *Table[R, C, V]) ContainsColumn(column C) bool {
+	_, ok := t.columns[column]
+	return ok
+}
+
+// Delete removes the cell at row and column, return true if the cell existed.
+func (t *Table[R, C, V]) Delete(row R, column C) bool {
+	if !t.Contains(row, column) {
+		return false
+	}
+
+	delete(t.rows[row], column)
+	if len(t.rows[row]) == 0 {
+		delete(t.rows, row)
+	}
+	delete(t.columns[column], row)
+	if len(t.columns[column]) == 0 {
+		delete(t.columns, column)
+	}
+	t.size--
+
+	return true
+}
+
+// DeleteRow removes all cells of the row, return the number of removed cells.
+func (t *Table[R, C, V]) DeleteRow(row R) int {
+	count := 0
+	for column := range t.rows[row] {
+		if t.Delete(row, column) {
+			count++
+		}
+	}
+	return count
+}
+
+// DeleteColumn removes all cells of the column, return the number of removed cells.
+func (t *Table[R, C, V]) DeleteColumn(column C) int {
+	count := 0
+	for row := range t.columns[column] {
+		if t.Delete(row, column) {
+			count++
+		}
+	}
+	return count
+}
+
+// Row returns the live view of the row, which maps column key to value.
+// the changes of table are visible in the view, and the changes made by the view are written to the table.
+func (t *Table[R, C, V]) Row(row R) *RowView[R, C, V] {
+	return &RowView[R, C, V]{table: t, row: row}
+}
+
+// Column returns the live view of the column, which maps row key to value.
+// the changes of table are visible in the view, and the changes made by the view are written to the table.
+func (t *Table[R, C, V]) Column(column C) *ColumnView[R, C, V] {
+	return &ColumnView[R, C, V]{table: t, column: column}
+}
+
+// RowKeys returns all row keys (random order).
+func (t *Table[R, C, V]) RowKeys() []R {
+	return Keys(t.rows)
+}
+
+// ColumnKeys returns all column keys (random order).
+func (t *Table[R, C, V]) ColumnKeys() []C {
+	return Keys(t.columns)
+}
+
+// Values returns all values of cells (random order).
+func (t *Table[R, C, V]) Values() []V {
+	values := make([]V, 0, t.size)
+	for _, rowMap := range t.rows {
+		for _, value := range rowMap {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// Cells returns all cells (random order).
+func (t *Table[R, C, V]) Cells() []Cell[R, C, V] {
+	cells := make([]Cell[R, C, V], 0, t.size)
+	for row, rowMap := range t.rows {
+		for column, value := range rowMap {
+			cells = append(cells, Cell[R, C, V]{Row: row, Column: column, Value: value})
+		}
+	}
+	return cells
+}
+
+// Size returns the number of cells.
+func (t *Table[R, C, V]) Size() int {
+	return t.size
+}
+
+// IsEmpty checks if there is no cell.
+func (t *Table[R, C, V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Clear removes all cells.
+func (t *Table[R, C, V]) Clear() {
+	t.rows = make(map[R]map[C]V)
+	t.columns = make(map[C]map[R]V)
+	t.size = 0
+}
+
+// Transpose returns a new table whose rows are columns of the table and columns are rows.
+func (t *Table[R, C, V]) Transpose() *Table[C, R, V] {
+	result := NewTable[C, R, V]()
+	for row, rowMap := range t.rows {
+		for column, value := range rowMap {
+			result.Put(column, row, value)
+		}
+	}
+	return result
+}
+
+// RowMap returns a copy of table as nested map, which maps row key to column key to value.
+func (t *Table[R, C, V]) RowMap() map[R]map[C]V {
+	result := make(map[R]map[C]V, len(t.rows))
+	for row, rowMap := range t.rows {
+		result[row] = copyMap(rowMap)
+	}
+	return result
+}
+
+// ColumnMap returns a copy of table as nested map, which maps column key to row key to value.
+func (t *Table[R, C, V]) ColumnMap() map[C]map[R]V {
+	result := make(map[C]map[R]V, len(t.columns))
+	for column, columnMap := range t.columns {
+		result[column] = copyMap(columnMap)
+	}
+	return result
+}
+
+// MarshalJSON implements the json.Marshaler interface, it's encoded as nested object of row to column to value.
+func (t *Table[R, C, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.rows)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *Table[R, C, V]) UnmarshalJSON(data []byte) error {
+	temp := make(map[R]map[C]V)
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	t.Clear()
+	for row, rowMap := range temp {
+		for column, value := range rowMap {
+			t.Put(row, column, value)
+		}
+	}
+
+	return nil
+}
+
+// RowView is the live view of a row of Table, it's returned by Table.Row.
+type RowView[R comparable, C comparable, V any] struct {
+	table *Table[R, C, V]
+	row   R
+}
+
+// Get returns the value of the column in row.
+func (rv *RowView[R, C, V]) Get(column C) (V, bool) {
+	return rv.table.Get(rv.row, column)
+}
+
+// Put sets the value of the column in row.
+func (rv *RowView[R, C, V]) Put(column C, value V) {
+	rv.table.Put(rv.row, column, value)
+}
+
+// Delete removes the column from row, return true if the cell existed.
+func (rv *RowView[R, C, V]) Delete(column C) bool {
+	return rv.table.Delete(rv.row, column)
+}
+
+// Contains checks if the column exists in row.
+func (rv *RowView[R, C, V]) Contains(column C) bool {
+	return rv.table.Contains(rv.row, column)
+}
+
+// Keys returns the column keys of row (random order).
+func (rv *RowView[R, C, V]) Keys() []C {
+	return Keys(rv.table.rows[rv.row])
+}
+
+// Values returns the values of row (random order).
+func (rv *RowView[R, C, V]) Values() []V {
+	return Values(rv.table.rows[rv.row])
+}
+
+// Len returns the number of cells in row.
+func (rv *RowView[R, C, V]) Len() int {
+	return len(rv.table.rows[rv.row])
+}
+
+// Range calls iteratee for each column and value of row until iteratee returns false.
+// the row shouldn't be changed by iteratee.
+func (rv *RowView[R, C, V]) Range(iteratee func(column C, value V) bool) {
+	for column, value := range rv.table.rows[rv.row] {
+		if !iteratee(column, value) {
+			return
+		}
+	}
+}
+
+// ToMap returns a copy of row, which maps column key to value.
+func (rv *RowView[R, C, V]) ToMap() map[C]V {
+	return copyMap(rv.table.rows[rv.row])
+}
+
+// ColumnView is the live view of a column of Table, it's returned by Table.Column.
+type ColumnView[R comparable, C comparable, V any] struct {
+	table  *Table[R, C, V]
+	column C
+}
+
+// Get returns the value of the row in column.
+func (cv *ColumnView[R, C, V]) Get(row R) (V, bool) {
+	return cv.table.Get(row, cv.column)
+}
+
+// Put sets the value of the row in column.
+func (cv *ColumnView[R, C, V]) Put(row R, value V) {
+	cv.table.Put(row, cv.column, value)
+}
+
+// Delete removes the row from column, return true if the cell existed.
+func (cv *ColumnView[R, C, V]) Delete(row R) bool {
+	return cv.table.Delete(row, cv.column)
+}
+
+// Contains checks if the row exists in column.
+func (cv *ColumnView[R, C, V]) Contains(row R) bool {
+	return cv.table.Contains(row, cv.column)
+}
+
+// Keys returns the row keys of column (random order).
+func (cv *ColumnView[R, C, V]) Keys() []R {
+	return Keys(cv.table.columns[cv.column])
+}
+
+// Values returns the values of column (random order).
+func (cv *ColumnView[R, C, V]) Values() []V {
+	return Values(cv.table.columns[cv.column])
+}
+
+// Len returns the number of cells in column.
+func (cv *ColumnView[R, C, V]) Len() int {
+	return len(cv.table.columns[cv.column])
+}
+
+// Range calls iteratee for each row and value of column until iteratee returns false.
+// the column shouldn't be changed by iteratee.
+func (cv *ColumnView[R, C, V]) Range(iteratee func(row R, value V) bool) {
+	for row, value := range cv.table.columns[cv.column] {
+		if !iteratee(row, value) {
+			return
+		}
+	}
+}
+
+// ToMap returns a copy of column, which maps row key to value.
+func (cv *ColumnView[R, C, V]) ToMap() map[R]V {
+	return copyMap(cv.table.columns[cv.column])
+}
+
+func copyMap[K comparable, V any](m map[K]V) map[K]V {
+	result := make(map[K]V, len(m))
+	for key, value := range m {
+		result[key] = value
+	}
+	return result
+}
@@ -0,0 +1,193 @@
+package maputil
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestTable_Put_Get(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestTable_Put_Get")
+
+	table := NewTable[string, string, int]()
+	table.Put("r1", "c1", 1)
+	table.Put("r1", "c2", 2)
+	table.Put("r2", "c1", 3)
+	table.Put("r2", "c1", 4)
+
+	value, ok := table.Get("r2", "c1")
+	assert.Equal(4, value)
+	assert.Equal(true, ok)
+
+	_, ok = table.Get("r2", "c2")
+	assert.Equal(false, ok)
+
+	assert.Equal(3, table.Size())
+	assert.Equal(true, table.Contains("r1", "c2"))
+	assert.Equal(true, table.ContainsRow("r2"))
+	assert.Equal(true, table.ContainsColumn("c2"))
+	assert.Equal(false, table.ContainsColumn("c3"))
+
+	assert.Equal(map[string]int{"c1": 1, "c2": 2}, table.Row("r1").ToMap())
+	assert.Equal(map[string]int{"r1": 1, "r2": 4}, table.Column("c1").ToMap())
+	assert.Equal(map[string]int{}, table.Row("r3").ToMap())
+
+	rowKeys := table.RowKeys()
+	sort.Strings(rowKeys)
+	assert.Equal([]string{"r1", "r2"}, rowKeys)
+
+	columnKeys := table.ColumnKeys()
+	sort.Strings(columnKeys)
+	assert.Equal([]string{"c1", "c2"}, columnKeys)
+
+	values := table.Values()
+	sort.Ints(values)
+	assert.Equal([]int{1, 2, 4}, values)
+}
+
+func TestTable_Delete(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestTable_Delete")
+
+	table := NewTable[string, string, int]()
+	table.Put("r1", "c1", 1)
+	table.Put("r1", "c2", 2)
+	table.Put("r2", "c1", 3)
+
+	assert.Equal(true, table.Delete("r1", "c2"))
+	assert.Equal(false, table.Delete("r1", "c2"))
+	assert.Equal(false, table.ContainsColumn("c2"))
+	assert.Equal(2, table.Size())
+
+	assert.Equal(2, table.DeleteColumn("c1"))
+	assert.Equal(false, table.ContainsRow("r1"))
+	assert.Equal(true, table.IsEmpty())
+
+	table.Put("r1", "c1", 1)
+	table.Put("r1", "c2", 2)
+	assert.Equal(2, table.DeleteRow("r1"))
+	assert.Equal(0, table.DeleteRow("r1"))
+	assert.Equal(false, table.ContainsColumn("c1"))
+
+	table.Put("r1", "c1", 1)
+	table.Clear()
+	assert.Equal(0, table.Size())
+	assert.Equal(false, table.ContainsRow("r1"))
+}
+
+func TestTable_Cells_Transpose(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestTable_Cells_Transpose")
+
+	table := NewTable[string, int, bool]()
+	table.Put("a", 1, true)
+	table.Put("a", 2, false)
+	table.Put("b", 1, false)
+
+	cells := table.Cells()
+	sort.Slice(cells, func(i, j int) bool {
+		if cells[i].Row != cells[j].Row {
+			return cells[i].Row < cells[j].Row
+		}
+		return cells[i].Column < cells[j].Column
+	})
+	assert.Equal([]Cell[string, int, bool]{
+		{Row: "a", Column: 1, Value: true},
+		{Row: "a", Column: 2, Value: false},
+		{Row: "b", Column: 1, Value: false},
+	}, cells)
+
+	transposed := table.Transpose()
+	assert.Equal(3, transposed.Size())
+	assert.Equal(map[string]bool{"a": true, "b": false}, transposed.Row(1).ToMap())
+
+	assert.Equal(map[string]map[int]bool{"a": {1: true, 2: false}, "b": {1: false}}, table.RowMap())
+	assert.Equal(map[int]map[string]bool{1: {"a": true, "b": false}, 2: {"a": false}}, table.ColumnMap())
+}
+
+func TestTable_JSON(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestTable_JSON")
+
+	table := NewTable[string, string, int]()
+	table.Put("r1", "c1", 1)
+	table.Put("r2", "c2", 2)
+
+	data, err := json.Marshal(table)
+	assert.IsNil(err)
+	assert.Equal(`{"r1":{"c1":1},"r2":{"c2":2}}`, string(data))
+
+	other := NewTable[string, string, int]()
+	err = json.Unmarshal(data, other)
+	assert.IsNil(err)
+	assert.Equal(2, other.Size())
+	assert.Equal(map[string]int{"r2": 2}, other.Column("c2").ToMap())
+}
+
+func TestTable_Views(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestTable_Views")
+
+	table := NewTable[string, string, int]()
+	row := table.Row("r1")
+	column := table.Column("c1")
+	assert.Equal(0, row.Len())
+
+	// the changes of table are visible in views.
+	table.Put("r1", "c1", 1)
+	table.Put("r1", "c2", 2)
+	table.Put("r2", "c1", 3)
+	assert.Equal(2, row.Len())
+	assert.Equal(2, column.Len())
+
+	value, ok := row.Get("c2")
+	assert.Equal(true, ok)
+	assert.Equal(2, value)
+	assert.Equal(true, column.Contains("r2"))
+
+	keys := row.Keys()
+	sort.Strings(keys)
+	assert.Equal([]string{"c1", "c2"}, keys)
+
+	values := column.Values()
+	sort.Ints(values)
+	assert.Equal([]int{1, 3}, values)
+
+	// the changes made by views are written to table.
+	row.Put("c3", 4)
+	value, _ = table.Get("r1", "c3")
+	assert.Equal(4, value)
+	assert.Equal(4, table.Size())
+
+	assert.Equal(true, column.Delete("r2"))
+	assert.Equal(false, column.Delete("r2"))
+	assert.Equal(false, table.ContainsRow("r2"))
+
+	column.Put("r3", 5)
+	assert.Equal(map[string]int{"c1": 5}, table.Row("r3").ToMap())
+
+	sum := 0
+	row.Range(func(column string, value int) bool {
+		sum += value
+		return true
+	})
+	assert.Equal(7, sum)
+
+	count := 0
+	column.Range(func(row string, value int) bool {
+		count++
+		return false
+	})
+	assert.Equal(1, count)
+
+	assert.Equal(true, row.Delete("c1"))
+	assert.Equal(false, row.Contains("c1"))
+	assert.Equal(map[string]int{"r3": 5}, column.ToMap())
+
+	table.Clear()
+	assert.Equal(0, row.Len())
+	assert.Equal(0, column.Len())
+}
@@ -0,0 +1,260 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license.
+
+package netutil
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned when the circuit breaker of request host is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed means requests are allowed.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen means requests are rejected with ErrCircuitOpen.
+	CircuitOpen
+	// CircuitHalfOpen means a limited number of trial requests are allowed.
+	CircuitHalfOpen
+)
+
+// String returns the name of state.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("CircuitState(%d)", int(s))
+}
+
+// CircuitBreakerConfig is the config of CircuitBreaker.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures to open the circuit, default is 5.
+	FailureThreshold int
+	// OpenTimeout is the duration of open state before trial requests are allowed, default is 30s.
+	OpenTimeout time.Duration
+	// HalfOpenMaxRequests is the number of successful trial requests to close the circuit, default is 1.
+	HalfOpenMaxRequests int
+	// IsFailure checks if the request is failed, default is error or response status >= 500.
+	IsFailure func(resp *http.Response, err error) bool
+	// OnStateChange is called when the state of a host is changed, it's called without holding the lock of
+	// circuit breaker, so it may call the methods of circuit breaker.
+	OnStateChange func(host string, from, to CircuitState)
+}
+
+// CircuitBreaker rejects requests to a host after consecutive failures, each host has its own state.
+type CircuitBreaker struct {
+	config CircuitBreakerConfig
+	mu     sync.Mutex
+	hosts  map[string]*hostCircuit
+	now    func() time.Time
+	// changes is the state changes made under lock, they are notified by unlock.
+	changes []stateChange
+}
+
+type hostCircuit struct {
+	state     CircuitState
+	failures  int
+	successes int
+	inFlight  int
+	openedAt  time.Time
+	// generation is increased on every state change, the results of requests admitted
+	// in an older generation are ignored.
+	generation uint64
+}
+
+type stateChange struct {
+	host     string
+	from, to CircuitState
+}
+
+// NewCircuitBreaker returns a CircuitBreaker with config.
+func NewCircuitBreaker(config CircuitBreakerConfig) *CircuitBreaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 5
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = 30 * time.Second
+	}
+	if config.HalfOpenMaxRequests <= 0 {
+		config.HalfOpenMaxRequests = 1
+	}
+	if config.IsFailure == nil {
+		config.IsFailure = func(resp *http.Response, err error) bool {
+			return err != nil || resp.StatusCode >= http.StatusInternalServerError
+		}
+	}
+
+	return &CircuitBreaker{
+		config: config,
+		hosts:  make(map[string]*hostCircuit),
+		now:    time.Now,
+	}
+}
+
+// Middleware returns the middleware which applies circuit breaker to requests.
+func (cb *CircuitBreaker) Middleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			host := req.URL.Host
+			generation, err := cb.allow(host)
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := next.RoundTrip(req)
+			// the request canceled by caller says nothing about the health of host.
+			if req.Context().Err() != nil {
+				cb.release(host, generation)
+				return resp, err
+			}
+			cb.record(host, generation, !cb.config.IsFailure(resp, err))
+
+			return resp, err
+		})
+	}
+}
+
+// State returns the circuit state of host.
+func (cb *CircuitBreaker) State(host string) CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	h, ok := cb.hosts[host]
+	if !ok {
+		return CircuitClosed
+	}
+	if h.state == CircuitOpen && !cb.now().Before(h.openedAt.Add(cb.config.OpenTimeout)) {
+		return CircuitHalfOpen
+	}
+	return h.state
+}
+
+// Reset closes the circuit of all hosts.
+func (cb *CircuitBreaker) Reset() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	// the requests admitted before reset are ignored by the new generation.
+	for host, h := range cb.hosts {
+		cb.hosts[host] = &hostCircuit{generation: h.generation + 1}
+	}
+}
+
+// allow checks if the request to host is allowed, and returns the generation of host state which admits it.
+func (cb *CircuitBreaker) allow(host string) (uint64, error) {
+	cb.mu.Lock()
+	defer cb.unlock()
+
+	h := cb.host(host)
+	switch h.state {
+	case CircuitOpen:
+		if cb.now().Before(h.openedAt.Add(cb.config.OpenTimeout)) {
+			return 0, fmt.Errorf("%w: %s", ErrCircuitOpen, host)
+		}
+		cb.setState(host, h, CircuitHalfOpen)
+		fallthrough
+	case CircuitHalfOpen:
+		if h.inFlight+h.successes >= cb.config.HalfOpenMaxRequests {
+			return 0, fmt.Errorf("%w: %s", ErrCircuitOpen, host)
+		}
+		h.inFlight++
+	}
+
+	return h.generation, nil
+}
+
+func (cb *CircuitBreaker) record(host string, generation uint64, success bool) {
+	cb.mu.Lock()
+	defer cb.unlock()
+
+	h := cb.host(host)
+	if h.generation != generation {
+		return
+	}
+
+	switch h.state {
+	case CircuitClosed:
+		if success {
+			h.failures = 0
+			return
+		}
+		h.failures++
+		if h.failures >= cb.config.FailureThreshold {
+			cb.open(host, h)
+		}
+	case CircuitHalfOpen:
+		if h.inFlight > 0 {
+			h.inFlight--
+		}
+		if !success {
+			cb.open(host, h)
+			return
+		}
+		h.successes++
+		if h.successes >= cb.config.HalfOpenMaxRequests {
+			h.failures, h.successes, h.inFlight = 0, 0, 0
+			cb.setState(host, h, CircuitClosed)
+		}
+	}
+}
+
+// release frees the trial request slot of host without recording the result.
+func (cb *CircuitBreaker) release(host string, generation uint64) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	h := cb.host(host)
+	if h.generation == generation && h.state == CircuitHalfOpen && h.inFlight > 0 {
+		h.inFlight--
+	}
+}
+
+func (cb *CircuitBreaker) open(host string, h *hostCircuit) {
+	h.openedAt = cb.now()
+	h.failures, h.successes, h.inFlight = 0, 0, 0
+	cb.setState(host, h, CircuitOpen)
+}
+
+func (cb *CircuitBreaker) setState(host string, h *hostCircuit, state CircuitState) {
+	if h.state == state {
+		return
+	}
+	cb.changes = append(cb.changes, stateChange{host: host, from: h.state, to: state})
+	h.state = state
+	h.generation++
+}
+
+// unlock releases the lock, then notifies the state changes made under lock.
+func (cb *CircuitBreaker) unlock() {
+	changes := cb.changes
+	cb.changes = nil
+	cb.mu.Unlock()
+
+	if cb.config.OnStateChange != nil {
+		for _, c := range changes {
+			cb.config.OnStateChange(c.host, c.from, c.to)
+		}
+	}
+}
+
+func (cb *CircuitBreaker) host(host string) *hostCircuit {
+	h, ok := cb.hosts[host]
+	if !ok {
+		h = &hostCircuit{}
+		cb.hosts[host] = h
+	}
+	return h
+}
@@ -0,0 +1,220 @@
+package netutil
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCircuitBreaker")
+
+	var failing int32 = 1
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&count, 1)
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var changes []string
+	client := NewHttpClientWithConfig(&HttpClientConfig{
+		CircuitBreaker: &CircuitBreakerConfig{
+			FailureThreshold: 2,
+			OpenTimeout:      time.Minute,
+			OnStateChange: func(host string, from, to CircuitState) {
+				changes = append(changes, from.String()+"->"+to.String())
+			},
+		},
+	})
+	cb := client.CircuitBreaker()
+	now := time.Now()
+	cb.now = func() time.Time { return now }
+
+	host := server.Listener.Addr().String()
+	request := &HttpRequest{RawURL: server.URL, Method: "GET"}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.SendRequest(request)
+		assert.IsNil(err)
+		resp.Body.Close()
+	}
+	assert.Equal(CircuitOpen, cb.State(host))
+
+	_, err := client.SendRequest(request)
+	assert.Equal(true, errors.Is(err, ErrCircuitOpen))
+	assert.Equal(int32(2), atomic.LoadInt32(&count))
+
+	// trial request after open timeout fails, the circuit is opened again.
+	now = now.Add(time.Minute)
+	assert.Equal(CircuitHalfOpen, cb.State(host))
+	resp, err := client.SendRequest(request)
+	assert.IsNil(err)
+	resp.Body.Close()
+	assert.Equal(CircuitOpen, cb.State(host))
+
+	// trial request succeeds, the circuit is closed.
+	atomic.StoreInt32(&failing, 0)
+	now = now.Add(time.Minute)
+	resp, err = client.SendRequest(request)
+	assert.IsNil(err)
+	resp.Body.Close()
+	assert.Equal(CircuitClosed, cb.State(host))
+
+	assert.Equal([]string{
+		"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed",
+	}, changes)
+
+	// other hosts are not affected.
+	assert.Equal(CircuitClosed, cb.State("other:80"))
+
+	cb.Reset()
+	assert.Equal(CircuitClosed, cb.State(host))
+	assert.Equal("CircuitState(9)", CircuitState(9).String())
+}
+
+func TestCircuitBreaker_WithRetry(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCircuitBreaker_WithRetry")
+
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&count, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewHttpClientWithConfig(&HttpClientConfig{
+		Retry:          &HttpRetryConfig{MaxAttempts: 5, Backoff: noBackoff},
+		CircuitBreaker: &CircuitBreakerConfig{FailureThreshold: 2},
+	})
+
+	_, err := client.SendRequest(&HttpRequest{RawURL: server.URL, Method: "GET"})
+	assert.Equal(true, errors.Is(err, ErrCircuitOpen))
+	assert.Equal(int32(2), atomic.LoadInt32(&count))
+}
+
+func TestCircuitBreaker_Canceled(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCircuitBreaker_Canceled")
+
+	cb := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute})
+	now := time.Now()
+	cb.now = func() time.Time { return now }
+
+	var failing int32 = 1
+	transport := cb.Middleware()(RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+		if atomic.LoadInt32(&failing) == 1 {
+			return nil, errors.New("connection refused")
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	canceled, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com", nil)
+	request, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+
+	// the requests canceled by caller are not failures.
+	for i := 0; i < 3; i++ {
+		_, err := transport.RoundTrip(canceled)
+		assert.Equal(true, errors.Is(err, context.Canceled))
+	}
+	assert.Equal(CircuitClosed, cb.State("example.com"))
+
+	_, err := transport.RoundTrip(request)
+	assert.IsNotNil(err)
+	assert.Equal(CircuitOpen, cb.State("example.com"))
+
+	// the canceled trial request frees its slot, the next trial request is allowed.
+	now = now.Add(time.Minute)
+	_, err = transport.RoundTrip(canceled)
+	assert.Equal(true, errors.Is(err, context.Canceled))
+	assert.Equal(CircuitHalfOpen, cb.State("example.com"))
+
+	atomic.StoreInt32(&failing, 0)
+	_, err = transport.RoundTrip(request)
+	assert.IsNil(err)
+	assert.Equal(CircuitClosed, cb.State("example.com"))
+}
+
+func TestCircuitBreaker_StaleResult(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCircuitBreaker_StaleResult")
+
+	var states []CircuitState
+	var cb *CircuitBreaker
+	cb = NewCircuitBreaker(CircuitBreakerConfig{
+		FailureThreshold: 1,
+		OpenTimeout:      time.Minute,
+		OnStateChange: func(host string, from, to CircuitState) {
+			// the callback may call the methods of circuit breaker.
+			states = append(states, cb.State(host))
+		},
+	})
+	now := time.Now()
+	cb.now = func() time.Time { return now }
+
+	entered := make(chan struct{})
+	release := map[string]chan struct{}{"/slow": make(chan struct{}), "/trial": make(chan struct{})}
+	transport := cb.Middleware()(RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if ch, ok := release[req.URL.Path]; ok {
+			entered <- struct{}{}
+			<-ch
+		}
+		if req.URL.Path == "/slow" {
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}
+		return nil, errors.New("connection refused")
+	}))
+
+	send := func(path string) <-chan error {
+		result := make(chan error, 1)
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com"+path, nil)
+		go func() {
+			_, err := transport.RoundTrip(req)
+			result <- err
+		}()
+		return result
+	}
+
+	// the slow request is admitted when the circuit is closed.
+	slow := send("/slow")
+	<-entered
+
+	assert.IsNotNil(<-send("/fail"))
+	assert.Equal(CircuitOpen, cb.State("example.com"))
+
+	now = now.Add(time.Minute)
+	trial := send("/trial")
+	<-entered
+
+	// the success of request admitted before the circuit is opened doesn't close it.
+	close(release["/slow"])
+	assert.IsNil(<-slow)
+	assert.Equal(CircuitHalfOpen, cb.State("example.com"))
+
+	close(release["/trial"])
+	assert.IsNotNil(<-trial)
+	assert.Equal(CircuitOpen, cb.State("example.com"))
+
+	assert.Equal([]CircuitState{CircuitOpen, CircuitHalfOpen, CircuitOpen}, states)
+}
@@ -0,0 +1,210 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license.
+
+package netutil
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CookieJar is a http.CookieJar which can be saved to and loaded from a file.
+// It's safe for concurrent use.
+type CookieJar struct {
+	mu      sync.Mutex
+	jar     *cookiejar.Jar
+	path    string
+	entries map[string]*cookieEntry
+	now     func() time.Time
+}
+
+// cookieEntry is a persisted cookie with the url which sets it.
+type cookieEntry struct {
+	URL      string    `json:"url"`
+	Name     string    `json:"name"`
+	Value    string    `json:"value"`
+	Domain   string    `json:"domain,omitempty"`
+	Path     string    `json:"path,omitempty"`
+	Expires  time.Time `json:"expires,omitempty"`
+	Secure   bool      `json:"secure,omitempty"`
+	HttpOnly bool      `json:"http_only,omitempty"`
+	SameSite int       `json:"same_site,omitempty"`
+}
+
+// NewCookieJar returns a CookieJar which is saved to path, the cookies in path are loaded if it exists.
+// If path is empty, the jar is kept in memory only.
+func NewCookieJar(path string) (*CookieJar, error) {
+	jar, _ := cookiejar.New(nil)
+
+	j := &CookieJar{
+		jar:     jar,
+		path:    path,
+		entries: make(map[string]*cookieEntry),
+		now:     time.Now,
+	}
+
+	if path == "" {
+		return j, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*cookieEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		u, err := url.Parse(entry.URL)
+		if err != nil {
+			continue
+		}
+		j.SetCookies(u, []*http.Cookie{entry.cookie()})
+	}
+
+	return j, nil
+}
+
+// SetCookies implements http.CookieJar interface.
+func (j *CookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.jar.SetCookies(u, cookies)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := j.now()
+	for _, c := range cookies {
+		entry := &cookieEntry{
+			URL:      (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}).String(),
+			Name:     c.Name,
+			Value:    c.Value,
+			Domain:   c.Domain,
+			Path:     c.Path,
+			Expires:  c.Expires,
+			Secure:   c.Secure,
+			HttpOnly: c.HttpOnly,
+			SameSite: int(c.SameSite),
+		}
+		if c.MaxAge > 0 {
+			entry.Expires = now.Add(time.Duration(c.MaxAge) * time.Second)
+		}
+
+		key := entry.key(u)
+		if c.MaxAge < 0 || (!entry.Expires.IsZero() && !entry.Expires.After(now)) {
+			delete(j.entries, key)
+			continue
+		}
+		j.entries[key] = entry
+	}
+}
+
+// Cookies implements http.CookieJar interface.
+func (j *CookieJar) Cookies(u *url.URL) []*http.Cookie {
+	return j.jar.Cookies(u)
+}
+
+// Save writes the unexpired persistent cookies to the file, session cookies are saved if
+// withSession is true. The file is replaced atomically.
+func (j *CookieJar) Save(withSession bool) error {
+	if j.path == "" {
+		return errors.New("cookie jar has no file path")
+	}
+
+	j.mu.Lock()
+	now := j.now()
+	entries := make([]*cookieEntry, 0, len(j.entries))
+	for key, entry := range j.entries {
+		if entry.Expires.IsZero() {
+			if withSession {
+				entries = append(entries, entry)
+			}
+			continue
+		}
+		if !entry.Expires.After(now) {
+			delete(j.entries, key)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	j.mu.Unlock()
+
+	sort.Slice(entries, func(a, b int) bool {
+		return entries[a].URL+entries[a].Name < entries[b].URL+entries[b].Name
+	})
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(j.path), filepath.Base(j.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), j.path)
+}
+
+// key identifies a cookie by domain, path and name like cookiejar.
+func (e *cookieEntry) key(u *url.URL) string {
+	domain := strings.TrimPrefix(strings.ToLower(e.Domain), ".")
+	if domain == "" {
+		domain = strings.ToLower(u.Hostname())
+	}
+
+	path := e.Path
+	if path == "" || path[0] != '/' {
+		path = defaultCookiePath(u.Path)
+	}
+
+	return domain + ";" + path + ";" + e.Name
+}
+
+// defaultCookiePath returns the default path of cookie, see RFC 6265 section 5.1.4.
+func defaultCookiePath(path string) string {
+	if path == "" || path[0] != '/' {
+		return "/"
+	}
+	i := strings.LastIndex(path, "/")
+	if i == 0 {
+		return "/"
+	}
+	return path[:i]
+}
+
+func (e *cookieEntry) cookie() *http.Cookie {
+	return &http.Cookie{
+		Name:     e.Name,
+		Value:    e.Value,
+		Domain:   e.Domain,
+		Path:     e.Path,
+		Expires:  e.Expires,
+		Secure:   e.Secure,
+		HttpOnly: e.HttpOnly,
+		SameSite: http.SameSite(e.SameSite),
+	}
+}
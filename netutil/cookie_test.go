@@ -0,0 +1,93 @@
+package netutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func newCookieServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc"})
+			http.SetCookie(w, &http.Cookie{Name: "remember", Value: "1", Path: "/", MaxAge: 3600})
+		case "/logout":
+			http.SetCookie(w, &http.Cookie{Name: "remember", Path: "/", MaxAge: -1})
+		}
+		w.Write([]byte(r.Header.Get("Cookie")))
+	}))
+}
+
+func sortedCookies(header string) []string {
+	cookies := strings.Split(header, "; ")
+	sort.Strings(cookies)
+	return cookies
+}
+
+func TestCookieJar(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCookieJar")
+
+	server := newCookieServer()
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cookies.json")
+	jar, err := NewCookieJar(path)
+	assert.IsNil(err)
+
+	client := NewHttpClientWithConfig(&HttpClientConfig{CookieJar: jar})
+
+	_, err = Do[string](client.R(), http.MethodGet, server.URL+"/login")
+	assert.IsNil(err)
+	cookie, err := Do[string](client.R(), http.MethodGet, server.URL+"/check")
+	assert.IsNil(err)
+	assert.Equal([]string{"remember=1", "session=abc"}, sortedCookies(cookie))
+
+	// session cookie is not saved by default.
+	assert.IsNil(jar.Save(false))
+	loaded, err := NewCookieJar(path)
+	assert.IsNil(err)
+	u, _ := url.Parse(server.URL)
+	assert.Equal(1, len(loaded.Cookies(u)))
+	assert.Equal("remember", loaded.Cookies(u)[0].Name)
+
+	assert.IsNil(jar.Save(true))
+	loaded, _ = NewCookieJar(path)
+	client = NewHttpClientWithConfig(&HttpClientConfig{CookieJar: loaded})
+	cookie, _ = Do[string](client.R(), http.MethodGet, server.URL+"/check")
+	assert.Equal([]string{"remember=1", "session=abc"}, sortedCookies(cookie))
+
+	// deleted cookie is removed from file.
+	Do[string](client.R(), http.MethodGet, server.URL+"/logout")
+	assert.IsNil(loaded.Save(false))
+	data, _ := os.ReadFile(path)
+	assert.Equal("[]", string(data))
+
+	memory, _ := NewCookieJar("")
+	assert.IsNotNil(memory.Save(true))
+
+	os.WriteFile(path, []byte("invalid"), 0644)
+	_, err = NewCookieJar(path)
+	assert.IsNotNil(err)
+}
+
+func TestDefaultCookiePath(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDefaultCookiePath")
+
+	assert.Equal("/", defaultCookiePath(""))
+	assert.Equal("/", defaultCookiePath("/"))
+	assert.Equal("/", defaultCookiePath("/login"))
+	assert.Equal("/api", defaultCookiePath("/api/login"))
+	assert.Equal("/", defaultCookiePath("login"))
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license.
+
+package netutil
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// RunServer starts server, and shuts it down gracefully when ctx is done or SIGINT/SIGTERM is received.
+// The in-flight requests are waited until shutdownTimeout, then the remaining connections are closed.
+// It serves TLS if server.TLSConfig has certificates. It returns nil if the server is shut down normally.
+func RunServer(ctx context.Context, server *http.Server, shutdownTimeout time.Duration) error {
+	return runServer(ctx, server, shutdownTimeout, func() error {
+		if server.TLSConfig != nil && (len(server.TLSConfig.Certificates) > 0 || server.TLSConfig.GetCertificate != nil) {
+			return server.ListenAndServeTLS("", "")
+		}
+		return server.ListenAndServe()
+	})
+}
+
+// ServeGracefully is like RunServer, but serves on the listener.
+func ServeGracefully(ctx context.Context, server *http.Server, listener net.Listener, shutdownTimeout time.Duration) error {
+	return runServer(ctx, server, shutdownTimeout, func() error {
+		return server.Serve(listener)
+	})
+}
+
+func runServer(ctx context.Context, server *http.Server, shutdownTimeout time.Duration, serve func() error) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- serve()
+	}()
+
+	select {
+	case err := <-errChan:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	err := server.Shutdown(shutdownCtx)
+	if err != nil {
+		server.Close()
+	}
+
+	if serveErr := <-errChan; !errors.Is(serveErr, http.ErrServerClosed) && err == nil {
+		err = serveErr
+	}
+
+	return err
+}
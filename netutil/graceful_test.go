@@ -0,0 +1,67 @@
+package netutil
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestServeGracefully(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestServeGracefully")
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.IsNil(err)
+
+	started := make(chan struct{})
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte("done"))
+	})}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() {
+		result <- ServeGracefully(ctx, server, listener, time.Second)
+	}()
+
+	body := make(chan string, 1)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String())
+		if err != nil {
+			body <- err.Error()
+			return
+		}
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		body <- string(data)
+	}()
+
+	// the in-flight request is finished before shutting down.
+	<-started
+	cancel()
+	assert.Equal("done", <-body)
+	assert.IsNil(<-result)
+}
+
+func TestRunServer(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRunServer")
+
+	server := &http.Server{Addr: "127.0.0.1:0", Handler: http.NotFoundHandler()}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.IsNil(RunServer(ctx, server, time.Second))
+
+	// the address is invalid.
+	server = &http.Server{Addr: "127.0.0.1:-1"}
+	assert.IsNotNil(RunServer(context.Background(), server, time.Second))
+}
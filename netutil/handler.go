@@ -0,0 +1,594 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license.
+
+package netutil
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/duke-git/lancet/v2/xerror"
+)
+
+// HandlerMiddleware wraps a http.Handler of server.
+type HandlerMiddleware func(next http.Handler) http.Handler
+
+// ChainHandler wraps handler with middlewares, the first middleware is the outermost one.
+func ChainHandler(handler http.Handler, middlewares ...HandlerMiddleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// responseWriter records the status code and size of response.
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	size        int64
+	wroteHeader bool
+}
+
+func newResponseWriter(w http.ResponseWriter) *responseWriter {
+	if rw, ok := w.(*responseWriter); ok {
+		return rw
+	}
+	return &responseWriter{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.status = status
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(b)
+	w.size += int64(n)
+	return n, err
+}
+
+// Flush implements http.Flusher interface.
+func (w *responseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wroteHeader = true
+		flusher.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker interface.
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer doesn't support hijacking")
+	}
+	return hijacker.Hijack()
+}
+
+// Unwrap returns the original http.ResponseWriter, it's used by http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Recovery recovers panic in handler, and writes ErrCodeInternal error response if the response is not written.
+// The panic is converted to XError by xerror.FromPanic and passed to onPanic if it's not nil.
+func Recovery(onPanic func(r *http.Request, err *xerror.XError)) HandlerMiddleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw := newResponseWriter(w)
+
+			defer func() {
+				value := recover()
+				if value == nil {
+					return
+				}
+				// http.ErrAbortHandler aborts the response intentionally.
+				if value == http.ErrAbortHandler {
+					panic(value)
+				}
+
+				err := xerror.FromPanic(value)
+				if onPanic != nil {
+					onPanic(r, err)
+				}
+				if !rw.wroteHeader {
+					WriteError(rw, r, ErrCodeInternal.Wrap(err))
+				}
+			}()
+
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
+
+// AccessLog is the log entry of a request handled by server.
+type AccessLog struct {
+	Method    string
+	Path      string
+	Query     string
+	RemoteIP  string
+	UserAgent string
+	RequestID string
+	Status    int
+	Size      int64
+	Duration  time.Duration
+}
+
+// AccessLogging calls logFn with the log entry after each request is handled.
+func AccessLogging(logFn func(entry *AccessLog)) HandlerMiddleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rw := newResponseWriter(w)
+
+			defer func() {
+				logFn(&AccessLog{
+					Method:    r.Method,
+					Path:      r.URL.Path,
+					Query:     r.URL.RawQuery,
+					RemoteIP:  remoteIP(r),
+					UserAgent: r.UserAgent(),
+					RequestID: requestIDOf(r),
+					Status:    rw.status,
+					Size:      rw.size,
+					Duration:  time.Since(start),
+				})
+			}()
+
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
+
+// StdAccessLogging logs requests by standard log package.
+func StdAccessLogging(logger *log.Logger) HandlerMiddleware {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return AccessLogging(func(entry *AccessLog) {
+		logger.Printf("%s %s %s status=%d size=%d duration=%s", entry.RemoteIP, entry.Method, entry.Path,
+			entry.Status, entry.Size, entry.Duration)
+	})
+}
+
+// remoteIP returns the ip of request RemoteAddr.
+func remoteIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return ip
+}
+
+// CORSConfig is the config of CORS middleware.
+type CORSConfig struct {
+	// AllowedOrigins is the origins allowed, "*" allows all origins, "https://*.example.com" allows subdomains.
+	AllowedOrigins []string
+	// AllowOriginFunc checks the origin if it's not nil, AllowedOrigins is ignored.
+	AllowOriginFunc func(origin string) bool
+	// AllowedMethods default is GET, POST, PUT, PATCH, DELETE, HEAD and OPTIONS.
+	AllowedMethods []string
+	// AllowedHeaders is the headers allowed in request, the headers requested by preflight are allowed if it's empty.
+	AllowedHeaders []string
+	// ExposedHeaders is the response headers which can be read by client.
+	ExposedHeaders   []string
+	AllowCredentials bool
+	// MaxAge is the cache time of preflight response.
+	MaxAge time.Duration
+}
+
+var defaultCORSMethods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodHead, http.MethodOptions,
+}
+
+// CORS handles cross origin requests, preflight requests are responded with 204 No Content.
+func CORS(config CORSConfig) HandlerMiddleware {
+	if len(config.AllowedMethods) == 0 {
+		config.AllowedMethods = defaultCORSMethods
+	}
+	allowedMethods := strings.Join(config.AllowedMethods, ", ")
+	allowedHeaders := strings.Join(config.AllowedHeaders, ", ")
+	exposedHeaders := strings.Join(config.ExposedHeaders, ", ")
+
+	allowAll := false
+	for _, origin := range config.AllowedOrigins {
+		if origin == "*" {
+			allowAll = true
+		}
+	}
+
+	allowed := func(origin string) bool {
+		if config.AllowOriginFunc != nil {
+			return config.AllowOriginFunc(origin)
+		}
+		if allowAll {
+			return true
+		}
+		for _, pattern := range config.AllowedOrigins {
+			if matchOrigin(pattern, origin) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			header := w.Header()
+			header.Add("Vary", "Origin")
+
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+			if preflight {
+				header.Add("Vary", "Access-Control-Request-Method")
+				header.Add("Vary", "Access-Control-Request-Headers")
+			}
+
+			if origin == "" || !allowed(origin) {
+				if preflight {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if allowAll && !config.AllowCredentials && config.AllowOriginFunc == nil {
+				header.Set("Access-Control-Allow-Origin", "*")
+			} else {
+				header.Set("Access-Control-Allow-Origin", origin)
+			}
+			if config.AllowCredentials {
+				header.Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if !preflight {
+				if exposedHeaders != "" {
+					header.Set("Access-Control-Expose-Headers", exposedHeaders)
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			header.Set("Access-Control-Allow-Methods", allowedMethods)
+			if allowedHeaders != "" {
+				header.Set("Access-Control-Allow-Headers", allowedHeaders)
+			} else if requested := r.Header.Get("Access-Control-Request-Headers"); requested != "" {
+				header.Set("Access-Control-Allow-Headers", requested)
+			}
+			if config.MaxAge > 0 {
+				header.Set("Access-Control-Max-Age", strconv.Itoa(int(config.MaxAge.Seconds())))
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// matchOrigin matches origin with pattern, pattern may contain a "*" as wildcard, eg: "https://*.example.com".
+func matchOrigin(pattern, origin string) bool {
+	prefix, suffix, found := strings.Cut(pattern, "*")
+	if !found {
+		return strings.EqualFold(pattern, origin)
+	}
+	return len(origin) >= len(prefix)+len(suffix) &&
+		strings.HasPrefix(strings.ToLower(origin), strings.ToLower(prefix)) &&
+		strings.HasSuffix(strings.ToLower(origin), strings.ToLower(suffix))
+}
+
+var gzipWriterPool = sync.Pool{}
+
+// Gzip compresses response if client accepts gzip encoding, level is the gzip compression level,
+// gzip.DefaultCompression is used if it's invalid. Response with Content-Encoding header is not compressed.
+func Gzip(level int) HandlerMiddleware {
+	if level < gzip.HuffmanOnly || level > gzip.BestCompression {
+		level = gzip.DefaultCompression
+	}
+
+	pool := &gzipWriterPool
+	if level != gzip.DefaultCompression {
+		pool = &sync.Pool{}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			if !acceptGzip(r) || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			gw := &gzipResponseWriter{ResponseWriter: w, pool: pool, level: level}
+			defer gw.close()
+
+			next.ServeHTTP(gw, r)
+		})
+	}
+}
+
+func acceptGzip(r *http.Request) bool {
+	for _, encoding := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(encoding), ";")
+		if strings.EqualFold(strings.TrimSpace(name), "gzip") {
+			return strings.ReplaceAll(params, " ", "") != "q=0"
+		}
+	}
+	return false
+}
+
+// gzipResponseWriter decides whether to compress response when the first data is written,
+// so the content type can be sniffed from the uncompressed data.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	pool   *sync.Pool
+	level  int
+	writer *gzip.Writer
+	// status is the status code written by handler, it's sent with the header by the first Write.
+	status      int
+	wroteHeader bool
+}
+
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader || w.status != 0 {
+		return
+	}
+	if status < http.StatusOK {
+		// informational response is sent before the final one.
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	w.status = status
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		if b == nil {
+			b = []byte{}
+		}
+		w.writeHeader(b)
+	}
+	if w.writer == nil {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.writer.Write(b)
+}
+
+// writeHeader sends the header, data is the first data of response, it's nil if there is no data written,
+// the response is compressed only if there is data.
+func (w *gzipResponseWriter) writeHeader(data []byte) {
+	w.wroteHeader = true
+
+	status := w.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	header := w.Header()
+	if data != nil && header.Get("Content-Encoding") == "" && header.Get("Content-Range") == "" &&
+		status != http.StatusNoContent && status != http.StatusNotModified && status != http.StatusPartialContent {
+		if header.Get("Content-Type") == "" {
+			header.Set("Content-Type", http.DetectContentType(data))
+		}
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+
+		if gz, ok := w.pool.Get().(*gzip.Writer); ok {
+			gz.Reset(w.ResponseWriter)
+			w.writer = gz
+		} else {
+			w.writer, _ = gzip.NewWriterLevel(w.ResponseWriter, w.level)
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Flush implements http.Flusher interface.
+func (w *gzipResponseWriter) Flush() {
+	if !w.wroteHeader {
+		w.writeHeader(nil)
+	}
+	if w.writer != nil {
+		w.writer.Flush()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the original http.ResponseWriter, it's used by http.ResponseController.
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *gzipResponseWriter) close() {
+	if !w.wroteHeader && w.status != 0 {
+		w.writeHeader(nil)
+	}
+	if w.writer == nil {
+		return
+	}
+	w.writer.Close()
+	w.writer.Reset(io.Discard)
+	w.pool.Put(w.writer)
+	w.writer = nil
+}
+
+// Timeout cancels the request context after timeout, and writes ErrCodeTimeout error response if the
+// handler doesn't finish in time. The response of handler is buffered until it finishes, like http.TimeoutHandler.
+func Timeout(timeout time.Duration) HandlerMiddleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			r = r.WithContext(ctx)
+
+			tw := &timeoutWriter{header: make(http.Header), status: http.StatusOK}
+			done := make(chan struct{})
+			panicChan := make(chan any, 1)
+
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicChan <- p
+					}
+				}()
+				next.ServeHTTP(tw, r)
+				close(done)
+			}()
+
+			select {
+			case p := <-panicChan:
+				panic(p)
+			case <-done:
+				tw.mu.Lock()
+				defer tw.mu.Unlock()
+
+				header := w.Header()
+				for k, v := range tw.header {
+					header[k] = v
+				}
+				w.WriteHeader(tw.status)
+				w.Write(tw.buf.Bytes())
+			case <-ctx.Done():
+				tw.mu.Lock()
+				defer tw.mu.Unlock()
+
+				tw.timedOut = true
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					WriteError(w, r, ErrCodeTimeout.New(""))
+				}
+			}
+		})
+	}
+}
+
+// timeoutWriter buffers the response of handler.
+type timeoutWriter struct {
+	mu          sync.Mutex
+	header      http.Header
+	buf         bytes.Buffer
+	status      int
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (w *timeoutWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *timeoutWriter) WriteHeader(status int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.timedOut || w.wroteHeader {
+		return
+	}
+	w.status = status
+	w.wroteHeader = true
+}
+
+func (w *timeoutWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	w.wroteHeader = true
+	return w.buf.Write(b)
+}
+
+// IPFilterConfig is the config of IPFilter middleware, an item is an ip, CIDR or ip range,
+// eg: "10.0.0.1", "192.168.0.0/16", "10.0.0.1-10.0.0.9".
+type IPFilterConfig struct {
+	// Allow is the allowed ips, all ips are allowed if it's empty.
+	Allow []string
+	// Deny is the denied ips, it takes precedence over Allow.
+	Deny []string
+	// TrustedProxies is the ips of trusted proxies. If the request comes from a trusted proxy, the client ip is
+	// the rightmost ip of X-Forwarded-For header which is not a trusted proxy, because the left entries can be
+	// forged by client. X-Real-Ip header is used if there is no X-Forwarded-For header.
+	TrustedProxies []string
+}
+
+// IPFilter rejects requests from denied or not allowed ips with ErrCodeForbidden error response.
+// It returns error if an item of config is not valid, see ParseIPSet.
+func IPFilter(config IPFilterConfig) (HandlerMiddleware, error) {
+	allow, err := ParseIPSet(config.Allow...)
+	if err != nil {
+		return nil, err
+	}
+	deny, err := ParseIPSet(config.Deny...)
+	if err != nil {
+		return nil, err
+	}
+	proxies, err := ParseIPSet(config.TrustedProxies...)
+	if err != nil {
+		return nil, err
+	}
+
+	permitted := func(ip net.IP) bool {
+		if ip == nil {
+			return false
+		}
+		if deny.Contains(ip) {
+			return false
+		}
+		return allow.IsEmpty() || allow.Contains(ip)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := clientIP(r, proxies)
+			if !permitted(net.ParseIP(ip)) {
+				WriteError(w, r, ErrCodeForbidden.New("ip %s is not allowed", ip))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+// clientIP returns the client ip of request, the ips of X-Forwarded-For header are checked from right to left,
+// until an ip isn't a trusted proxy.
+func clientIP(r *http.Request, proxies *IPSet) string {
+	ip := remoteIP(r)
+	if !proxies.Contains(net.ParseIP(ip)) {
+		return ip
+	}
+
+	forwarded := r.Header.Values("X-Forwarded-For")
+	if len(forwarded) == 0 {
+		if realIP := strings.TrimSpace(r.Header.Get("X-Real-Ip")); realIP != "" {
+			return realIP
+		}
+		return ip
+	}
+
+	hops := strings.Split(strings.Join(forwarded, ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip = strings.TrimSpace(hops[i])
+		if !proxies.Contains(net.ParseIP(ip)) {
+			return ip
+		}
+	}
+
+	return ip
+}
@@ -0,0 +1,384 @@
+package netutil
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+	"github.com/duke-git/lancet/v2/xerror"
+)
+
+func TestChainHandler(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestChainHandler")
+
+	var order []string
+	trace := func(name string) HandlerMiddleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	handler := ChainHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		order = append(order, "handler")
+	}), trace("a"), trace("b"))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal([]string{"a", "b", "handler"}, order)
+}
+
+func TestRecovery(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRecovery")
+
+	var panicErr *xerror.XError
+	var entry *AccessLog
+	handler := ChainHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/written" {
+			w.WriteHeader(http.StatusAccepted)
+		}
+		panic("boom")
+	}), AccessLogging(func(e *AccessLog) { entry = e }), Recovery(func(r *http.Request, err *xerror.XError) {
+		panicErr = err
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(http.StatusInternalServerError, recorder.Code)
+	assert.Equal(ErrCodeInternal.Code, decodeResponse(recorder).Code)
+	assert.Equal("boom", panicErr.Error())
+	assert.Equal(true, panicErr.Values()["panic"])
+	assert.Equal(http.StatusInternalServerError, entry.Status)
+
+	// response is not overwritten.
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/written", nil))
+	assert.Equal(http.StatusAccepted, recorder.Code)
+	assert.Equal(0, recorder.Body.Len())
+
+	// http.ErrAbortHandler is not recovered.
+	handler = Recovery(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	defer func() {
+		assert.Equal(http.ErrAbortHandler, recover())
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestAccessLogging(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestAccessLogging")
+
+	var buf bytes.Buffer
+	handler := ChainHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("created"))
+	}), StdAccessLogging(log.New(&buf, "", 0)))
+
+	req := httptest.NewRequest(http.MethodPost, "/users?id=1", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(true, strings.HasPrefix(buf.String(), "10.0.0.1 POST /users status=201 size=7 duration="))
+}
+
+func TestCORS(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCORS")
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+
+	serve := func(handler http.Handler, method, origin string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/", nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	handler := CORS(CORSConfig{
+		AllowedOrigins:   []string{"https://example.com", "https://*.lancet.dev"},
+		ExposedHeaders:   []string{"X-Total"},
+		AllowCredentials: true,
+		MaxAge:           time.Hour,
+	})(next)
+
+	recorder := serve(handler, http.MethodGet, "https://api.lancet.dev", nil)
+	assert.Equal("ok", recorder.Body.String())
+	assert.Equal("https://api.lancet.dev", recorder.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal("true", recorder.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal("X-Total", recorder.Header().Get("Access-Control-Expose-Headers"))
+
+	recorder = serve(handler, http.MethodOptions, "https://example.com", map[string]string{
+		"Access-Control-Request-Method":  "PUT",
+		"Access-Control-Request-Headers": "X-Token",
+	})
+	assert.Equal(http.StatusNoContent, recorder.Code)
+	assert.Equal("", recorder.Body.String())
+	assert.Equal("GET, POST, PUT, PATCH, DELETE, HEAD, OPTIONS", recorder.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal("X-Token", recorder.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal("3600", recorder.Header().Get("Access-Control-Max-Age"))
+
+	// disallowed origin.
+	recorder = serve(handler, http.MethodGet, "https://other.com", nil)
+	assert.Equal("ok", recorder.Body.String())
+	assert.Equal("", recorder.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal([]string{"Origin"}, recorder.Header().Values("Vary"))
+
+	recorder = serve(handler, http.MethodOptions, "https://lancet.dev", map[string]string{"Access-Control-Request-Method": "GET"})
+	assert.Equal(http.StatusNoContent, recorder.Code)
+	assert.Equal("", recorder.Header().Get("Access-Control-Allow-Origin"))
+
+	handler = CORS(CORSConfig{AllowedOrigins: []string{"*"}, AllowedHeaders: []string{"X-A", "X-B"}})(next)
+	recorder = serve(handler, http.MethodOptions, "https://any.com", map[string]string{"Access-Control-Request-Method": "GET"})
+	assert.Equal("*", recorder.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal("X-A, X-B", recorder.Header().Get("Access-Control-Allow-Headers"))
+
+	handler = CORS(CORSConfig{AllowOriginFunc: func(origin string) bool { return origin == "null" }})(next)
+	recorder = serve(handler, http.MethodGet, "null", nil)
+	assert.Equal("null", recorder.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestGzip(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGzip")
+
+	content := strings.Repeat("lancet ", 100)
+	handler := Gzip(gzip.BestSpeed)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/encoded":
+			w.Header().Set("Content-Encoding", "br")
+		case "/empty":
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case "/html":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte("<html><body>" + content + "</body></html>"))
+			return
+		case "/range":
+			http.ServeContent(w, r, "file", time.Time{}, strings.NewReader(content))
+			return
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(content))
+	}))
+
+	serve := func(path, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	recorder := serve("/", "deflate, gzip;q=1.0")
+	assert.Equal("gzip", recorder.Header().Get("Content-Encoding"))
+	assert.Equal("text/plain; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.Equal("Accept-Encoding", recorder.Header().Get("Vary"))
+	assert.Equal(true, recorder.Body.Len() < len(content))
+
+	reader, err := gzip.NewReader(recorder.Body)
+	assert.IsNil(err)
+	body, _ := io.ReadAll(reader)
+	assert.Equal(content, string(body))
+
+	// the pooled writer is reused.
+	recorder = serve("/", "gzip")
+	reader, _ = gzip.NewReader(recorder.Body)
+	body, _ = io.ReadAll(reader)
+	assert.Equal(content, string(body))
+
+	recorder = serve("/", "gzip;q=0")
+	assert.Equal("", recorder.Header().Get("Content-Encoding"))
+	assert.Equal(content, recorder.Body.String())
+
+	recorder = serve("/encoded", "gzip")
+	assert.Equal("br", recorder.Header().Get("Content-Encoding"))
+	assert.Equal(content, recorder.Body.String())
+
+	recorder = serve("/empty", "gzip")
+	assert.Equal(http.StatusNoContent, recorder.Code)
+	assert.Equal("", recorder.Header().Get("Content-Encoding"))
+
+	// the content type is sniffed from the uncompressed data after WriteHeader.
+	recorder = serve("/html", "gzip")
+	assert.Equal(http.StatusCreated, recorder.Code)
+	assert.Equal("gzip", recorder.Header().Get("Content-Encoding"))
+	assert.Equal("text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+
+	recorder = serve("/missing", "gzip")
+	assert.Equal(http.StatusNotFound, recorder.Code)
+	assert.Equal("", recorder.Header().Get("Content-Encoding"))
+
+	// partial content isn't compressed.
+	req := httptest.NewRequest(http.MethodGet, "/range", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set("Range", "bytes=0-6")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(http.StatusPartialContent, recorder.Code)
+	assert.Equal("", recorder.Header().Get("Content-Encoding"))
+	assert.Equal("lancet ", recorder.Body.String())
+
+	handler = Gzip(100)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(content))
+	}))
+	recorder = serve("/", "gzip")
+	assert.Equal("gzip", recorder.Header().Get("Content-Encoding"))
+}
+
+func TestTimeout(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestTimeout")
+
+	handler := Timeout(50 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			<-r.Context().Done()
+			_, err := w.Write([]byte("late"))
+			assert.Equal(http.ErrHandlerTimeout, err)
+			return
+		}
+		if r.URL.Path == "/panic" {
+			panic("boom")
+		}
+		w.Header().Set("X-Handler", "fast")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("fast"))
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(http.StatusCreated, recorder.Code)
+	assert.Equal("fast", recorder.Body.String())
+	assert.Equal("fast", recorder.Header().Get("X-Handler"))
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	assert.Equal(http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(ErrCodeTimeout.Code, decodeResponse(recorder).Code)
+
+	// panic is propagated to the serving goroutine.
+	recorder = httptest.NewRecorder()
+	Recovery(nil)(handler).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	assert.Equal(http.StatusInternalServerError, recorder.Code)
+
+	// request is canceled by client.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/slow", nil).WithContext(ctx))
+	assert.Equal(0, recorder.Body.Len())
+}
+
+func TestIPFilter(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestIPFilter")
+
+	filter, err := IPFilter(IPFilterConfig{
+		Allow: []string{"10.0.0.0/8", "192.168.1.1", "2001:db8::/32"},
+		Deny:  []string{"10.0.0.13", "10.1.0.0/16"},
+	})
+	assert.IsNil(err)
+
+	handler := filter(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+
+	tests := []struct {
+		remoteAddr string
+		status     int
+	}{
+		{"10.0.0.1:80", http.StatusOK},
+		{"192.168.1.1:80", http.StatusOK},
+		{"[2001:db8::1]:80", http.StatusOK},
+		{"10.0.0.13:80", http.StatusForbidden},
+		{"10.1.2.3:80", http.StatusForbidden},
+		{"192.168.1.2:80", http.StatusForbidden},
+		{"[::1]:80", http.StatusForbidden},
+		{"invalid", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = tt.remoteAddr
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		assert.Equal(tt.status, recorder.Code)
+	}
+
+	// client ip from proxy header.
+	filter, err = IPFilter(IPFilterConfig{Deny: []string{"8.8.8.8"}, TrustedProxies: []string{"10.0.0.0/8"}})
+	assert.IsNil(err)
+	handler = filter(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	proxyTests := []struct {
+		remoteAddr string
+		forwarded  []string
+		realIP     string
+		status     int
+	}{
+		{"10.0.0.1:80", []string{"8.8.8.8"}, "", http.StatusForbidden},
+		{"10.0.0.1:80", []string{"8.8.8.8, 10.0.0.2"}, "", http.StatusForbidden},
+		{"10.0.0.1:80", []string{"8.8.8.8", "10.0.0.2"}, "", http.StatusForbidden},
+		{"10.0.0.1:80", nil, "8.8.8.8", http.StatusForbidden},
+		// the headers of untrusted client are ignored.
+		{"8.8.8.8:80", []string{"1.1.1.1"}, "1.1.1.1", http.StatusForbidden},
+		// spoofed ip on the left is ignored, the ip appended by trusted proxy is used.
+		{"10.0.0.1:80", []string{"1.1.1.1, 8.8.8.8"}, "", http.StatusForbidden},
+		{"10.0.0.1:80", []string{"8.8.8.8, 1.1.1.1"}, "", http.StatusOK},
+		{"10.0.0.1:80", []string{"10.0.0.2"}, "", http.StatusOK},
+	}
+
+	for _, tt := range proxyTests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = tt.remoteAddr
+		for _, v := range tt.forwarded {
+			req.Header.Add("X-Forwarded-For", v)
+		}
+		if tt.realIP != "" {
+			req.Header.Set("X-Real-Ip", tt.realIP)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		assert.Equal(tt.status, recorder.Code)
+		if tt.status == http.StatusForbidden {
+			assert.Equal("ip 8.8.8.8 is not allowed", decodeResponse(recorder).Message)
+		}
+	}
+
+	_, err = IPFilter(IPFilterConfig{Allow: []string{"10.0.0.0/33"}})
+	assert.IsNotNil(err)
+	_, err = IPFilter(IPFilterConfig{Deny: []string{"abc"}})
+	assert.IsNotNil(err)
+	_, err = IPFilter(IPFilterConfig{TrustedProxies: []string{"abc"}})
+	assert.IsNotNil(err)
+}
@@ -26,6 +26,7 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/duke-git/lancet/v2/slice"
@@ -108,8 +109,15 @@ type HttpClientConfig struct {
 	Compressed       bool
 	HandshakeTimeout time.Duration
 	ResponseTimeout  time.Duration
-	Verbose          bool
-	Proxy            *url.URL
+	// Verbose logs every request by StdLogging middleware.
+	Verbose bool
+	Proxy   *url.URL
+	// Retry retries failed requests if it's not nil, see Retry middleware.
+	Retry *HttpRetryConfig
+	// CircuitBreaker rejects requests to a failing host if it's not nil, see CircuitBreaker.
+	CircuitBreaker *CircuitBreakerConfig
+	// CookieJar stores cookies of responses, and sends them with requests, see CookieJar.
+	CookieJar http.CookieJar
 }
 
 // defaultHttpClientConfig defalut client config.
@@ -123,10 +131,17 @@ var defaultHttpClientConfig = &HttpClientConfig{
 // HttpClient is used for sending http request.
 type HttpClient struct {
 	*http.Client
+	// TLS is the tls config of transport, use SetTLS to change it.
 	TLS     *tls.Config
 	Request *http.Request
 	Config  HttpClientConfig
 	Context context.Context
+
+	// transport is the underlying transport wrapped by middlewares.
+	transport      http.RoundTripper
+	middlewares    []Middleware
+	circuitBreaker *CircuitBreaker
+	tlsMu          sync.Mutex
 }
 
 // NewHttpClient make a HttpClient instance.
@@ -162,9 +177,10 @@ func NewHttpClientWithConfig(config *HttpClientConfig) *HttpClient {
 		},
 		Config: *config,
 	}
+	client.Client.Jar = config.CookieJar
 
 	if config.SSLEnabled {
-		client.TLS = config.TLSConfig
+		client.SetTLS(config.TLSConfig)
 	}
 
 	if config.Proxy != nil {
@@ -172,6 +188,20 @@ func NewHttpClientWithConfig(config *HttpClientConfig) *HttpClient {
 		transport.Proxy = http.ProxyURL(config.Proxy)
 	}
 
+	// retry is the outermost middleware, so every attempt goes through circuit breaker and logging.
+	if config.Retry != nil {
+		client.Use(Retry(*config.Retry))
+	}
+
+	if config.CircuitBreaker != nil {
+		client.circuitBreaker = NewCircuitBreaker(*config.CircuitBreaker)
+		client.Use(client.circuitBreaker.Middleware())
+	}
+
+	if config.Verbose {
+		client.Use(StdLogging(nil))
+	}
+
 	return client
 }
 
@@ -195,7 +225,6 @@ func (client *HttpClient) SendRequest(request *HttpRequest) (*http.Response, err
 		return nil, err
 	}
 
-	client.setTLS(rawUrl)
 	client.setHeader(req, request.Headers)
 
 	err = client.setQueryParam(req, rawUrl, request.QueryParams)
@@ -221,6 +250,11 @@ func (client *HttpClient) SendRequest(request *HttpRequest) (*http.Response, err
 	return resp, nil
 }
 
+// CircuitBreaker returns the circuit breaker created by HttpClientConfig.CircuitBreaker, it's nil if not configured.
+func (client *HttpClient) CircuitBreaker() *CircuitBreaker {
+	return client.circuitBreaker
+}
+
 // AsyncSendRequest send http request with goroutine, pop response and error to channels
 func (client *HttpClient) AsyncSendRequest(request *HttpRequest, respChan chan *http.Response, errChan chan error) {
 	go func() {
@@ -247,13 +281,26 @@ func (client *HttpClient) DecodeResponse(resp *http.Response, target any) error
 	return json.NewDecoder(resp.Body).Decode(target)
 }
 
-// setTLS set http client transport TLSClientConfig
-func (client *HttpClient) setTLS(rawUrl string) {
-	if strings.HasPrefix(rawUrl, "https") {
-		if transport, ok := client.Client.Transport.(*http.Transport); ok {
-			transport.TLSClientConfig = client.TLS
-		}
+// SetTLS sets the tls config of http client transport, it should be called before sending requests,
+// the transport isn't modified by requests, so the settings added by transport (e.g. http2) are kept.
+func (client *HttpClient) SetTLS(config *tls.Config) *HttpClient {
+	client.tlsMu.Lock()
+	defer client.tlsMu.Unlock()
+
+	client.TLS = config
+	if transport, ok := client.baseTransport().(*http.Transport); ok {
+		transport.TLSClientConfig = config
+	}
+
+	return client
+}
+
+// baseTransport returns the transport which is not wrapped by middlewares.
+func (client *HttpClient) baseTransport() http.RoundTripper {
+	if client.transport != nil {
+		return client.transport
 	}
+	return client.Client.Transport
 }
 
 // setHeader set http request header
@@ -2,12 +2,15 @@ package netutil
 
 import (
 	"bytes"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -432,3 +435,59 @@ func TestProxy(t *testing.T) {
 		t.Fatalf("expected %d, got %d", http.StatusOK, resp.StatusCode)
 	}
 }
+
+func TestHttpClient_SetTLS(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestHttpClient_SetTLS")
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	config := &tls.Config{RootCAs: pool}
+
+	client := NewHttpClient().SetTLS(config)
+	transport := client.Client.Transport.(*http.Transport)
+	assert.Equal(config, client.TLS)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.R().Get(server.URL)
+			assert.IsNil(err)
+		}()
+	}
+	wg.Wait()
+
+	// the tls config set by transport isn't replaced by requests.
+	tlsConfig := transport.TLSClientConfig
+	resp, err := client.SendRequest(&HttpRequest{RawURL: server.URL, Method: "GET"})
+	assert.IsNil(err)
+	resp.Body.Close()
+	assert.Equal(true, tlsConfig == transport.TLSClientConfig)
+
+	// the http2 settings of transport without tls config are kept.
+	client = NewHttpClient()
+	transport = client.Client.Transport.(*http.Transport)
+	_, err = client.R().Get(server.URL)
+	assert.IsNotNil(err)
+	tlsConfig = transport.TLSClientConfig
+	assert.IsNotNil(tlsConfig)
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.R().Get(server.URL)
+			assert.IsNotNil(err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(true, tlsConfig == transport.TLSClientConfig)
+}
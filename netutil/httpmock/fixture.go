@@ -0,0 +1,342 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license.
+
+package httpmock
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/duke-git/lancet/v2/fileutil"
+)
+
+// Mode is the mode of Recorder.
+type Mode int
+
+const (
+	// ModeAuto replays the fixture file if it exists, otherwise records a new one.
+	ModeAuto Mode = iota
+	// ModeRecord sends requests by the real transport, and records the interactions.
+	ModeRecord
+	// ModeReplay responds requests by the recorded interactions, no request is sent.
+	ModeReplay
+)
+
+// ErrNoInteraction is returned by Recorder in replay mode if no recorded interaction matches the request.
+var ErrNoInteraction = errors.New("httpmock: no recorded interaction matches request")
+
+// defaultRedactedHeaders is the request and response headers which are not written to fixture file by default.
+var defaultRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// defaultRedactedQuery is the url query parameters and form fields which are not written to fixture file by default.
+var defaultRedactedQuery = []string{"access_token", "api_key", "apikey", "client_secret", "password", "secret", "token"}
+
+// redactedValue replaces the values of redacted headers.
+const redactedValue = "REDACTED"
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the request of Interaction.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+	// BodyEncoding is "base64" if the body isn't valid utf-8 text.
+	BodyEncoding string `json:"body_encoding,omitempty"`
+}
+
+// RecordedResponse is the response of Interaction.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	// BodyEncoding is "base64" if the body isn't valid utf-8 text.
+	BodyEncoding string `json:"body_encoding,omitempty"`
+}
+
+// fixture is the content of fixture file.
+type fixture struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Recorder is a http.RoundTripper which records real interactions into a json fixture file once,
+// and replays them later, so the tests can run offline. It's safe for concurrent use.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	redacted  []string
+	// redactedQuery is the query parameters whose values are replaced, they are ignored when matching requests.
+	redactedQuery []string
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	// replayed marks the interactions which have been replayed.
+	replayed []bool
+}
+
+// NewRecorder returns a recorder of fixture file. In record mode, requests are sent by transport
+// (http.DefaultTransport if it's nil), and the interactions are written to the file by Save.
+// In replay mode, the fixture file is loaded, and every interaction is replayed once in recorded order.
+func NewRecorder(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if mode == ModeAuto {
+		mode = ModeRecord
+		if fileutil.IsExist(path) {
+			mode = ModeReplay
+		}
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: transport,
+		redacted:  defaultRedactedHeaders,
+
+		redactedQuery: defaultRedactedQuery,
+	}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var f fixture
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("httpmock: invalid fixture file %s: %w", path, err)
+		}
+		r.interactions = f.Interactions
+		r.replayed = make([]bool, len(f.Interactions))
+	}
+
+	return r, nil
+}
+
+// Mode returns the mode of recorder, it's ModeRecord or ModeReplay.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// RedactHeaders sets the request and response headers whose values are replaced in fixture file,
+// default is Authorization, Proxy-Authorization, Cookie and Set-Cookie.
+func (r *Recorder) RedactHeaders(keys ...string) *Recorder {
+	r.redacted = keys
+	return r
+}
+
+// RedactQuery sets the url query parameters whose values are replaced in fixture file, they are also replaced in
+// the application/x-www-form-urlencoded request body. The values are ignored when matching requests in replay mode.
+// default is access_token, api_key, apikey, client_secret, password, secret and token.
+func (r *Recorder) RedactQuery(keys ...string) *Recorder {
+	r.redactedQuery = keys
+	return r
+}
+
+// Interactions returns the recorded or loaded interactions.
+func (r *Recorder) Interactions() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]*Interaction{}, r.interactions...)
+}
+
+// RoundTrip implements http.RoundTripper interface.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	realReq := req.Clone(req.Context())
+	if body != nil {
+		realReq.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := r.transport.RoundTrip(realReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := &Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    redactURL(req.URL, r.redactedQuery).String(),
+			Header: redactHeader(req.Header, r.redacted),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redactHeader(resp.Header, r.redacted),
+		},
+	}
+	interaction.Request.Body, interaction.Request.BodyEncoding = encodeBody(redactBody(req.Header, body, r.redactedQuery))
+	interaction.Response.Body, interaction.Response.BodyEncoding = encodeBody(respBody)
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, interaction)
+	r.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.interactions {
+		if r.replayed[i] || !interaction.Request.matches(req, body, r.redactedQuery) {
+			continue
+		}
+
+		respBody, err := decodeBody(interaction.Response.Body, interaction.Response.BodyEncoding)
+		if err != nil {
+			return nil, err
+		}
+		r.replayed[i] = true
+
+		resp := NewResponse(interaction.Response.StatusCode, interaction.Response.Header.Clone(), respBody)
+		resp.Request = req
+
+		return resp, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL)
+}
+
+// Save writes the recorded interactions to fixture file in record mode, the directory is created if
+// it doesn't exist. It does nothing in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode == ModeReplay {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(&fixture{Interactions: r.interactions}, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, append(data, '\n'), 0644)
+}
+
+// matches checks if the request has the same method, url and body as the recorded one,
+// the order of query parameters and the values of redacted query parameters are ignored.
+func (rr *RecordedRequest) matches(req *http.Request, body []byte, redactedQuery []string) bool {
+	if rr.Method != req.Method {
+		return false
+	}
+
+	u, err := url.Parse(rr.URL)
+	if err != nil || u.Scheme != req.URL.Scheme || u.Host != req.URL.Host || u.Path != req.URL.Path ||
+		u.Query().Encode() != redactURL(req.URL, redactedQuery).Query().Encode() {
+		return false
+	}
+
+	recordedBody, err := decodeBody(rr.Body, rr.BodyEncoding)
+	return err == nil && bytes.Equal(recordedBody, redactBody(req.Header, body, redactedQuery))
+}
+
+// redactHeader returns a copy of header, the values of keys are replaced.
+func redactHeader(header http.Header, keys []string) http.Header {
+	header = header.Clone()
+	for _, key := range keys {
+		if header.Get(key) != "" {
+			header.Set(key, redactedValue)
+		}
+	}
+	return header
+}
+
+// redactURL returns a copy of u, the values of query parameters in keys are replaced, keys are case-insensitive.
+func redactURL(u *url.URL, keys []string) *url.URL {
+	query := u.Query()
+
+	result := *u
+	if redactValues(query, keys) {
+		result.RawQuery = query.Encode()
+	}
+	return &result
+}
+
+// redactBody returns the form body whose values of fields in keys are replaced, other bodies are returned as is.
+func redactBody(header http.Header, body []byte, keys []string) []byte {
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	if mediaType != "application/x-www-form-urlencoded" || len(body) == 0 {
+		return body
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil || !redactValues(form, keys) {
+		return body
+	}
+	return []byte(form.Encode())
+}
+
+// redactValues replaces the values of keys, keys are case-insensitive. It returns true if any value is replaced.
+func redactValues(values url.Values, keys []string) bool {
+	redacted := false
+	for name, items := range values {
+		for _, key := range keys {
+			if strings.EqualFold(name, key) {
+				for i := range items {
+					items[i] = redactedValue
+				}
+				redacted = true
+			}
+		}
+	}
+	return redacted
+}
+
+func encodeBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+func decodeBody(body, encoding string) ([]byte, error) {
+	if encoding == "base64" {
+		return base64.StdEncoding.DecodeString(body)
+	}
+	return []byte(body), nil
+}
@@ -0,0 +1,145 @@
+package httpmock
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRecorder")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/binary":
+			w.Write([]byte{0xff, 0x00, 0xfe})
+		default:
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "cookie-secret"})
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"user":"` + r.URL.Query().Get("name") + `"}`))
+		}
+	}))
+
+	path := filepath.Join(t.TempDir(), "fixtures", "users.json")
+
+	recorder, err := NewRecorder(path, ModeAuto, nil)
+	assert.IsNil(err)
+	assert.Equal(ModeRecord, recorder.Mode())
+
+	client := &http.Client{Transport: recorder}
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL+"/users?name=lancet&id=1&access_token=token-secret", strings.NewReader(`{"age":1}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := client.Do(req)
+	assert.IsNil(err)
+	assert.Equal(`{"user":"lancet"}`, readAll(resp))
+
+	resp, err = client.Get(server.URL + "/binary")
+	assert.IsNil(err)
+	assert.Equal("\xff\x00\xfe", readAll(resp))
+
+	// the secret fields of form body are redacted.
+	resp, err = client.PostForm(server.URL+"/token", url.Values{
+		"grant_type": {"password"}, "password": {"hunter2"}, "client_secret": {"s3cr3t"},
+	})
+	assert.IsNil(err)
+	readAll(resp)
+
+	assert.IsNil(recorder.Save())
+	server.Close()
+
+	data, err := os.ReadFile(path)
+	assert.IsNil(err)
+	assert.Equal(false, strings.Contains(string(data), "-secret"))
+	assert.Equal(false, strings.Contains(string(data), "Bearer secret"))
+	assert.Equal(false, strings.Contains(string(data), "hunter2"))
+	assert.Equal(false, strings.Contains(string(data), "s3cr3t"))
+	assert.Equal(true, strings.Contains(string(data), "access_token=REDACTED"))
+	assert.Equal(true, strings.Contains(string(data), `"body_encoding": "base64"`))
+
+	// the server is closed, the responses are replayed from fixture file.
+	recorder, err = NewRecorder(path, ModeAuto, nil)
+	assert.IsNil(err)
+	assert.Equal(ModeReplay, recorder.Mode())
+	assert.Equal(3, len(recorder.Interactions()))
+
+	client = &http.Client{Transport: recorder}
+
+	resp, err = client.Get(server.URL + "/binary")
+	assert.IsNil(err)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal("\xff\x00\xfe", readAll(resp))
+
+	// the order of query parameters is ignored, but the body should be the same.
+	_, err = client.Post(server.URL+"/users?id=1&name=lancet&access_token=other", "application/json", strings.NewReader(`{"age":2}`))
+	assert.Equal(true, errors.Is(err, ErrNoInteraction))
+
+	// the values of redacted query parameters are ignored.
+	resp, err = client.Post(server.URL+"/users?id=1&name=lancet&access_token=other", "application/json", strings.NewReader(`{"age":1}`))
+	assert.IsNil(err)
+	assert.Equal("application/json", resp.Header.Get("Content-Type"))
+	assert.Equal("REDACTED", resp.Header.Get("Set-Cookie"))
+	assert.Equal(`{"user":"lancet"}`, readAll(resp))
+
+	resp, err = client.PostForm(server.URL+"/token", url.Values{
+		"grant_type": {"password"}, "password": {"other"}, "client_secret": {"other"},
+	})
+	assert.IsNil(err)
+	assert.Equal(200, resp.StatusCode)
+	readAll(resp)
+
+	_, err = client.PostForm(server.URL+"/token", url.Values{"grant_type": {"client_credentials"}})
+	assert.Equal(true, errors.Is(err, ErrNoInteraction))
+
+	// every interaction is replayed once.
+	_, err = client.Get(server.URL + "/binary")
+	assert.Equal(true, errors.Is(err, ErrNoInteraction))
+}
+
+func TestRecorder_WithTransport(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRecorder_WithTransport")
+
+	_, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), ModeReplay, nil)
+	assert.IsNotNil(err)
+
+	invalid := filepath.Join(t.TempDir(), "invalid.json")
+	assert.IsNil(os.WriteFile(invalid, []byte("{"), 0644))
+	_, err = NewRecorder(invalid, ModeReplay, nil)
+	assert.IsNotNil(err)
+
+	// the unmatched requests of mock transport are sent by recorder.
+	backend := NewTransport()
+	backend.On("GET", "/remote").RespondString(200, "remote")
+
+	recorder, err := NewRecorder(filepath.Join(t.TempDir(), "remote.json"), ModeRecord, backend)
+	assert.IsNil(err)
+
+	mock := NewTransport().Fallback(recorder)
+	mock.On("GET", "/local").RespondString(200, "local")
+
+	client := mock.Client()
+
+	resp, err := client.Get("http://localhost/local")
+	assert.IsNil(err)
+	assert.Equal("local", readAll(resp))
+
+	resp, err = client.Get("http://localhost/remote")
+	assert.IsNil(err)
+	assert.Equal("remote", readAll(resp))
+
+	interactions := recorder.Interactions()
+	assert.Equal(1, len(interactions))
+	assert.Equal("http://localhost/remote", interactions[0].Request.URL)
+	assert.Equal("remote", interactions[0].Response.Body)
+}
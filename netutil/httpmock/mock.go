@@ -0,0 +1,616 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license.
+
+// Package httpmock implements a mock http.RoundTripper for testing code which sends http requests.
+// Requests are responded by registered routes or recorded fixture files, no real server is needed.
+package httpmock
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/duke-git/lancet/v2/slice"
+)
+
+// ErrNoRoute is returned by Transport if a request matches no route and there is no fallback transport.
+var ErrNoRoute = errors.New("httpmock: no route matches request")
+
+// Transport is a http.RoundTripper which responds requests by registered routes, every request is recorded
+// as a Call. It's safe for concurrent use.
+type Transport struct {
+	mu       sync.Mutex
+	routes   []*Route
+	calls    []*Call
+	fallback http.RoundTripper
+}
+
+// NewTransport returns a mock transport without routes.
+func NewTransport() *Transport {
+	return &Transport{}
+}
+
+// On registers a route of method and url pattern, routes are matched in the order of registration.
+// Method "" or "*" matches all methods. The pattern can contain named parameters and a trailing wildcard,
+// eg: "/users/{id}", "/static/*". The scheme and host are also matched if pattern is an absolute url,
+// eg: "https://api.example.com/users/{id}".
+func (t *Transport) On(method, pattern string) *Route {
+	route := &Route{
+		method:  normalizeMethod(method),
+		pattern: parsePattern(pattern),
+		header:  make(http.Header),
+		mu:      &t.mu,
+	}
+
+	t.mu.Lock()
+	t.routes = append(t.routes, route)
+	t.mu.Unlock()
+
+	return route
+}
+
+// Fallback sets the transport which sends the requests matching no route, eg: http.DefaultTransport or Recorder.
+func (t *Transport) Fallback(transport http.RoundTripper) *Transport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.fallback = transport
+	return t
+}
+
+// Client returns a http client which sends requests by the mock transport.
+func (t *Transport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// Install replaces http.DefaultTransport with the mock transport, so requests sent by http.DefaultClient
+// and netutil.HttpGet etc. are mocked. The returned function restores http.DefaultTransport.
+// It affects the whole process, so it should not be used in parallel tests.
+func (t *Transport) Install() (restore func()) {
+	original := http.DefaultTransport
+	http.DefaultTransport = t
+
+	return func() {
+		http.DefaultTransport = original
+	}
+}
+
+// Reset removes all routes and calls.
+func (t *Transport) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.routes = nil
+	t.calls = nil
+}
+
+// RoundTrip implements http.RoundTripper interface.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	call := &Call{Request: req, Body: body, Time: time.Now()}
+
+	t.mu.Lock()
+	route := t.match(call)
+	t.calls = append(t.calls, call)
+	fallback := t.fallback
+	t.mu.Unlock()
+
+	if route != nil {
+		return route.respond(call)
+	}
+
+	if fallback != nil {
+		r := req.Clone(req.Context())
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		return fallback.RoundTrip(r)
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoRoute, req.Method, req.URL)
+}
+
+// match returns the first available route which matches the call, the caller should hold the lock.
+func (t *Transport) match(call *Call) *Route {
+	for _, route := range t.routes {
+		if route.limit > 0 && route.hits >= route.limit {
+			continue
+		}
+
+		params, ok := route.matchURL(call.Request)
+		if !ok {
+			continue
+		}
+		call.Params = params
+
+		if route.matchAll(call) {
+			route.hits++
+			call.route = route
+			return route
+		}
+	}
+
+	call.Params = nil
+	return nil
+}
+
+// Calls returns all recorded calls in the order of requests.
+func (t *Transport) Calls() []*Call {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]*Call{}, t.calls...)
+}
+
+// CallsTo returns the recorded calls of method and url pattern, see On for the format of pattern.
+func (t *Transport) CallsTo(method, pattern string) []*Call {
+	route := &Route{method: normalizeMethod(method), pattern: parsePattern(pattern)}
+
+	result := []*Call{}
+	for _, call := range t.Calls() {
+		if _, ok := route.matchURL(call.Request); ok {
+			result = append(result, call)
+		}
+	}
+
+	return result
+}
+
+// AssertCalled checks if method and url pattern is requested at least once.
+func (t *Transport) AssertCalled(tb testing.TB, method, pattern string) bool {
+	tb.Helper()
+
+	if len(t.CallsTo(method, pattern)) == 0 {
+		tb.Errorf("httpmock: expected %s %s to be called, but it's not called", method, pattern)
+		return false
+	}
+	return true
+}
+
+// AssertNotCalled checks if method and url pattern is never requested.
+func (t *Transport) AssertNotCalled(tb testing.TB, method, pattern string) bool {
+	tb.Helper()
+
+	if count := len(t.CallsTo(method, pattern)); count > 0 {
+		tb.Errorf("httpmock: expected %s %s not to be called, but it's called %d times", method, pattern, count)
+		return false
+	}
+	return true
+}
+
+// AssertCallCount checks if method and url pattern is requested count times.
+func (t *Transport) AssertCallCount(tb testing.TB, method, pattern string, count int) bool {
+	tb.Helper()
+
+	if actual := len(t.CallsTo(method, pattern)); actual != count {
+		tb.Errorf("httpmock: expected %s %s to be called %d times, but it's called %d times", method, pattern, count, actual)
+		return false
+	}
+	return true
+}
+
+// AssertAllRoutesCalled checks if every route is matched at least once.
+func (t *Transport) AssertAllRoutesCalled(tb testing.TB) bool {
+	tb.Helper()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ok := true
+	for _, route := range t.routes {
+		if route.hits == 0 {
+			tb.Errorf("httpmock: route %s %s is not called", route.method, route.pattern.raw)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// AssertNoUnmatchedCalls checks if every request matches a route.
+func (t *Transport) AssertNoUnmatchedCalls(tb testing.TB) bool {
+	tb.Helper()
+
+	ok := true
+	for _, call := range t.Calls() {
+		if !call.Matched() {
+			tb.Errorf("httpmock: request %s %s matches no route", call.Request.Method, call.Request.URL)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// Call is a request recorded by Transport.
+type Call struct {
+	Request *http.Request
+	// Body is the request body, the body of Request has been read.
+	Body []byte
+	// Params is the named parameters and wildcard ("*") in url pattern of the matched route.
+	Params map[string]string
+	Time   time.Time
+
+	route *Route
+}
+
+// Matched checks if the call matches a route.
+func (c *Call) Matched() bool {
+	return c.route != nil
+}
+
+// Query returns the first value of query parameter.
+func (c *Call) Query(key string) string {
+	return c.Request.URL.Query().Get(key)
+}
+
+// Header returns the first value of request header.
+func (c *Call) Header(key string) string {
+	return c.Request.Header.Get(key)
+}
+
+// DecodeJSON decodes the json request body into v.
+func (c *Call) DecodeJSON(v any) error {
+	return json.Unmarshal(c.Body, v)
+}
+
+// BodyJSON returns the json request body decoded into any, nil is returned if the body isn't valid json.
+// It's useful in response template, eg: `{{index .BodyJSON "name"}}`.
+func (c *Call) BodyJSON() any {
+	var v any
+	if err := c.DecodeJSON(&v); err != nil {
+		return nil
+	}
+	return v
+}
+
+// Matcher checks if a call matches a route.
+type Matcher func(call *Call) bool
+
+// Responder creates the response of a call.
+type Responder func(call *Call) (*http.Response, error)
+
+// Route responds the matched requests, it's created by Transport.On.
+// The methods of Route should be called before sending requests.
+type Route struct {
+	method    string
+	pattern   *pattern
+	matchers  []Matcher
+	responder Responder
+	header    http.Header
+	limit     int
+
+	// mu is the lock of transport, which guards hits.
+	mu   *sync.Mutex
+	hits int
+}
+
+// WithQuery matches the requests which have query parameter key with value.
+func (r *Route) WithQuery(key, value string) *Route {
+	return r.Match(func(call *Call) bool {
+		return slice.Contain(call.Request.URL.Query()[key], value)
+	})
+}
+
+// WithHeader matches the requests which have header key with value.
+func (r *Route) WithHeader(key, value string) *Route {
+	return r.Match(func(call *Call) bool {
+		return slice.Contain(call.Request.Header.Values(key), value)
+	})
+}
+
+// WithBody matches the requests whose body is equal to body.
+func (r *Route) WithBody(body string) *Route {
+	return r.Match(func(call *Call) bool {
+		return string(call.Body) == body
+	})
+}
+
+// WithJSONBody matches the requests whose json body is equal to the json of v, the order of
+// object keys and white spaces are ignored. If v is string or []byte, it's used as json directly.
+func (r *Route) WithJSONBody(v any) *Route {
+	expected, err := normalizeJSON(v)
+
+	return r.Match(func(call *Call) bool {
+		if err != nil {
+			return false
+		}
+		actual := call.BodyJSON()
+		return actual != nil && reflect.DeepEqual(expected, actual)
+	})
+}
+
+// Match matches the requests by custom matchers, all matchers should be passed.
+func (r *Route) Match(matchers ...Matcher) *Route {
+	r.matchers = append(r.matchers, matchers...)
+	return r
+}
+
+// Times limits the route to respond at most n requests, the later requests are matched by other routes.
+// eg: respond 503 to the first request, and 200 to the others.
+//
+//	mock.On("GET", "/ping").Times(1).RespondString(503, "unavailable")
+//	mock.On("GET", "/ping").RespondString(200, "pong")
+func (r *Route) Times(n int) *Route {
+	r.limit = n
+	return r
+}
+
+// Respond sets the responder of route, an empty 200 response is returned if it's not set.
+func (r *Route) Respond(responder Responder) *Route {
+	r.responder = responder
+	return r
+}
+
+// RespondString responds with status and body.
+func (r *Route) RespondString(status int, body string) *Route {
+	return r.Respond(StringResponse(status, body))
+}
+
+// RespondJSON responds with status and json of v.
+func (r *Route) RespondJSON(status int, v any) *Route {
+	return r.Respond(JSONResponse(status, v))
+}
+
+// RespondTemplate responds with status and body rendered by text/template, see TemplateResponse.
+func (r *Route) RespondTemplate(status int, tmpl string) *Route {
+	return r.Respond(TemplateResponse(status, tmpl))
+}
+
+// RespondError returns err instead of response, eg: simulate network errors.
+func (r *Route) RespondError(err error) *Route {
+	return r.Respond(ErrorResponse(err))
+}
+
+// SetResponseHeader sets a header of response.
+func (r *Route) SetResponseHeader(key, value string) *Route {
+	r.header.Set(key, value)
+	return r
+}
+
+// CallCount returns the number of requests responded by route.
+func (r *Route) CallCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.hits
+}
+
+func (r *Route) matchURL(req *http.Request) (map[string]string, bool) {
+	if r.method != "*" && r.method != req.Method {
+		return nil, false
+	}
+	return r.pattern.match(req.URL)
+}
+
+func (r *Route) matchAll(call *Call) bool {
+	for _, matcher := range r.matchers {
+		if !matcher(call) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Route) respond(call *Call) (*http.Response, error) {
+	responder := r.responder
+	if responder == nil {
+		responder = StringResponse(http.StatusOK, "")
+	}
+
+	resp, err := responder(call)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("httpmock: responder of %s %s returns nil response", call.Request.Method, call.Request.URL)
+	}
+	if resp.Header == nil {
+		resp.Header = make(http.Header)
+	}
+
+	for k, v := range r.header {
+		resp.Header[k] = v
+	}
+	resp.Request = call.Request
+
+	return resp, nil
+}
+
+// NewResponse returns a response with status, header and body.
+func NewResponse(status int, header http.Header, body []byte) *http.Response {
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+}
+
+// StringResponse returns a responder which responds with status and body.
+func StringResponse(status int, body string) Responder {
+	return func(call *Call) (*http.Response, error) {
+		header := make(http.Header)
+		if body != "" {
+			header.Set("Content-Type", "text/plain; charset=utf-8")
+		}
+		return NewResponse(status, header, []byte(body)), nil
+	}
+}
+
+// JSONResponse returns a responder which responds with status and json of v.
+func JSONResponse(status int, v any) Responder {
+	body, err := json.Marshal(v)
+
+	return func(call *Call) (*http.Response, error) {
+		if err != nil {
+			return nil, err
+		}
+		header := http.Header{"Content-Type": {"application/json"}}
+		return NewResponse(status, header, body), nil
+	}
+}
+
+// TemplateResponse returns a responder which renders body by text/template with the Call as data,
+// eg: `{"id": "{{.Params.id}}", "page": "{{.Query "page"}}"}`. The template function `json` encodes
+// a value to json. The Content-Type is application/json if the body is valid json, otherwise text/plain.
+// It panics if tmpl is invalid.
+func TemplateResponse(status int, tmpl string) Responder {
+	t := template.Must(template.New("response").Funcs(template.FuncMap{
+		"json": func(v any) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}).Parse(tmpl))
+
+	return func(call *Call) (*http.Response, error) {
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, call); err != nil {
+			return nil, err
+		}
+
+		header := make(http.Header)
+		if json.Valid(buf.Bytes()) {
+			header.Set("Content-Type", "application/json")
+		} else {
+			header.Set("Content-Type", "text/plain; charset=utf-8")
+		}
+
+		return NewResponse(status, header, buf.Bytes()), nil
+	}
+}
+
+// ErrorResponse returns a responder which returns err.
+func ErrorResponse(err error) Responder {
+	return func(call *Call) (*http.Response, error) {
+		return nil, err
+	}
+}
+
+// pattern is the parsed url pattern of route.
+type pattern struct {
+	raw      string
+	scheme   string
+	host     string
+	segments []string
+	// wildcard matches the rest of path.
+	wildcard bool
+}
+
+func parsePattern(raw string) *pattern {
+	p := &pattern{raw: raw}
+
+	path := raw
+	if scheme, rest, found := strings.Cut(raw, "://"); found {
+		p.scheme = strings.ToLower(scheme)
+		host, rest, _ := strings.Cut(rest, "/")
+		p.host = strings.ToLower(host)
+		path = "/" + rest
+	}
+
+	p.segments = splitPath(path)
+	if n := len(p.segments); n > 0 && p.segments[n-1] == "*" {
+		p.segments = p.segments[:n-1]
+		p.wildcard = true
+	}
+	if raw == "" {
+		p.wildcard = true
+	}
+
+	return p
+}
+
+// match checks if u matches the pattern, and returns the named parameters.
+func (p *pattern) match(u *url.URL) (map[string]string, bool) {
+	if p.scheme != "" && p.scheme != strings.ToLower(u.Scheme) {
+		return nil, false
+	}
+	if p.host != "" && p.host != strings.ToLower(u.Host) {
+		return nil, false
+	}
+
+	segments := splitPath(u.Path)
+	if len(segments) < len(p.segments) || (!p.wildcard && len(segments) != len(p.segments)) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, segment := range p.segments {
+		switch {
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			params[segment[1:len(segment)-1]] = segments[i]
+		case segment == "*":
+		case segment != segments[i]:
+			return nil, false
+		}
+	}
+	if p.wildcard {
+		params["*"] = strings.Join(segments[len(p.segments):], "/")
+	}
+
+	return params, true
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+func normalizeMethod(method string) string {
+	if method == "" {
+		return "*"
+	}
+	return strings.ToUpper(method)
+}
+
+// readRequestBody reads and closes the request body.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+
+	return io.ReadAll(req.Body)
+}
+
+// normalizeJSON converts v to the value decoded from its json, so it can be compared with decoded body.
+func normalizeJSON(v any) (any, error) {
+	var data []byte
+	switch value := v.(type) {
+	case string:
+		data = []byte(value)
+	case []byte:
+		data = value
+	default:
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+
+	var result any
+	err := json.Unmarshal(data, &result)
+
+	return result, err
+}
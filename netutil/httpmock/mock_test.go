@@ -0,0 +1,262 @@
+package httpmock
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+	"github.com/duke-git/lancet/v2/netutil"
+)
+
+type user struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func readAll(resp *http.Response) string {
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func TestTransport_Routes(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestTransport_Routes")
+
+	mock := NewTransport()
+	mock.On("GET", "/users/{id}").WithHeader("Authorization", "Bearer token").RespondJSON(200, user{ID: 1, Name: "lancet"})
+	mock.On("GET", "/users/{id}").RespondString(401, "unauthorized")
+	mock.On("*", "https://api.example.com/static/*").SetResponseHeader("Cache-Control", "no-cache")
+
+	client := mock.Client()
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/users/1", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	resp, err := client.Do(req)
+	assert.IsNil(err)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal("application/json", resp.Header.Get("Content-Type"))
+	assert.Equal(`{"id":1,"name":"lancet"}`, readAll(resp))
+
+	resp, err = client.Get("http://localhost/users/1")
+	assert.IsNil(err)
+	assert.Equal(401, resp.StatusCode)
+	assert.Equal("unauthorized", readAll(resp))
+
+	resp, err = client.Head("https://api.example.com/static/js/app.js")
+	assert.IsNil(err)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal("no-cache", resp.Header.Get("Cache-Control"))
+
+	// the host of absolute pattern doesn't match.
+	_, err = client.Get("https://example.com/static/app.js")
+	assert.IsNotNil(err)
+	assert.Equal(true, errors.Is(err, ErrNoRoute))
+
+	_, err = client.Post("http://localhost/users/1/2", "", nil)
+	assert.Equal(true, errors.Is(err, ErrNoRoute))
+
+	calls := mock.Calls()
+	assert.Equal(5, len(calls))
+	assert.Equal(map[string]string{"id": "1"}, calls[0].Params)
+	assert.Equal(map[string]string{"*": "js/app.js"}, calls[2].Params)
+	assert.Equal(true, calls[2].Matched())
+	assert.Equal(false, calls[3].Matched())
+}
+
+func TestTransport_Matchers(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestTransport_Matchers")
+
+	mock := NewTransport()
+	created := mock.On("POST", "/users").WithJSONBody(map[string]any{"name": "lancet", "tags": []string{"go"}}).
+		RespondJSON(201, user{ID: 2, Name: "lancet"})
+	mock.On("POST", "/users").WithJSONBody(`{"name": ""}`).RespondString(400, "name is required")
+	mock.On("GET", "/users").WithQuery("page", "2").WithQuery("size", "10").RespondJSON(200, []user{})
+	mock.On("GET", "/search").Match(func(call *Call) bool {
+		return strings.HasPrefix(call.Query("q"), "lan")
+	}).RespondString(200, "found")
+
+	client := mock.Client()
+
+	resp, err := client.Post("http://localhost/users", "application/json",
+		strings.NewReader(`{ "tags": ["go"], "name": "lancet" }`))
+	assert.IsNil(err)
+	assert.Equal(201, resp.StatusCode)
+	assert.Equal(1, created.CallCount())
+
+	resp, err = client.Post("http://localhost/users", "application/json", strings.NewReader(`{"name":""}`))
+	assert.IsNil(err)
+	assert.Equal(400, resp.StatusCode)
+
+	_, err = client.Post("http://localhost/users", "application/json", strings.NewReader(`not json`))
+	assert.Equal(true, errors.Is(err, ErrNoRoute))
+
+	resp, err = client.Get("http://localhost/users?size=10&page=2")
+	assert.IsNil(err)
+	assert.Equal("[]", readAll(resp))
+
+	_, err = client.Get("http://localhost/users?page=3&size=10")
+	assert.Equal(true, errors.Is(err, ErrNoRoute))
+
+	resp, err = client.Get("http://localhost/search?q=lancet")
+	assert.IsNil(err)
+	assert.Equal("found", readAll(resp))
+
+	var body map[string]any
+	assert.IsNil(mock.Calls()[0].DecodeJSON(&body))
+	assert.Equal("lancet", body["name"])
+}
+
+func TestTransport_Responders(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestTransport_Responders")
+
+	mock := NewTransport()
+	mock.On("GET", "/ping").Times(2).RespondString(503, "unavailable")
+	mock.On("GET", "/ping").RespondString(200, "pong")
+	mock.On("POST", "/users/{id}").RespondTemplate(200,
+		`{"id":{{.Params.id}},"name":{{json (index .BodyJSON "name")}},"page":"{{.Query "page"}}"}`)
+	mock.On("GET", "/greet").RespondTemplate(200, `hello {{.Header "X-Name"}}`)
+	mock.On("GET", "/broken").RespondError(io.ErrUnexpectedEOF)
+
+	client := mock.Client()
+
+	statuses := []int{}
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get("http://localhost/ping")
+		assert.IsNil(err)
+		readAll(resp)
+		statuses = append(statuses, resp.StatusCode)
+	}
+	assert.Equal([]int{503, 503, 200}, statuses)
+
+	resp, err := client.Post("http://localhost/users/7?page=3", "application/json", strings.NewReader(`{"name":"lancet"}`))
+	assert.IsNil(err)
+	assert.Equal("application/json", resp.Header.Get("Content-Type"))
+	assert.Equal(`{"id":7,"name":"lancet","page":"3"}`, readAll(resp))
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/greet", nil)
+	req.Header.Set("X-Name", "lancet")
+	resp, err = client.Do(req)
+	assert.IsNil(err)
+	assert.Equal("text/plain; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Equal("hello lancet", readAll(resp))
+
+	_, err = client.Get("http://localhost/broken")
+	assert.Equal(true, errors.Is(err, io.ErrUnexpectedEOF))
+
+	// custom responder may return response without header.
+	mock.On("GET", "/raw").SetResponseHeader("X-Mock", "1").Respond(func(call *Call) (*http.Response, error) {
+		return &http.Response{StatusCode: 204, Body: http.NoBody}, nil
+	})
+	mock.On("GET", "/nil").Respond(func(call *Call) (*http.Response, error) {
+		return nil, nil
+	})
+
+	resp, err = client.Get("http://localhost/raw")
+	assert.IsNil(err)
+	assert.Equal(204, resp.StatusCode)
+	assert.Equal("1", resp.Header.Get("X-Mock"))
+
+	_, err = client.Get("http://localhost/nil")
+	assert.IsNotNil(err)
+}
+
+func TestTransport_Assertions(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestTransport_Assertions")
+
+	mock := NewTransport()
+	mock.On("GET", "/users/{id}").RespondJSON(200, user{ID: 1})
+	mock.On("DELETE", "/users/{id}")
+
+	client := netutil.NewHttpClient().SetTransport(mock)
+	result, err := netutil.Do[user](client.R(), http.MethodGet, "http://localhost/users/1")
+	assert.IsNil(err)
+	assert.Equal(user{ID: 1}, result)
+
+	_, err = client.R().Get("http://localhost/users/2")
+	assert.IsNil(err)
+
+	assert.Equal(true, mock.AssertCalled(t, "GET", "/users/{id}"))
+	assert.Equal(true, mock.AssertCallCount(t, "GET", "/users/*", 2))
+	assert.Equal(true, mock.AssertCallCount(t, "", "/users/2", 1))
+	assert.Equal(true, mock.AssertNotCalled(t, "DELETE", "/users/{id}"))
+	assert.Equal(true, mock.AssertNoUnmatchedCalls(t))
+
+	// the failures are reported to the fake testing.TB.
+	fake := &fakeTB{TB: t}
+	assert.Equal(false, mock.AssertAllRoutesCalled(fake))
+	assert.Equal(false, mock.AssertCalled(fake, "POST", "/users"))
+	assert.Equal(false, mock.AssertCallCount(fake, "GET", "/users/{id}", 1))
+	assert.Equal(3, len(fake.errors))
+	assert.Equal("httpmock: route DELETE /users/{id} is not called", fake.errors[0])
+
+	mock.Reset()
+	assert.Equal(0, len(mock.Calls()))
+	assert.Equal(true, mock.AssertAllRoutesCalled(t))
+}
+
+type fakeTB struct {
+	testing.TB
+	errors []string
+}
+
+func (tb *fakeTB) Helper() {}
+
+func (tb *fakeTB) Errorf(format string, args ...any) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func TestTransport_Install(t *testing.T) {
+	assert := internal.NewAssert(t, "TestTransport_Install")
+
+	mock := NewTransport()
+	mock.On("GET", "/ip").RespondJSON(200, map[string]string{"ip": "10.0.0.1"})
+
+	restore := mock.Install()
+	defer restore()
+
+	resp, err := netutil.HttpGet("http://localhost/ip")
+	assert.IsNil(err)
+
+	var result map[string]string
+	assert.IsNil(netutil.ParseHttpResponse(resp, &result))
+	assert.Equal("10.0.0.1", result["ip"])
+
+	mock.AssertCallCount(t, "GET", "/ip", 1)
+}
+
+func TestTransport_Fallback(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestTransport_Fallback")
+
+	fallback := NewTransport()
+	fallback.On("GET", "/*").RespondString(200, "fallback")
+
+	mock := NewTransport().Fallback(fallback)
+	mock.On("GET", "/mocked").RespondString(200, "mocked")
+
+	client := mock.Client()
+
+	resp, err := client.Get("http://localhost/mocked")
+	assert.IsNil(err)
+	assert.Equal("mocked", readAll(resp))
+
+	resp, err = client.Get("http://localhost/other")
+	assert.IsNil(err)
+	assert.Equal("fallback", readAll(resp))
+
+	mock.AssertCallCount(t, "GET", "/*", 2)
+	fallback.AssertCallCount(t, "GET", "/other", 1)
+}
@@ -0,0 +1,595 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license.
+
+package netutil
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/duke-git/lancet/v2/iterator"
+)
+
+// maxSplitPrefixDiff limits the number of subnets returned by SplitCIDR to 2^16.
+const maxSplitPrefixDiff = 16
+
+// ParseCIDR parses CIDR notation, eg: "192.168.0.0/16", "2001:db8::/32". A single ip is parsed as a host
+// network, eg: "10.0.0.1" is "10.0.0.1/32". The host bits are cleared, eg: "10.1.2.3/8" is "10.0.0.0/8".
+func ParseCIDR(s string) (*net.IPNet, error) {
+	s = strings.TrimSpace(s)
+
+	if !strings.Contains(s, "/") {
+		ip := normalizeIP(net.ParseIP(s))
+		if ip == nil {
+			return nil, fmt.Errorf("invalid ip: %q", s)
+		}
+		bits := 8 * len(ip)
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, ipNet, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return ipNet, nil
+}
+
+// CIDRContains checks if subnet is inside of network, both of them should be in the same ip family.
+func CIDRContains(network, subnet *net.IPNet) bool {
+	netIP, netOnes, bits, ok := cidrOf(network)
+	if !ok {
+		return false
+	}
+	subIP, subOnes, subBits, ok := cidrOf(subnet)
+	if !ok || bits != subBits || subOnes < netOnes {
+		return false
+	}
+
+	return netIP.Equal(maskIP(subIP, netOnes))
+}
+
+// CIDROverlaps checks if the two networks have common ips.
+func CIDROverlaps(a, b *net.IPNet) bool {
+	return CIDRContains(a, b) || CIDRContains(b, a)
+}
+
+// SplitCIDR splits network into subnets with prefix length, eg: split "10.0.0.0/16" by 18 returns 4 subnets.
+// At most 2^16 subnets can be returned.
+func SplitCIDR(ipNet *net.IPNet, prefixLen int) ([]*net.IPNet, error) {
+	ip, ones, bits, ok := cidrOf(ipNet)
+	if !ok {
+		return nil, fmt.Errorf("invalid CIDR: %v", ipNet)
+	}
+	if prefixLen < ones || prefixLen > bits {
+		return nil, fmt.Errorf("prefix length %d should be in range [%d, %d]", prefixLen, ones, bits)
+	}
+	if prefixLen-ones > maxSplitPrefixDiff {
+		return nil, fmt.Errorf("too many subnets: 2^%d", prefixLen-ones)
+	}
+
+	count := 1 << (prefixLen - ones)
+	step := new(big.Int).Lsh(big.NewInt(1), uint(bits-prefixLen))
+	n := ipToInt(ip)
+
+	result := make([]*net.IPNet, 0, count)
+	for i := 0; i < count; i++ {
+		result = append(result, &net.IPNet{IP: intToIP(n, len(ip)), Mask: net.CIDRMask(prefixLen, bits)})
+		n.Add(n, step)
+	}
+
+	return result, nil
+}
+
+// AggregateCIDRs merges overlapped and adjacent networks into the minimal list of networks (supernetting),
+// eg: "10.0.0.0/25" and "10.0.0.128/25" are merged to "10.0.0.0/24". IPv4 networks are sorted before IPv6.
+func AggregateCIDRs(ipNets ...*net.IPNet) ([]*net.IPNet, error) {
+	set, err := NewIPSet(ipNets...)
+	if err != nil {
+		return nil, err
+	}
+	return set.CIDRs(), nil
+}
+
+// IPv4ToUint32 converts IPv4 to integer, eg: "1.2.3.4" is 0x01020304.
+func IPv4ToUint32(ip net.IP) (uint32, error) {
+	ip4 := ip.To4()
+	if ip4 == nil {
+		return 0, fmt.Errorf("invalid ipv4: %v", ip)
+	}
+	return binary.BigEndian.Uint32(ip4), nil
+}
+
+// Uint32ToIPv4 converts integer to IPv4.
+func Uint32ToIPv4(n uint32) net.IP {
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, n)
+	return ip
+}
+
+// IPToBigInt converts ip to integer, IPv4-mapped IPv6 is converted as IPv4.
+func IPToBigInt(ip net.IP) (*big.Int, error) {
+	normalized := normalizeIP(ip)
+	if normalized == nil {
+		return nil, fmt.Errorf("invalid ip: %v", ip)
+	}
+	return ipToInt(normalized), nil
+}
+
+// BigIntToIP converts integer to IPv6 if ipv6 is true, otherwise IPv4.
+func BigIntToIP(n *big.Int, ipv6 bool) (net.IP, error) {
+	size := net.IPv4len
+	if ipv6 {
+		size = net.IPv6len
+	}
+	if n == nil || n.Sign() < 0 || n.BitLen() > 8*size {
+		return nil, fmt.Errorf("integer %v is out of ip range", n)
+	}
+	return intToIP(n, size), nil
+}
+
+// IPRange is an inclusive range of ips, Start and End should be in the same ip family.
+type IPRange struct {
+	Start net.IP
+	End   net.IP
+}
+
+// NewIPRange returns the range from start to end, start should not be greater than end.
+func NewIPRange(start, end net.IP) (*IPRange, error) {
+	startIP, endIP := normalizeIP(start), normalizeIP(end)
+	if startIP == nil || endIP == nil {
+		return nil, fmt.Errorf("invalid ip range: %v-%v", start, end)
+	}
+	if len(startIP) != len(endIP) {
+		return nil, errors.New("start and end ip should be in the same ip family")
+	}
+	if bytes.Compare(startIP, endIP) > 0 {
+		return nil, fmt.Errorf("start ip %v is greater than end ip %v", start, end)
+	}
+
+	return &IPRange{Start: startIP, End: endIP}, nil
+}
+
+// ParseIPRange parses ip range, eg: "10.0.0.1-10.0.0.100", CIDR notation and single ip are also supported.
+func ParseIPRange(s string) (*IPRange, error) {
+	if start, end, found := strings.Cut(s, "-"); found {
+		startIP := net.ParseIP(strings.TrimSpace(start))
+		endIP := net.ParseIP(strings.TrimSpace(end))
+		if startIP == nil || endIP == nil {
+			return nil, fmt.Errorf("invalid ip range: %q", s)
+		}
+		return NewIPRange(startIP, endIP)
+	}
+
+	ipNet, err := ParseCIDR(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return CIDRToRange(ipNet)
+}
+
+// CIDRToRange returns the range from the first to the last ip of network.
+func CIDRToRange(ipNet *net.IPNet) (*IPRange, error) {
+	ip, ones, bits, ok := cidrOf(ipNet)
+	if !ok {
+		return nil, fmt.Errorf("invalid CIDR: %v", ipNet)
+	}
+
+	last := make(net.IP, len(ip))
+	mask := net.CIDRMask(ones, bits)
+	for i := range ip {
+		last[i] = ip[i] | ^mask[i]
+	}
+
+	return &IPRange{Start: ip, End: last}, nil
+}
+
+// String returns the range in "start-end" format.
+func (r *IPRange) String() string {
+	return r.Start.String() + "-" + r.End.String()
+}
+
+// Contains checks if ip is in the range.
+func (r *IPRange) Contains(ip net.IP) bool {
+	start, end, ok := r.bounds()
+	ip = normalizeIP(ip)
+	if !ok || len(ip) != len(start) {
+		return false
+	}
+	return bytes.Compare(start, ip) <= 0 && bytes.Compare(ip, end) <= 0
+}
+
+// Size returns the number of ips in the range.
+func (r *IPRange) Size() *big.Int {
+	start, end, ok := r.bounds()
+	if !ok {
+		return big.NewInt(0)
+	}
+
+	size := new(big.Int).Sub(ipToInt(end), ipToInt(start))
+	return size.Add(size, big.NewInt(1))
+}
+
+// CIDRs returns the minimal list of networks which cover the range exactly.
+func (r *IPRange) CIDRs() []*net.IPNet {
+	start, end, ok := r.bounds()
+	if !ok {
+		return nil
+	}
+
+	bits := 8 * len(start)
+	one := big.NewInt(1)
+	current, last := ipToInt(start), ipToInt(end)
+
+	result := []*net.IPNet{}
+	for current.Cmp(last) <= 0 {
+		// the largest block which is aligned with current ip, and doesn't exceed the end of range.
+		hostBits := bits
+		if current.Sign() != 0 {
+			hostBits = int(current.TrailingZeroBits())
+		}
+		remaining := new(big.Int).Sub(last, current)
+		if limit := remaining.Add(remaining, one).BitLen() - 1; limit < hostBits {
+			hostBits = limit
+		}
+
+		result = append(result, &net.IPNet{IP: intToIP(current, len(start)), Mask: net.CIDRMask(bits-hostBits, bits)})
+		current.Add(current, new(big.Int).Lsh(one, uint(hostBits)))
+	}
+
+	return result
+}
+
+// Iterator returns an iterator of ips in the range, in ascending order.
+func (r *IPRange) Iterator() *IPIterator {
+	start, end, ok := r.bounds()
+	if !ok {
+		return &IPIterator{done: true}
+	}
+	return &IPIterator{next: append(net.IP(nil), start...), end: append(net.IP(nil), end...)}
+}
+
+func (r *IPRange) bounds() (net.IP, net.IP, bool) {
+	start, end := normalizeIP(r.Start), normalizeIP(r.End)
+	if start == nil || end == nil || len(start) != len(end) || bytes.Compare(start, end) > 0 {
+		return nil, nil, false
+	}
+	return start, end, true
+}
+
+// IPIterator iterates ips of IPRange. It implements iterator.Iterator.
+type IPIterator struct {
+	next net.IP
+	end  net.IP
+	done bool
+}
+
+var _ iterator.Iterator[net.IP] = (*IPIterator)(nil)
+
+// HasNext checks if there is a next ip.
+func (iter *IPIterator) HasNext() bool {
+	return !iter.done
+}
+
+// Next returns the next ip, ok is false if the iteration is over.
+func (iter *IPIterator) Next() (net.IP, bool) {
+	if iter.done {
+		return nil, false
+	}
+
+	ip := iter.next
+	if ip.Equal(iter.end) {
+		iter.done = true
+	} else {
+		iter.next = nextIP(ip)
+	}
+
+	return ip, true
+}
+
+// IPSet is a set of ips backed by binary radix tree, the lookup time is proportional to the bit length of ip.
+// Overlapped and adjacent networks are merged when they're added. It's safe for concurrent use.
+type IPSet struct {
+	mu   sync.RWMutex
+	ipv4 *ipTreeNode
+	ipv6 *ipTreeNode
+}
+
+// ipTreeNode is a node of binary radix tree, the path from root is the network prefix of node.
+type ipTreeNode struct {
+	children [2]*ipTreeNode
+	// full means all ips of the network are in the set.
+	full bool
+}
+
+// NewIPSet returns a set with networks.
+func NewIPSet(ipNets ...*net.IPNet) (*IPSet, error) {
+	set := &IPSet{}
+	for _, ipNet := range ipNets {
+		if err := set.Add(ipNet); err != nil {
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+// ParseIPSet returns a set with items, an item is an ip, CIDR or ip range, see ParseIPRange.
+func ParseIPSet(items ...string) (*IPSet, error) {
+	set := &IPSet{}
+	for _, item := range items {
+		r, err := ParseIPRange(item)
+		if err != nil {
+			return nil, err
+		}
+		if err := set.AddRange(r); err != nil {
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+// Add adds all ips of network to the set.
+func (s *IPSet) Add(ipNet *net.IPNet) error {
+	ip, ones, _, ok := cidrOf(ipNet)
+	if !ok {
+		return fmt.Errorf("invalid CIDR: %v", ipNet)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	root := s.root(len(ip))
+	if *root == nil {
+		*root = &ipTreeNode{}
+	}
+	(*root).insert(ip, 0, ones)
+
+	return nil
+}
+
+// AddIP adds ip to the set.
+func (s *IPSet) AddIP(ip net.IP) error {
+	normalized := normalizeIP(ip)
+	if normalized == nil {
+		return fmt.Errorf("invalid ip: %v", ip)
+	}
+	bits := 8 * len(normalized)
+	return s.Add(&net.IPNet{IP: normalized, Mask: net.CIDRMask(bits, bits)})
+}
+
+// AddRange adds all ips of range to the set.
+func (s *IPSet) AddRange(r *IPRange) error {
+	if _, _, ok := r.bounds(); !ok {
+		return fmt.Errorf("invalid ip range: %v", r)
+	}
+	for _, ipNet := range r.CIDRs() {
+		if err := s.Add(ipNet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Remove removes all ips of network from the set, a larger network in the set is split if it's needed.
+func (s *IPSet) Remove(ipNet *net.IPNet) error {
+	ip, ones, _, ok := cidrOf(ipNet)
+	if !ok {
+		return fmt.Errorf("invalid CIDR: %v", ipNet)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	root := s.root(len(ip))
+	if *root != nil && (*root).remove(ip, 0, ones) {
+		*root = nil
+	}
+
+	return nil
+}
+
+// Contains checks if ip is in the set.
+func (s *IPSet) Contains(ip net.IP) bool {
+	ip = normalizeIP(ip)
+	if ip == nil {
+		return false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return (*s.root(len(ip))).contains(ip, 8*len(ip))
+}
+
+// ContainsCIDR checks if all ips of network are in the set.
+func (s *IPSet) ContainsCIDR(ipNet *net.IPNet) bool {
+	ip, ones, _, ok := cidrOf(ipNet)
+	if !ok {
+		return false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return (*s.root(len(ip))).contains(ip, ones)
+}
+
+// IsEmpty checks if the set is empty.
+func (s *IPSet) IsEmpty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.ipv4 == nil && s.ipv6 == nil
+}
+
+// CIDRs returns the minimal list of networks of the set in ascending order, IPv4 networks are before IPv6.
+func (s *IPSet) CIDRs() []*net.IPNet {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := []*net.IPNet{}
+	if s.ipv4 != nil {
+		result = s.ipv4.collect(make(net.IP, net.IPv4len), 0, result)
+	}
+	if s.ipv6 != nil {
+		result = s.ipv6.collect(make(net.IP, net.IPv6len), 0, result)
+	}
+
+	return result
+}
+
+func (s *IPSet) root(size int) **ipTreeNode {
+	if size == net.IPv4len {
+		return &s.ipv4
+	}
+	return &s.ipv6
+}
+
+// insert adds the network of ip with prefix length ones, depth is the prefix length of node.
+func (node *ipTreeNode) insert(ip net.IP, depth, ones int) {
+	if node.full {
+		return
+	}
+	if depth == ones {
+		node.full = true
+		node.children = [2]*ipTreeNode{}
+		return
+	}
+
+	bit := ipBit(ip, depth)
+	if node.children[bit] == nil {
+		node.children[bit] = &ipTreeNode{}
+	}
+	node.children[bit].insert(ip, depth+1, ones)
+
+	// merge the two halves of network.
+	if node.children[0] != nil && node.children[0].full && node.children[1] != nil && node.children[1].full {
+		node.full = true
+		node.children = [2]*ipTreeNode{}
+	}
+}
+
+// remove removes the network of ip with prefix length ones, it returns true if the node becomes empty.
+func (node *ipTreeNode) remove(ip net.IP, depth, ones int) bool {
+	if depth == ones {
+		return true
+	}
+	if node.full {
+		node.full = false
+		node.children = [2]*ipTreeNode{{full: true}, {full: true}}
+	}
+
+	bit := ipBit(ip, depth)
+	if child := node.children[bit]; child != nil && child.remove(ip, depth+1, ones) {
+		node.children[bit] = nil
+	}
+
+	return node.children[0] == nil && node.children[1] == nil
+}
+
+// contains checks if the network of ip with prefix length ones is covered by the tree.
+func (node *ipTreeNode) contains(ip net.IP, ones int) bool {
+	for depth := 0; node != nil; depth++ {
+		if node.full {
+			return true
+		}
+		if depth == ones {
+			return false
+		}
+		node = node.children[ipBit(ip, depth)]
+	}
+	return false
+}
+
+// collect appends the full networks of tree to result, prefix is the network ip of node.
+func (node *ipTreeNode) collect(prefix net.IP, depth int, result []*net.IPNet) []*net.IPNet {
+	if node.full {
+		bits := 8 * len(prefix)
+		return append(result, &net.IPNet{IP: append(net.IP(nil), prefix...), Mask: net.CIDRMask(depth, bits)})
+	}
+
+	for bit, child := range node.children {
+		if child == nil {
+			continue
+		}
+		if bit == 1 {
+			prefix[depth/8] |= 0x80 >> (depth % 8)
+		}
+		result = child.collect(prefix, depth+1, result)
+		if bit == 1 {
+			prefix[depth/8] &^= 0x80 >> (depth % 8)
+		}
+	}
+
+	return result
+}
+
+// normalizeIP returns the 4-byte form of IPv4 or 16-byte form of IPv6, nil is returned if ip is invalid.
+func normalizeIP(ip net.IP) net.IP {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4
+	}
+	return ip.To16()
+}
+
+// cidrOf returns the normalized network ip, prefix length and bit length of ipNet.
+func cidrOf(ipNet *net.IPNet) (net.IP, int, int, bool) {
+	if ipNet == nil {
+		return nil, 0, 0, false
+	}
+
+	ip := normalizeIP(ipNet.IP)
+	ones, bits := ipNet.Mask.Size()
+	if ip == nil || bits == 0 {
+		return nil, 0, 0, false
+	}
+
+	// IPv4 with 16-byte mask.
+	if len(ip) == net.IPv4len && bits == 8*net.IPv6len {
+		if ones < 8*(net.IPv6len-net.IPv4len) {
+			return nil, 0, 0, false
+		}
+		ones, bits = ones-8*(net.IPv6len-net.IPv4len), 8*net.IPv4len
+	}
+	if bits != 8*len(ip) {
+		return nil, 0, 0, false
+	}
+
+	return maskIP(ip, ones), ones, bits, true
+}
+
+func maskIP(ip net.IP, ones int) net.IP {
+	return ip.Mask(net.CIDRMask(ones, 8*len(ip)))
+}
+
+// ipBit returns the bit of ip at index i, the index of the most significant bit is 0.
+func ipBit(ip net.IP, i int) int {
+	return int(ip[i/8]>>(7-i%8)) & 1
+}
+
+func ipToInt(ip net.IP) *big.Int {
+	return new(big.Int).SetBytes(ip)
+}
+
+func intToIP(n *big.Int, size int) net.IP {
+	return n.FillBytes(make(net.IP, size))
+}
+
+// nextIP returns ip + 1, the ip is not changed.
+func nextIP(ip net.IP) net.IP {
+	next := append(net.IP(nil), ip...)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
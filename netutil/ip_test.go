@@ -0,0 +1,265 @@
+package netutil
+
+import (
+	"math/big"
+	"net"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+	"github.com/duke-git/lancet/v2/iterator"
+)
+
+func mustParseCIDR(s string) *net.IPNet {
+	ipNet, err := ParseCIDR(s)
+	if err != nil {
+		panic(err)
+	}
+	return ipNet
+}
+
+func cidrStrings(ipNets []*net.IPNet) []string {
+	result := make([]string, 0, len(ipNets))
+	for _, ipNet := range ipNets {
+		result = append(result, ipNet.String())
+	}
+	return result
+}
+
+func TestParseCIDR(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestParseCIDR")
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"192.168.0.0/16", "192.168.0.0/16"},
+		{" 10.1.2.3/8 ", "10.0.0.0/8"},
+		{"10.0.0.1", "10.0.0.1/32"},
+		{"2001:db8::1/32", "2001:db8::/32"},
+		{"::1", "::1/128"},
+	}
+
+	for _, tt := range tests {
+		ipNet, err := ParseCIDR(tt.input)
+		assert.IsNil(err)
+		assert.Equal(tt.expected, ipNet.String())
+	}
+
+	_, err := ParseCIDR("10.0.0.256")
+	assert.IsNotNil(err)
+	_, err = ParseCIDR("10.0.0.0/33")
+	assert.IsNotNil(err)
+}
+
+func TestCIDRContainsAndOverlaps(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCIDRContainsAndOverlaps")
+
+	network := mustParseCIDR("10.0.0.0/16")
+
+	assert.Equal(true, CIDRContains(network, mustParseCIDR("10.0.1.0/24")))
+	assert.Equal(true, CIDRContains(network, network))
+	assert.Equal(false, CIDRContains(mustParseCIDR("10.0.1.0/24"), network))
+	assert.Equal(false, CIDRContains(network, mustParseCIDR("10.1.0.0/24")))
+	assert.Equal(false, CIDRContains(mustParseCIDR("::/0"), network))
+
+	assert.Equal(true, CIDROverlaps(network, mustParseCIDR("10.0.0.0/8")))
+	assert.Equal(true, CIDROverlaps(mustParseCIDR("10.0.255.0/24"), network))
+	assert.Equal(false, CIDROverlaps(network, mustParseCIDR("10.1.0.0/16")))
+	assert.Equal(false, CIDROverlaps(network, nil))
+}
+
+func TestSplitCIDR(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSplitCIDR")
+
+	subnets, err := SplitCIDR(mustParseCIDR("10.0.0.0/16"), 18)
+	assert.IsNil(err)
+	assert.Equal([]string{"10.0.0.0/18", "10.0.64.0/18", "10.0.128.0/18", "10.0.192.0/18"}, cidrStrings(subnets))
+
+	subnets, err = SplitCIDR(mustParseCIDR("2001:db8::/32"), 34)
+	assert.IsNil(err)
+	assert.Equal([]string{"2001:db8::/34", "2001:db8:4000::/34", "2001:db8:8000::/34", "2001:db8:c000::/34"},
+		cidrStrings(subnets))
+
+	subnets, err = SplitCIDR(mustParseCIDR("10.0.0.0/24"), 24)
+	assert.IsNil(err)
+	assert.Equal([]string{"10.0.0.0/24"}, cidrStrings(subnets))
+
+	_, err = SplitCIDR(mustParseCIDR("10.0.0.0/24"), 16)
+	assert.IsNotNil(err)
+	_, err = SplitCIDR(mustParseCIDR("10.0.0.0/24"), 33)
+	assert.IsNotNil(err)
+	_, err = SplitCIDR(mustParseCIDR("2001:db8::/32"), 64)
+	assert.IsNotNil(err)
+}
+
+func TestAggregateCIDRs(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestAggregateCIDRs")
+
+	result, err := AggregateCIDRs(
+		mustParseCIDR("10.0.1.0/24"),
+		mustParseCIDR("2001:db8::/33"),
+		mustParseCIDR("10.0.0.0/25"),
+		mustParseCIDR("10.0.0.128/25"),
+		mustParseCIDR("10.0.1.5"),
+		mustParseCIDR("2001:db8:8000::/33"),
+		mustParseCIDR("192.168.0.0/24"),
+	)
+	assert.IsNil(err)
+	assert.Equal([]string{"10.0.0.0/23", "192.168.0.0/24", "2001:db8::/32"}, cidrStrings(result))
+
+	_, err = AggregateCIDRs(&net.IPNet{IP: net.IP{1, 2}})
+	assert.IsNotNil(err)
+}
+
+func TestIPIntConversion(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestIPIntConversion")
+
+	n, err := IPv4ToUint32(net.ParseIP("1.2.3.4"))
+	assert.IsNil(err)
+	assert.Equal(uint32(0x01020304), n)
+	assert.Equal("255.255.255.255", Uint32ToIPv4(0xffffffff).String())
+
+	_, err = IPv4ToUint32(net.ParseIP("::1"))
+	assert.IsNotNil(err)
+
+	bigInt, err := IPToBigInt(net.ParseIP("2001:db8::1"))
+	assert.IsNil(err)
+	assert.Equal("42540766411282592856903984951653826561", bigInt.String())
+
+	ip, err := BigIntToIP(bigInt, true)
+	assert.IsNil(err)
+	assert.Equal("2001:db8::1", ip.String())
+
+	ip, err = BigIntToIP(big.NewInt(0x0a000001), false)
+	assert.IsNil(err)
+	assert.Equal("10.0.0.1", ip.String())
+
+	_, err = BigIntToIP(new(big.Int).Lsh(big.NewInt(1), 32), false)
+	assert.IsNotNil(err)
+	_, err = BigIntToIP(big.NewInt(-1), true)
+	assert.IsNotNil(err)
+}
+
+func TestIPRange(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestIPRange")
+
+	r, err := ParseIPRange("10.0.0.254 - 10.0.1.2")
+	assert.IsNil(err)
+	assert.Equal("10.0.0.254-10.0.1.2", r.String())
+	assert.Equal(int64(5), r.Size().Int64())
+	assert.Equal(true, r.Contains(net.ParseIP("10.0.1.0")))
+	assert.Equal(false, r.Contains(net.ParseIP("10.0.1.3")))
+	assert.Equal(false, r.Contains(net.ParseIP("::1")))
+
+	ips := iterator.ToSlice[net.IP](r.Iterator())
+	assert.Equal([]string{"10.0.0.254", "10.0.0.255", "10.0.1.0", "10.0.1.1", "10.0.1.2"}, ipStrings(ips))
+
+	r, err = ParseIPRange("2001:db8::/126")
+	assert.IsNil(err)
+	assert.Equal("2001:db8::-2001:db8::3", r.String())
+	assert.Equal(4, len(iterator.ToSlice[net.IP](r.Iterator())))
+
+	// the iteration stops at the last ip of address space.
+	r, err = ParseIPRange("255.255.255.254/31")
+	assert.IsNil(err)
+	assert.Equal([]string{"255.255.255.254", "255.255.255.255"}, ipStrings(iterator.ToSlice[net.IP](r.Iterator())))
+
+	_, err = ParseIPRange("10.0.0.2-10.0.0.1")
+	assert.IsNotNil(err)
+	_, err = ParseIPRange("10.0.0.1-::1")
+	assert.IsNotNil(err)
+	_, err = ParseIPRange("10.0.0.1-x")
+	assert.IsNotNil(err)
+}
+
+func ipStrings(ips []net.IP) []string {
+	result := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		result = append(result, ip.String())
+	}
+	return result
+}
+
+func TestIPRange_CIDRs(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestIPRange_CIDRs")
+
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"10.0.0.0-10.0.0.255", []string{"10.0.0.0/24"}},
+		{"10.0.0.1-10.0.0.10", []string{"10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/30", "10.0.0.8/31", "10.0.0.10/32"}},
+		{"0.0.0.0-255.255.255.255", []string{"0.0.0.0/0"}},
+		{"255.255.255.255", []string{"255.255.255.255/32"}},
+		{"2001:db8::-2001:db8::5", []string{"2001:db8::/126", "2001:db8::4/127"}},
+	}
+
+	for _, tt := range tests {
+		r, err := ParseIPRange(tt.input)
+		assert.IsNil(err)
+		assert.Equal(tt.expected, cidrStrings(r.CIDRs()))
+	}
+}
+
+func TestIPSet(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestIPSet")
+
+	set, err := ParseIPSet("10.0.0.0/16", "192.168.1.1-192.168.1.3", "2001:db8::/32")
+	assert.IsNil(err)
+	assert.Equal(false, set.IsEmpty())
+
+	assert.Equal(true, set.Contains(net.ParseIP("10.0.200.1")))
+	assert.Equal(true, set.Contains(net.ParseIP("192.168.1.2")))
+	assert.Equal(true, set.Contains(net.ParseIP("2001:db8:1::1")))
+	assert.Equal(false, set.Contains(net.ParseIP("10.1.0.0")))
+	assert.Equal(false, set.Contains(net.ParseIP("192.168.1.4")))
+	assert.Equal(false, set.Contains(net.ParseIP("::1")))
+	assert.Equal(false, set.Contains(nil))
+
+	assert.Equal(true, set.ContainsCIDR(mustParseCIDR("10.0.3.0/24")))
+	assert.Equal(false, set.ContainsCIDR(mustParseCIDR("10.0.0.0/8")))
+
+	assert.IsNil(set.AddIP(net.ParseIP("192.168.1.0")))
+	assert.Equal([]string{"10.0.0.0/16", "192.168.1.0/30", "2001:db8::/32"}, cidrStrings(set.CIDRs()))
+
+	// remove a subnet of a network in the set.
+	assert.IsNil(set.Remove(mustParseCIDR("10.0.128.0/17")))
+	assert.IsNil(set.Remove(mustParseCIDR("10.0.0.1")))
+	assert.Equal(false, set.Contains(net.ParseIP("10.0.200.1")))
+	assert.Equal(false, set.Contains(net.ParseIP("10.0.0.1")))
+	assert.Equal(true, set.Contains(net.ParseIP("10.0.0.2")))
+	assert.Equal([]string{
+		"10.0.0.0/32", "10.0.0.2/31", "10.0.0.4/30", "10.0.0.8/29", "10.0.0.16/28", "10.0.0.32/27",
+		"10.0.0.64/26", "10.0.0.128/25", "10.0.1.0/24", "10.0.2.0/23", "10.0.4.0/22", "10.0.8.0/21",
+		"10.0.16.0/20", "10.0.32.0/19", "10.0.64.0/18", "192.168.1.0/30", "2001:db8::/32",
+	}, cidrStrings(set.CIDRs()))
+
+	// removed ips can be added back and merged.
+	assert.IsNil(set.Add(mustParseCIDR("10.0.0.0/16")))
+	assert.IsNil(set.Remove(mustParseCIDR("::/0")))
+	assert.IsNil(set.Remove(mustParseCIDR("192.168.0.0/16")))
+	assert.Equal([]string{"10.0.0.0/16"}, cidrStrings(set.CIDRs()))
+
+	assert.IsNil(set.Remove(mustParseCIDR("0.0.0.0/0")))
+	assert.Equal(true, set.IsEmpty())
+	assert.Equal(0, len(set.CIDRs()))
+
+	_, err = ParseIPSet("10.0.0.0/8", "invalid")
+	assert.IsNotNil(err)
+}
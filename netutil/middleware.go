@@ -0,0 +1,425 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license.
+
+package netutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/duke-git/lancet/v2/random"
+)
+
+// RoundTripFunc is an adapter to allow the use of function as http.RoundTripper.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper interface.
+func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a http.RoundTripper to intercept requests and responses of HttpClient.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// Use appends middlewares to the client, the first middleware is the outermost one.
+// it should be called before sending requests.
+func (client *HttpClient) Use(middlewares ...Middleware) *HttpClient {
+	if client.transport == nil {
+		client.transport = client.Client.Transport
+		if client.transport == nil {
+			client.transport = http.DefaultTransport
+		}
+	}
+
+	client.middlewares = append(client.middlewares, middlewares...)
+	client.Client.Transport = ChainMiddlewares(client.transport, client.middlewares...)
+
+	return client
+}
+
+// SetTransport replaces the underlying transport of client, the middlewares are kept.
+// It's useful to send requests by a mock transport in test, see httpmock package.
+func (client *HttpClient) SetTransport(transport http.RoundTripper) *HttpClient {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	client.transport = transport
+	client.Client.Transport = ChainMiddlewares(transport, client.middlewares...)
+
+	return client
+}
+
+// ChainMiddlewares wraps the transport with middlewares, the first middleware is the outermost one.
+func ChainMiddlewares(transport http.RoundTripper, middlewares ...Middleware) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		transport = middlewares[i](transport)
+	}
+	return transport
+}
+
+// BearerAuth sets `Authorization: Bearer <token>` header if the request has no Authorization header.
+func BearerAuth(token string) Middleware {
+	return setHeaderIfAbsent("Authorization", "Bearer "+token)
+}
+
+// BasicAuth sets basic authorization header if the request has no Authorization header.
+func BasicAuth(username, password string) Middleware {
+	credential := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	return setHeaderIfAbsent("Authorization", "Basic "+credential)
+}
+
+func setHeaderIfAbsent(key, value string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(key) != "" {
+				return next.RoundTrip(req)
+			}
+			req = cloneRequest(req)
+			req.Header.Set(key, value)
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// DefaultRequestIDHeader is the default header of request id.
+const DefaultRequestIDHeader = "X-Request-Id"
+
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx with request id, which is propagated by RequestID middleware.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request id in ctx, it returns empty string if not found.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// RequestID sets request id header if the request has no one. the id is taken from request context
+// (see ContextWithRequestID), or a new uuid is generated. header is DefaultRequestIDHeader if it's empty.
+func RequestID(header string) Middleware {
+	if header == "" {
+		header = DefaultRequestIDHeader
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(header) != "" {
+				return next.RoundTrip(req)
+			}
+
+			id := RequestIDFromContext(req.Context())
+			if id == "" {
+				var err error
+				if id, err = random.UUIdV4(); err != nil {
+					return nil, err
+				}
+			}
+
+			req = cloneRequest(req)
+			req.Header.Set(header, id)
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// RequestLog is the log entry of request and response, it's passed to the function of Logging middleware.
+type RequestLog struct {
+	Method          string
+	URL             string
+	RequestHeader   http.Header
+	RequestBody     []byte
+	StatusCode      int
+	ResponseHeader  http.Header
+	ResponseBody    []byte
+	Duration        time.Duration
+	Err             error
+	BodyIsTruncated bool
+}
+
+// maxLogBodySize is the max size of request and response body in RequestLog.
+const maxLogBodySize = 4096
+
+// Logging calls logFn with the log entry after each request is done. if withBody is true, the first
+// 4KB of request and response body are logged, the bodies are still sent and read completely.
+// The response body is captured while the caller reads it, so streaming responses are not blocked,
+// and logFn is called when the response body is read to EOF or closed.
+func Logging(logFn func(entry *RequestLog), withBody bool) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			entry := &RequestLog{
+				Method:        req.Method,
+				URL:           req.URL.String(),
+				RequestHeader: req.Header.Clone(),
+			}
+
+			if withBody && req.Body != nil && req.Body != http.NoBody {
+				var truncated bool
+				req = cloneRequest(req)
+				entry.RequestBody, req.Body, truncated = peekBody(req.Body)
+				entry.BodyIsTruncated = entry.BodyIsTruncated || truncated
+			}
+
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			entry.Duration = time.Since(start)
+			entry.Err = err
+
+			if resp != nil {
+				entry.StatusCode = resp.StatusCode
+				entry.ResponseHeader = resp.Header.Clone()
+				if withBody && resp.Body != nil && resp.Body != http.NoBody {
+					resp.Body = &loggingBody{ReadCloser: resp.Body, entry: entry, logFn: logFn}
+					return resp, err
+				}
+			}
+
+			logFn(entry)
+
+			return resp, err
+		})
+	}
+}
+
+// StdLogging logs requests by standard log package, it's used by HttpClientConfig.Verbose.
+func StdLogging(logger *log.Logger) Middleware {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return Logging(func(entry *RequestLog) {
+		if entry.Err != nil {
+			logger.Printf("%s %s error=%q duration=%s", entry.Method, entry.URL, entry.Err.Error(), entry.Duration)
+			return
+		}
+		logger.Printf("%s %s status=%d duration=%s", entry.Method, entry.URL, entry.StatusCode, entry.Duration)
+	}, false)
+}
+
+// peekBody reads the first maxLogBodySize bytes of body, and returns a new body which reads the whole content.
+func peekBody(body io.ReadCloser) ([]byte, io.ReadCloser, bool) {
+	buf := make([]byte, maxLogBodySize+1)
+	n, err := io.ReadFull(body, buf)
+	buf = buf[:n]
+
+	var rest io.Reader = body
+	switch err {
+	case nil:
+	case io.EOF, io.ErrUnexpectedEOF:
+		// body is read completely.
+		rest = bytes.NewReader(nil)
+	default:
+		rest = errReader{err: err}
+	}
+
+	peeked := buf
+	truncated := n > maxLogBodySize
+	if truncated {
+		peeked = buf[:maxLogBodySize]
+	}
+
+	return append([]byte{}, peeked...), &readCloser{
+		Reader: io.MultiReader(bytes.NewReader(buf), rest),
+		Closer: body,
+	}, truncated
+}
+
+// loggingBody captures the first maxLogBodySize bytes of response body as it's read,
+// and logs the entry once the body is read to EOF or closed.
+type loggingBody struct {
+	io.ReadCloser
+	entry *RequestLog
+	logFn func(entry *RequestLog)
+	once  sync.Once
+}
+
+func (b *loggingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+
+	if rest := maxLogBodySize - len(b.entry.ResponseBody); rest > 0 {
+		captured := n
+		if captured > rest {
+			captured = rest
+		}
+		b.entry.ResponseBody = append(b.entry.ResponseBody, p[:captured]...)
+		if captured < n {
+			b.entry.BodyIsTruncated = true
+		}
+	} else if n > 0 {
+		b.entry.BodyIsTruncated = true
+	}
+
+	if err != nil {
+		if err != io.EOF && b.entry.Err == nil {
+			b.entry.Err = err
+		}
+		b.log()
+	}
+
+	return n, err
+}
+
+func (b *loggingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.log()
+	return err
+}
+
+func (b *loggingBody) log() {
+	b.once.Do(func() {
+		b.logFn(b.entry)
+	})
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// errReader returns the error of reading original body.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+// RequestMetrics is the metrics of a request, it's passed to the function of Metrics middleware.
+type RequestMetrics struct {
+	Method     string
+	Host       string
+	Path       string
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+// Metrics calls observe with the metrics after each request is done, the duration is the time
+// until response header is received.
+func Metrics(observe func(m RequestMetrics)) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+
+			m := RequestMetrics{
+				Method:   req.Method,
+				Host:     req.URL.Host,
+				Path:     req.URL.Path,
+				Duration: time.Since(start),
+				Err:      err,
+			}
+			if resp != nil {
+				m.StatusCode = resp.StatusCode
+			}
+			observe(m)
+
+			return resp, err
+		})
+	}
+}
+
+// DefaultLatencyBuckets is the default upper bounds of LatencyHistogram buckets.
+var DefaultLatencyBuckets = []time.Duration{
+	5 * time.Millisecond, 10 * time.Millisecond, 25 * time.Millisecond, 50 * time.Millisecond,
+	100 * time.Millisecond, 250 * time.Millisecond, 500 * time.Millisecond,
+	time.Second, 2500 * time.Millisecond, 5 * time.Second, 10 * time.Second,
+}
+
+// LatencyHistogram counts request latencies in buckets, it's safe for concurrent use.
+// Its Observe method can be passed to Metrics middleware, eg: client.Use(netutil.Metrics(histogram.Observe)).
+type LatencyHistogram struct {
+	mu      sync.Mutex
+	buckets []time.Duration
+	counts  []uint64
+	count   uint64
+	sum     time.Duration
+}
+
+// HistogramSnapshot is the snapshot of LatencyHistogram.
+type HistogramSnapshot struct {
+	// Buckets is the upper bounds of buckets.
+	Buckets []time.Duration
+	// Counts is the cumulative count of each bucket, the last one is the count of +Inf bucket.
+	Counts []uint64
+	Count  uint64
+	Sum    time.Duration
+}
+
+// NewLatencyHistogram returns a LatencyHistogram with bucket upper bounds, DefaultLatencyBuckets is used if it's empty.
+func NewLatencyHistogram(buckets ...time.Duration) *LatencyHistogram {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	sorted := append([]time.Duration{}, buckets...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return &LatencyHistogram{
+		buckets: sorted,
+		counts:  make([]uint64, len(sorted)+1),
+	}
+}
+
+// Observe records the duration of request metrics.
+func (h *LatencyHistogram) Observe(m RequestMetrics) {
+	h.ObserveDuration(m.Duration)
+}
+
+// ObserveDuration records a latency.
+func (h *LatencyHistogram) ObserveDuration(d time.Duration) {
+	i := sort.Search(len(h.buckets), func(i int) bool { return d <= h.buckets[i] })
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.counts[i]++
+	h.count++
+	h.sum += d
+}
+
+// Snapshot returns the current state of histogram.
+func (h *LatencyHistogram) Snapshot() HistogramSnapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	snapshot := HistogramSnapshot{
+		Buckets: append([]time.Duration{}, h.buckets...),
+		Counts:  make([]uint64, len(h.counts)),
+		Count:   h.count,
+		Sum:     h.sum,
+	}
+
+	var cumulative uint64
+	for i, c := range h.counts {
+		cumulative += c
+		snapshot.Counts[i] = cumulative
+	}
+
+	return snapshot
+}
+
+// cloneRequest returns a shallow copy of req with deep copied header,
+// a RoundTripper should not modify the request.
+func cloneRequest(req *http.Request) *http.Request {
+	r := new(http.Request)
+	*r = *req
+	r.Header = req.Header.Clone()
+	if r.Header == nil {
+		r.Header = make(http.Header)
+	}
+	return r
+}
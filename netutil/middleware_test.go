@@ -0,0 +1,287 @@
+package netutil
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func newEchoServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Auth", r.Header.Get("Authorization"))
+		w.Header().Set("X-Request-Id", r.Header.Get("X-Request-Id"))
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+}
+
+func TestHttpClient_Use(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestHttpClient_Use")
+
+	server := newEchoServer()
+	defer server.Close()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name+" before")
+				resp, err := next.RoundTrip(req)
+				order = append(order, name+" after")
+				return resp, err
+			})
+		}
+	}
+
+	client := NewHttpClient()
+	client.Use(trace("a")).Use(trace("b"))
+
+	resp, err := client.SendRequest(&HttpRequest{RawURL: server.URL, Method: "GET"})
+	assert.IsNil(err)
+	resp.Body.Close()
+
+	assert.Equal([]string{"a before", "b before", "b after", "a after"}, order)
+}
+
+func TestHttpClient_SetTransport(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestHttpClient_SetTransport")
+
+	var hosts []string
+	transport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		hosts = append(hosts, req.URL.Host)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       io.NopCloser(strings.NewReader(req.Header.Get("Authorization"))),
+		}, nil
+	})
+
+	client := NewHttpClient().Use(BearerAuth("token")).SetTransport(transport)
+
+	body, err := Do[string](client.R(), http.MethodGet, "http://example.com")
+	assert.IsNil(err)
+	assert.Equal("Bearer token", body)
+	assert.Equal([]string{"example.com"}, hosts)
+
+	// the middlewares added later wrap the new transport.
+	client.Use(RequestID(""))
+	_, err = client.R().Get("http://example.org")
+	assert.IsNil(err)
+	assert.Equal([]string{"example.com", "example.org"}, hosts)
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestAuthMiddleware")
+
+	server := newEchoServer()
+	defer server.Close()
+
+	client := NewHttpClient().Use(BearerAuth("token"))
+	resp, err := client.SendRequest(&HttpRequest{RawURL: server.URL, Method: "GET"})
+	assert.IsNil(err)
+	resp.Body.Close()
+	assert.Equal("Bearer token", resp.Header.Get("X-Auth"))
+
+	// existing Authorization header is kept.
+	header := http.Header{}
+	header.Set("Authorization", "Custom abc")
+	resp, err = client.SendRequest(&HttpRequest{RawURL: server.URL, Method: "GET", Headers: header})
+	assert.IsNil(err)
+	resp.Body.Close()
+	assert.Equal("Custom abc", resp.Header.Get("X-Auth"))
+
+	client = NewHttpClient().Use(BasicAuth("user", "pass"))
+	resp, err = client.SendRequest(&HttpRequest{RawURL: server.URL, Method: "GET"})
+	assert.IsNil(err)
+	resp.Body.Close()
+	assert.Equal("Basic dXNlcjpwYXNz", resp.Header.Get("X-Auth"))
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRequestIDMiddleware")
+
+	server := newEchoServer()
+	defer server.Close()
+
+	client := NewHttpClient().Use(RequestID(""))
+	resp, err := client.SendRequest(&HttpRequest{RawURL: server.URL, Method: "GET"})
+	assert.IsNil(err)
+	resp.Body.Close()
+	assert.Equal(36, len(resp.Header.Get("X-Request-Id")))
+
+	client.Context = ContextWithRequestID(context.Background(), "req-1")
+	resp, err = client.SendRequest(&HttpRequest{RawURL: server.URL, Method: "GET"})
+	assert.IsNil(err)
+	resp.Body.Close()
+	assert.Equal("req-1", resp.Header.Get("X-Request-Id"))
+	assert.Equal("req-1", RequestIDFromContext(client.Context))
+	assert.Equal("", RequestIDFromContext(context.Background()))
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestLoggingMiddleware")
+
+	server := newEchoServer()
+	defer server.Close()
+
+	var entries []*RequestLog
+	client := NewHttpClient().Use(Logging(func(entry *RequestLog) {
+		entries = append(entries, entry)
+	}, true))
+
+	body := []byte(`{"name":"lancet"}`)
+	resp, err := client.SendRequest(&HttpRequest{RawURL: server.URL, Method: "POST", Body: body})
+	assert.IsNil(err)
+
+	respBody, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(body, respBody)
+
+	assert.Equal(1, len(entries))
+	assert.Equal("POST", entries[0].Method)
+	assert.Equal(http.StatusOK, entries[0].StatusCode)
+	assert.Equal(body, entries[0].RequestBody)
+	assert.Equal(body, entries[0].ResponseBody)
+	assert.Equal(false, entries[0].BodyIsTruncated)
+
+	// large body is truncated in log, but sent completely.
+	large := bytes.Repeat([]byte("a"), maxLogBodySize*2)
+	resp, err = client.SendRequest(&HttpRequest{RawURL: server.URL, Method: "POST", Body: large})
+	assert.IsNil(err)
+	respBody, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	assert.Equal(len(large), len(respBody))
+	assert.Equal(maxLogBodySize, len(entries[1].RequestBody))
+	assert.Equal(true, entries[1].BodyIsTruncated)
+}
+
+func TestLoggingMiddleware_Stream(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestLoggingMiddleware_Stream")
+
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("data: 1\n\n"))
+		w.(http.Flusher).Flush()
+		<-done
+		w.Write([]byte("data: 2\n\n"))
+	}))
+	defer server.Close()
+
+	entries := make(chan *RequestLog, 1)
+	client := NewHttpClient().Use(Logging(func(entry *RequestLog) {
+		entries <- entry
+	}, true))
+
+	// the response is returned before the stream is finished.
+	resp, err := client.SendRequest(&HttpRequest{RawURL: server.URL, Method: "GET"})
+	assert.IsNil(err)
+
+	buf := make([]byte, 16)
+	n, err := resp.Body.Read(buf)
+	assert.IsNil(err)
+	assert.Equal("data: 1\n\n", string(buf[:n]))
+	assert.Equal(0, len(entries))
+
+	close(done)
+	rest, err := io.ReadAll(resp.Body)
+	assert.IsNil(err)
+	assert.Equal("data: 2\n\n", string(rest))
+	resp.Body.Close()
+
+	entry := <-entries
+	assert.Equal(http.StatusOK, entry.StatusCode)
+	assert.Equal("data: 1\n\ndata: 2\n\n", string(entry.ResponseBody))
+	assert.Equal(0, len(entries))
+}
+
+func TestHttpClientConfig_Verbose(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestHttpClientConfig_Verbose")
+
+	server := newEchoServer()
+	defer server.Close()
+
+	var buf bytes.Buffer
+	var mu sync.Mutex
+	client := NewHttpClient().Use(StdLogging(log.New(&syncWriter{w: &buf, mu: &mu}, "", 0)))
+	resp, err := client.SendRequest(&HttpRequest{RawURL: server.URL, Method: "GET"})
+	assert.IsNil(err)
+	resp.Body.Close()
+
+	mu.Lock()
+	assert.Equal(true, strings.HasPrefix(buf.String(), "GET "+server.URL+" status=200"))
+	mu.Unlock()
+
+	verbose := NewHttpClientWithConfig(&HttpClientConfig{Verbose: true})
+	assert.Equal(1, len(verbose.middlewares))
+}
+
+type syncWriter struct {
+	w  io.Writer
+	mu *sync.Mutex
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestMetricsMiddleware")
+
+	server := newEchoServer()
+	defer server.Close()
+
+	histogram := NewLatencyHistogram(time.Nanosecond, time.Minute)
+
+	var metrics []RequestMetrics
+	client := NewHttpClient().Use(Metrics(func(m RequestMetrics) {
+		metrics = append(metrics, m)
+		histogram.Observe(m)
+	}))
+
+	resp, err := client.SendRequest(&HttpRequest{RawURL: server.URL + "/path", Method: "GET"})
+	assert.IsNil(err)
+	resp.Body.Close()
+
+	assert.Equal(1, len(metrics))
+	assert.Equal("GET", metrics[0].Method)
+	assert.Equal("/path", metrics[0].Path)
+	assert.Equal(http.StatusOK, metrics[0].StatusCode)
+
+	histogram.ObserveDuration(time.Hour)
+	snapshot := histogram.Snapshot()
+	assert.Equal([]time.Duration{time.Nanosecond, time.Minute}, snapshot.Buckets)
+	assert.Equal([]uint64{0, 1, 2}, snapshot.Counts)
+	assert.Equal(uint64(2), snapshot.Count)
+	assert.Equal(true, snapshot.Sum > time.Hour)
+
+	assert.Equal(len(DefaultLatencyBuckets), len(NewLatencyHistogram().Snapshot().Buckets))
+}
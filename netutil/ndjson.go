@@ -0,0 +1,89 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license.
+
+package netutil
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/duke-git/lancet/v2/iterator"
+)
+
+// NDJSONIterator decodes newline delimited json values from a stream one by one.
+// It implements iterator.StopIterator.
+type NDJSONIterator[T any] struct {
+	reader  io.Reader
+	decoder *json.Decoder
+	next    T
+	fetched bool
+	done    bool
+	err     error
+}
+
+var _ iterator.StopIterator[int] = (*NDJSONIterator[int])(nil)
+
+// DecodeNDJSON returns an iterator which decodes newline delimited json (JSON Lines) values from r.
+// If r is io.Closer, eg: http response body, it's closed when the iteration is over or Stop is called.
+func DecodeNDJSON[T any](r io.Reader) *NDJSONIterator[T] {
+	return &NDJSONIterator[T]{
+		reader:  r,
+		decoder: json.NewDecoder(r),
+	}
+}
+
+// HasNext checks if there is a next value, it decodes the next value from stream.
+func (iter *NDJSONIterator[T]) HasNext() bool {
+	if iter.done {
+		return false
+	}
+	if iter.fetched {
+		return true
+	}
+
+	var value T
+	if err := iter.decoder.Decode(&value); err != nil {
+		if !errors.Is(err, io.EOF) {
+			iter.err = err
+		}
+		iter.Stop()
+		return false
+	}
+
+	iter.next = value
+	iter.fetched = true
+
+	return true
+}
+
+// Next returns the next value, ok is false if the stream is ended or an error occurs, see Err.
+func (iter *NDJSONIterator[T]) Next() (T, bool) {
+	var zero T
+	if !iter.HasNext() {
+		return zero, false
+	}
+
+	value := iter.next
+	iter.next = zero
+	iter.fetched = false
+
+	return value, true
+}
+
+// Err returns the error which stops the iteration, it's nil if the stream is ended normally.
+func (iter *NDJSONIterator[T]) Err() error {
+	return iter.err
+}
+
+// Stop stops the iteration, and closes the reader if it's io.Closer.
+func (iter *NDJSONIterator[T]) Stop() {
+	if iter.done {
+		return
+	}
+	iter.done = true
+
+	if closer, ok := iter.reader.(io.Closer); ok {
+		closer.Close()
+	}
+}
@@ -0,0 +1,83 @@
+package netutil
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+	"github.com/duke-git/lancet/v2/iterator"
+)
+
+func TestDecodeNDJSON(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDecodeNDJSON")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		for i := 1; i <= 3; i++ {
+			w.Write([]byte(`{"id":` + string(rune('0'+i)) + `,"title":"todo"}` + "\n\n"))
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer server.Close()
+
+	resp, err := NewHttpClient().R().Get(server.URL)
+	assert.IsNil(err)
+
+	iter := DecodeNDJSON[testTodo](resp.Body)
+	todos := iterator.ToSlice[testTodo](iter)
+	assert.IsNil(iter.Err())
+	assert.Equal(3, len(todos))
+	assert.Equal(3, todos[2].ID)
+
+	_, ok := iter.Next()
+	assert.Equal(false, ok)
+	assert.Equal(false, iter.HasNext())
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestDecodeNDJSON_Error(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDecodeNDJSON_Error")
+
+	reader := &closeRecorder{Reader: strings.NewReader("1\n2\nabc\n4\n")}
+	iter := DecodeNDJSON[int](reader)
+
+	assert.Equal(true, iter.HasNext())
+	assert.Equal(true, iter.HasNext())
+	v, ok := iter.Next()
+	assert.Equal(1, v)
+	assert.Equal(true, ok)
+
+	v, _ = iter.Next()
+	assert.Equal(2, v)
+
+	_, ok = iter.Next()
+	assert.Equal(false, ok)
+	assert.IsNotNil(iter.Err())
+	assert.Equal(true, reader.closed)
+
+	// stop before the stream is ended.
+	reader = &closeRecorder{Reader: strings.NewReader("1\n2\n")}
+	iter = DecodeNDJSON[int](reader)
+	iter.Next()
+	iter.Stop()
+	iter.Stop()
+	assert.Equal(true, reader.closed)
+	assert.Equal(false, iter.HasNext())
+	assert.IsNil(iter.Err())
+}
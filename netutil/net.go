@@ -1,12 +1,10 @@
 package netutil
 
 import (
-	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"mime/multipart"
 	"net"
 	"net/http"
 	"net/url"
@@ -217,43 +215,28 @@ func EncodeUrl(urlStr string) (string, error) {
 	return URL.String(), nil
 }
 
-// DownloadFile will upload the file to a server.
+// UploadFile will upload the file to a server, the file content is streamed.
 func UploadFile(filepath string, server string) (bool, error) {
 	if !fileutil.IsExist(filepath) {
 		return false, errors.New("file not exist")
 	}
 
-	fileInfo, err := os.Stat(filepath)
+	body, err := newMultipartBody(nil, []*FormFile{{FieldName: "uploadfile", Path: filepath}}, nil)
 	if err != nil {
 		return false, err
 	}
 
-	bodyBuffer := &bytes.Buffer{}
-	writer := multipart.NewWriter(bodyBuffer)
-
-	formFile, err := writer.CreateFormFile("uploadfile", fileInfo.Name())
-	if err != nil {
-		return false, err
-	}
-
-	srcFile, err := os.Open(filepath)
+	req, err := http.NewRequest(http.MethodPost, server, nil)
 	if err != nil {
 		return false, err
 	}
-	defer srcFile.Close()
-
-	_, err = io.Copy(formFile, srcFile)
-	if err != nil {
-		return false, err
-	}
-
-	contentType := writer.FormDataContentType()
-	writer.Close()
+	body.setRequest(req)
 
-	_, err = http.Post(server, contentType, bodyBuffer)
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return false, err
 	}
+	resp.Body.Close()
 
 	return true, nil
 }
@@ -0,0 +1,251 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license.
+
+package netutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OAuth2Token is the access token of OAuth2.
+type OAuth2Token struct {
+	AccessToken  string
+	TokenType    string
+	RefreshToken string
+	// Expiry is the expiration time of access token, zero means the token never expires.
+	Expiry time.Time
+}
+
+// Type returns the token type in Authorization header, default is "Bearer".
+func (t *OAuth2Token) Type() string {
+	if t.TokenType == "" || strings.EqualFold(t.TokenType, "bearer") {
+		return "Bearer"
+	}
+	return t.TokenType
+}
+
+// OAuth2Config is the config of OAuth2TokenSource.
+type OAuth2Config struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// EndpointParams is the additional parameters of token request.
+	EndpointParams url.Values
+	// AuthInParams sends client id and secret in request body, instead of basic authorization header.
+	AuthInParams bool
+	// ExpiryDelta is the time before expiry to refresh the token, default is 10s.
+	ExpiryDelta time.Duration
+	// Client is used to request token endpoint, default is NewHttpClient().
+	Client *HttpClient
+}
+
+const defaultTokenExpiryDelta = 10 * time.Second
+
+// OAuth2TokenSource fetches and caches OAuth2 token, it refreshes the token before expiry.
+// It's safe for concurrent use.
+type OAuth2TokenSource struct {
+	config    OAuth2Config
+	grantType string
+
+	mu    sync.Mutex
+	token *OAuth2Token
+	now   func() time.Time
+}
+
+// NewClientCredentialsTokenSource returns a token source with client credentials grant.
+// If the token has refresh token, it's used to refresh token first.
+func NewClientCredentialsTokenSource(config OAuth2Config) *OAuth2TokenSource {
+	return newOAuth2TokenSource(config, "client_credentials", nil)
+}
+
+// NewRefreshTokenSource returns a token source with refresh token grant, the refresh token is
+// replaced if a new one is returned by token endpoint.
+func NewRefreshTokenSource(config OAuth2Config, refreshToken string) *OAuth2TokenSource {
+	return newOAuth2TokenSource(config, "refresh_token", &OAuth2Token{RefreshToken: refreshToken})
+}
+
+func newOAuth2TokenSource(config OAuth2Config, grantType string, token *OAuth2Token) *OAuth2TokenSource {
+	if config.ExpiryDelta <= 0 {
+		config.ExpiryDelta = defaultTokenExpiryDelta
+	}
+	if config.Client == nil {
+		config.Client = NewHttpClient()
+	}
+
+	return &OAuth2TokenSource{
+		config:    config,
+		grantType: grantType,
+		token:     token,
+		now:       time.Now,
+	}
+}
+
+// Token returns the cached token, or fetches a new one if it's expired or will expire within ExpiryDelta.
+func (s *OAuth2TokenSource) Token(ctx context.Context) (*OAuth2Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.valid(s.token) {
+		return s.token, nil
+	}
+
+	token, err := s.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.token = token
+
+	return token, nil
+}
+
+// Invalidate drops the cached access token, so the next call of Token fetches a new one.
+func (s *OAuth2TokenSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.invalidate(nil)
+}
+
+// invalidate drops the access token if it's the current one, or token is nil.
+func (s *OAuth2TokenSource) invalidate(token *OAuth2Token) {
+	if s.token == nil || (token != nil && token != s.token) {
+		return
+	}
+	s.token = &OAuth2Token{RefreshToken: s.token.RefreshToken}
+}
+
+func (s *OAuth2TokenSource) valid(token *OAuth2Token) bool {
+	if token == nil || token.AccessToken == "" {
+		return false
+	}
+	return token.Expiry.IsZero() || s.now().Add(s.config.ExpiryDelta).Before(token.Expiry)
+}
+
+func (s *OAuth2TokenSource) fetch(ctx context.Context) (*OAuth2Token, error) {
+	var refreshToken string
+	if s.token != nil {
+		refreshToken = s.token.RefreshToken
+	}
+
+	if refreshToken != "" {
+		params := url.Values{"grant_type": {"refresh_token"}, "refresh_token": {refreshToken}}
+		token, err := s.requestToken(ctx, params)
+		if err == nil || s.grantType == "refresh_token" {
+			if token != nil && token.RefreshToken == "" {
+				token.RefreshToken = refreshToken
+			}
+			return token, err
+		}
+	}
+
+	if s.grantType != "client_credentials" {
+		return nil, errors.New("oauth2: refresh token is empty")
+	}
+
+	params := url.Values{"grant_type": {"client_credentials"}}
+	if len(s.config.Scopes) > 0 {
+		params.Set("scope", strings.Join(s.config.Scopes, " "))
+	}
+
+	return s.requestToken(ctx, params)
+}
+
+// tokenResponse is the successful response of token endpoint, see RFC 6749 section 5.1.
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+func (s *OAuth2TokenSource) requestToken(ctx context.Context, params url.Values) (*OAuth2Token, error) {
+	for k, v := range s.config.EndpointParams {
+		params[k] = v
+	}
+
+	r := s.config.Client.R().SetContext(ctx).SetHeader("Accept", "application/json")
+	if s.config.AuthInParams {
+		params.Set("client_id", s.config.ClientID)
+		if s.config.ClientSecret != "" {
+			params.Set("client_secret", s.config.ClientSecret)
+		}
+	} else {
+		r.SetBasicAuth(url.QueryEscape(s.config.ClientID), url.QueryEscape(s.config.ClientSecret))
+	}
+
+	var resp tokenResponse
+	_, err := r.SetFormBody(params).SetResult(&resp).Post(s.config.TokenURL)
+	if err != nil {
+		return nil, fmt.Errorf("oauth2: cannot fetch token: %w", err)
+	}
+	if resp.AccessToken == "" {
+		return nil, errors.New("oauth2: server response missing access_token")
+	}
+
+	token := &OAuth2Token{
+		AccessToken:  resp.AccessToken,
+		TokenType:    resp.TokenType,
+		RefreshToken: resp.RefreshToken,
+	}
+	if resp.ExpiresIn > 0 {
+		token.Expiry = s.now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
+
+// Middleware returns a middleware which sets the access token to Authorization header.
+// If the response is 401 Unauthorized, the token is refreshed and the request is sent again once.
+func (s *OAuth2TokenSource) Middleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			token, err := s.Token(req.Context())
+			if err != nil {
+				return nil, err
+			}
+
+			req = cloneRequest(req)
+			if err := bufferRequestBody(req); err != nil {
+				return nil, err
+			}
+
+			r, err := rewindRequest(req)
+			if err != nil {
+				return nil, err
+			}
+			r.Header.Set("Authorization", token.Type()+" "+token.AccessToken)
+
+			resp, err := next.RoundTrip(r)
+			if err != nil || resp.StatusCode != http.StatusUnauthorized {
+				return resp, err
+			}
+
+			s.mu.Lock()
+			s.invalidate(token)
+			s.mu.Unlock()
+
+			newToken, err := s.Token(req.Context())
+			if err != nil {
+				// keep the 401 response if token can't be refreshed.
+				return resp, nil
+			}
+			drainBody(resp.Body)
+
+			r, err = rewindRequest(req)
+			if err != nil {
+				return nil, err
+			}
+			r.Header.Set("Authorization", newToken.Type()+" "+newToken.AccessToken)
+
+			return next.RoundTrip(r)
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package netutil
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+type testTokenServer struct {
+	*httptest.Server
+	requests int32
+	grants   []string
+
+	mu     sync.Mutex
+	tokens map[string]bool
+	reject bool
+}
+
+func newTestTokenServer() *testTokenServer {
+	s := &testTokenServer{tokens: make(map[string]bool)}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&s.requests, 1)
+		r.ParseForm()
+
+		clientID, secret, ok := r.BasicAuth()
+		if !ok {
+			clientID, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+		}
+		if clientID != "id" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+
+		grant := r.PostForm.Get("grant_type")
+		s.mu.Lock()
+		s.grants = append(s.grants, grant+":"+r.PostForm.Get("scope")+r.PostForm.Get("refresh_token"))
+		s.mu.Unlock()
+
+		if grant == "refresh_token" && r.PostForm.Get("refresh_token") == "invalid" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+
+		token := "token-" + strconv.Itoa(int(n))
+		s.mu.Lock()
+		s.tokens[token] = true
+		s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  token,
+			"token_type":    "bearer",
+			"expires_in":    3600,
+			"refresh_token": "refresh-" + strconv.Itoa(int(n)),
+		})
+	}))
+
+	return s
+}
+
+// apiHandler accepts requests with valid token, and echoes request body.
+func (s *testTokenServer) apiHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		valid := !s.reject && s.tokens[r.Header.Get("Authorization")[len("Bearer "):]]
+		s.mu.Unlock()
+
+		if !valid {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Write(body)
+	})
+}
+
+func (s *testTokenServer) revokeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = make(map[string]bool)
+}
+
+func TestOAuth2TokenSource_ClientCredentials(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestOAuth2TokenSource_ClientCredentials")
+
+	server := newTestTokenServer()
+	defer server.Close()
+
+	source := NewClientCredentialsTokenSource(OAuth2Config{
+		TokenURL:     server.URL,
+		ClientID:     "id",
+		ClientSecret: "secret",
+		Scopes:       []string{"read", "write"},
+	})
+
+	token, err := source.Token(context.Background())
+	assert.IsNil(err)
+	assert.Equal("token-1", token.AccessToken)
+	assert.Equal("Bearer", token.Type())
+
+	// token is cached.
+	token, _ = source.Token(context.Background())
+	assert.Equal("token-1", token.AccessToken)
+	assert.Equal(int32(1), atomic.LoadInt32(&server.requests))
+
+	// token is refreshed before expiry.
+	source.now = func() time.Time { return time.Now().Add(time.Hour - 5*time.Second) }
+	token, _ = source.Token(context.Background())
+	assert.Equal("token-2", token.AccessToken)
+
+	// fallback to client credentials if refresh token is invalid.
+	source.token.RefreshToken = "invalid"
+	source.Invalidate()
+	token, err = source.Token(context.Background())
+	assert.IsNil(err)
+	assert.Equal("token-4", token.AccessToken)
+
+	assert.Equal([]string{
+		"client_credentials:read write", "refresh_token:refresh-1", "refresh_token:invalid", "client_credentials:read write",
+	}, server.grants)
+
+	source = NewClientCredentialsTokenSource(OAuth2Config{TokenURL: server.URL, ClientID: "id", ClientSecret: "wrong"})
+	_, err = source.Token(context.Background())
+	var httpErr *HTTPError
+	assert.Equal(true, errors.As(err, &httpErr))
+	assert.Equal(http.StatusUnauthorized, httpErr.StatusCode)
+}
+
+func TestOAuth2TokenSource_RefreshToken(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestOAuth2TokenSource_RefreshToken")
+
+	server := newTestTokenServer()
+	defer server.Close()
+
+	config := OAuth2Config{TokenURL: server.URL, ClientID: "id", ClientSecret: "secret", AuthInParams: true}
+
+	source := NewRefreshTokenSource(config, "refresh-0")
+	token, err := source.Token(context.Background())
+	assert.IsNil(err)
+	assert.Equal("token-1", token.AccessToken)
+	assert.Equal("refresh-1", token.RefreshToken)
+
+	source = NewRefreshTokenSource(config, "invalid")
+	_, err = source.Token(context.Background())
+	assert.IsNotNil(err)
+
+	source = NewRefreshTokenSource(config, "")
+	_, err = source.Token(context.Background())
+	assert.IsNotNil(err)
+}
+
+func TestOAuth2TokenSource_Middleware(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestOAuth2TokenSource_Middleware")
+
+	server := newTestTokenServer()
+	defer server.Close()
+
+	api := httptest.NewServer(server.apiHandler())
+	defer api.Close()
+
+	source := NewClientCredentialsTokenSource(OAuth2Config{TokenURL: server.URL, ClientID: "id", ClientSecret: "secret"})
+	client := NewHttpClient().Use(source.Middleware())
+
+	body, err := Do[string](client.R().SetBody([]byte("first")), http.MethodPost, api.URL)
+	assert.IsNil(err)
+	assert.Equal("first", body)
+
+	// token is revoked by server, it's refreshed and the request is sent again.
+	server.revokeAll()
+	body, err = Do[string](client.R().SetBody([]byte("second")), http.MethodPost, api.URL)
+	assert.IsNil(err)
+	assert.Equal("second", body)
+	assert.Equal(int32(2), atomic.LoadInt32(&server.requests))
+
+	// request is sent again only once.
+	server.mu.Lock()
+	server.reject = true
+	server.mu.Unlock()
+	_, err = Do[string](client.R(), http.MethodGet, api.URL)
+	var httpErr *HTTPError
+	assert.Equal(true, errors.As(err, &httpErr))
+	assert.Equal(http.StatusUnauthorized, httpErr.StatusCode)
+}
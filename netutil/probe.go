@@ -0,0 +1,437 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license.
+
+package netutil
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/duke-git/lancet/v2/slice"
+)
+
+const (
+	// maxProbeBodySize is the max size of response body read by ProbeHTTP.
+	maxProbeBodySize = 1 << 20
+
+	defaultScanConcurrency = 100
+	defaultScanTimeout     = time.Second
+)
+
+// ProbeResult is the result of a probe.
+type ProbeResult struct {
+	// Target is the probed address, url or host.
+	Target string
+	// Success reports whether the probe succeeded, Err is the reason if it's false.
+	Success bool
+	Err     error
+	// Start is the start time of probe.
+	Start time.Time
+	// Latency is the duration of probe.
+	Latency time.Duration
+}
+
+func newProbeResult(target string) ProbeResult {
+	return ProbeResult{Target: target, Start: time.Now()}
+}
+
+// finish records the latency and error of probe.
+func (r *ProbeResult) finish(err error) {
+	r.Latency = time.Since(r.Start)
+	r.Err = err
+	r.Success = err == nil
+}
+
+// probeContext returns ctx with timeout, the timeout is not set if it's not positive.
+func probeContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// ProbeTCP checks if a tcp connection can be established to address, eg: "example.com:80".
+// Latency is the duration of connecting. The probe is stopped when ctx is done or timeout is reached,
+// the timeout is not set if it's not positive.
+func ProbeTCP(ctx context.Context, address string, timeout time.Duration) *ProbeResult {
+	ctx, cancel := probeContext(ctx, timeout)
+	defer cancel()
+
+	result := newProbeResult(address)
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	result.finish(err)
+	if conn != nil {
+		conn.Close()
+	}
+
+	return &result
+}
+
+// CertificateInfo is the information of a x509 certificate.
+type CertificateInfo struct {
+	Subject   string
+	Issuer    string
+	DNSNames  []string
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// TLSProbeResult is the result of ProbeTLS.
+type TLSProbeResult struct {
+	ProbeResult
+	// ConnectLatency is the duration of tcp connecting, HandshakeLatency is the duration of tls handshake.
+	ConnectLatency   time.Duration
+	HandshakeLatency time.Duration
+	// Version is the tls version, eg: tls.VersionTLS13.
+	Version     uint16
+	CipherSuite string
+	// Certificates is the certificate chain sent by server, the leaf certificate is the first one.
+	Certificates []CertificateInfo
+	// Expiry is the earliest expiration time of the certificates.
+	Expiry time.Time
+}
+
+// ExpiresWithin checks if any certificate expires within d, the expired certificates are included.
+func (r *TLSProbeResult) ExpiresWithin(d time.Duration) bool {
+	return len(r.Certificates) > 0 && time.Now().Add(d).After(r.Expiry)
+}
+
+// ProbeTLS performs tls handshake with address, eg: "example.com:443", and inspects the certificates.
+// The certificates are verified by config, ServerName is the host of address if it's empty. The certificates
+// are returned even if the verification fails, eg: the certificate is expired, so the expiry can be inspected.
+func ProbeTLS(ctx context.Context, address string, config *tls.Config, timeout time.Duration) *TLSProbeResult {
+	ctx, cancel := probeContext(ctx, timeout)
+	defer cancel()
+
+	result := &TLSProbeResult{ProbeResult: newProbeResult(address)}
+
+	if config == nil {
+		config = &tls.Config{}
+	}
+	config = config.Clone()
+	if config.ServerName == "" {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			result.finish(err)
+			return result
+		}
+		config.ServerName = host
+	}
+
+	// verify the certificates after handshake, so they can be inspected even if they're invalid.
+	verify := !config.InsecureSkipVerify
+	config.InsecureSkipVerify = true
+
+	var dialer net.Dialer
+	rawConn, err := dialer.DialContext(ctx, "tcp", address)
+	result.ConnectLatency = time.Since(result.Start)
+	if err != nil {
+		result.finish(err)
+		return result
+	}
+
+	handshakeStart := time.Now()
+	conn := tls.Client(rawConn, config)
+	defer conn.Close()
+	err = conn.HandshakeContext(ctx)
+	result.HandshakeLatency = time.Since(handshakeStart)
+	if err != nil {
+		result.finish(err)
+		return result
+	}
+
+	state := conn.ConnectionState()
+	result.Version = state.Version
+	result.CipherSuite = tls.CipherSuiteName(state.CipherSuite)
+
+	for _, cert := range state.PeerCertificates {
+		result.Certificates = append(result.Certificates, CertificateInfo{
+			Subject:   cert.Subject.String(),
+			Issuer:    cert.Issuer.String(),
+			DNSNames:  cert.DNSNames,
+			NotBefore: cert.NotBefore,
+			NotAfter:  cert.NotAfter,
+		})
+		if result.Expiry.IsZero() || cert.NotAfter.Before(result.Expiry) {
+			result.Expiry = cert.NotAfter
+		}
+	}
+
+	if verify {
+		err = verifyCertificates(state.PeerCertificates, config)
+	}
+	result.finish(err)
+
+	return result
+}
+
+func verifyCertificates(certs []*x509.Certificate, config *tls.Config) error {
+	if len(certs) == 0 {
+		return fmt.Errorf("tls: server %s sent no certificate", config.ServerName)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         config.RootCAs,
+		DNSName:       config.ServerName,
+		Intermediates: intermediates,
+	}
+	if config.Time != nil {
+		opts.CurrentTime = config.Time()
+	}
+
+	_, err := certs[0].Verify(opts)
+	return err
+}
+
+// HTTPProbeConfig is the config of ProbeHTTP.
+type HTTPProbeConfig struct {
+	URL string
+	// Method default is GET.
+	Method string
+	Header http.Header
+	// ExpectedStatus is the accepted status codes, any 2xx status code is accepted if it's empty.
+	ExpectedStatus []int
+	// ExpectedBody is a substring which the response body should contain, it's not checked if it's empty.
+	ExpectedBody string
+	Timeout      time.Duration
+	// Client sends the request, default is http.DefaultClient.
+	Client *http.Client
+}
+
+// HTTPProbeResult is the result of ProbeHTTP.
+type HTTPProbeResult struct {
+	ProbeResult
+	StatusCode int
+	// FirstByteLatency is the duration until the response header is received.
+	FirstByteLatency time.Duration
+}
+
+// ProbeHTTP sends a http request, and checks the status code and body of response.
+// Latency is the duration until the response body is read.
+func ProbeHTTP(ctx context.Context, config HTTPProbeConfig) *HTTPProbeResult {
+	ctx, cancel := probeContext(ctx, config.Timeout)
+	defer cancel()
+
+	result := &HTTPProbeResult{ProbeResult: newProbeResult(config.URL)}
+	result.finish(probeHTTP(ctx, config, result))
+
+	return result
+}
+
+func probeHTTP(ctx context.Context, config HTTPProbeConfig, result *HTTPProbeResult) error {
+	method := config.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	client := config.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, config.URL, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range config.Header {
+		req.Header[k] = v
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	result.FirstByteLatency = time.Since(result.Start)
+	result.StatusCode = resp.StatusCode
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxProbeBodySize))
+	if err != nil {
+		return err
+	}
+
+	if len(config.ExpectedStatus) > 0 {
+		if !slice.Contain(config.ExpectedStatus, resp.StatusCode) {
+			return fmt.Errorf("unexpected status code %d, expected %v", resp.StatusCode, config.ExpectedStatus)
+		}
+	} else if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d, expected 2xx", resp.StatusCode)
+	}
+
+	if config.ExpectedBody != "" && !strings.Contains(string(body), config.ExpectedBody) {
+		return fmt.Errorf("response body doesn't contain %q", config.ExpectedBody)
+	}
+
+	return nil
+}
+
+// DNSProbeConfig is the config of ProbeDNS.
+type DNSProbeConfig struct {
+	// Server is the address of dns server, eg: "8.8.8.8:53", the system resolver is used if it's empty.
+	// It's ignored if Resolver is set.
+	Server string
+	// Resolver resolves the host if it's not nil.
+	Resolver *net.Resolver
+	// Network is "ip", "ip4" or "ip6", default is "ip".
+	Network string
+	Timeout time.Duration
+}
+
+// DNSProbeResult is the result of ProbeDNS.
+type DNSProbeResult struct {
+	ProbeResult
+	IPs []net.IP
+}
+
+// ProbeDNS resolves the ips of host. The probe fails if no ip is resolved.
+func ProbeDNS(ctx context.Context, host string, config DNSProbeConfig) *DNSProbeResult {
+	ctx, cancel := probeContext(ctx, config.Timeout)
+	defer cancel()
+
+	result := &DNSProbeResult{ProbeResult: newProbeResult(host)}
+
+	network := config.Network
+	if network == "" {
+		network = "ip"
+	}
+
+	resolver := config.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+		if config.Server != "" {
+			resolver = &net.Resolver{
+				PreferGo: true,
+				Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+					var dialer net.Dialer
+					return dialer.DialContext(ctx, network, config.Server)
+				},
+			}
+		}
+	}
+
+	ips, err := resolver.LookupIP(ctx, network, host)
+	if err == nil && len(ips) == 0 {
+		err = fmt.Errorf("no ip of host %s", host)
+	}
+	result.IPs = ips
+	result.finish(err)
+
+	return result
+}
+
+// PortScanConfig is the config of ScanPorts.
+type PortScanConfig struct {
+	// Concurrency is the max number of concurrent connections, default is 100.
+	Concurrency int
+	// Timeout is the connect timeout of every port, default is 1s.
+	Timeout time.Duration
+}
+
+// PortResult is the result of a scanned port, the port is open if Success is true.
+type PortResult struct {
+	ProbeResult
+	Host string
+	Port int
+}
+
+// ScanPorts checks if the ports of hosts are open by tcp connecting. The results are sorted by host
+// then port, in the order of arguments. Hosts can be generated by IPRange, and ports by PortRange.
+func ScanPorts(ctx context.Context, hosts []string, ports []int, config PortScanConfig) []*PortResult {
+	if config.Concurrency <= 0 {
+		config.Concurrency = defaultScanConcurrency
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultScanTimeout
+	}
+
+	targets := make([]*PortResult, 0, len(hosts)*len(ports))
+	for _, host := range hosts {
+		for _, port := range ports {
+			targets = append(targets, &PortResult{Host: host, Port: port})
+		}
+	}
+
+	return ProbeAll(ctx, targets, config.Concurrency, func(ctx context.Context, target *PortResult) *PortResult {
+		address := net.JoinHostPort(target.Host, strconv.Itoa(target.Port))
+		target.ProbeResult = *ProbeTCP(ctx, address, config.Timeout)
+		return target
+	})
+}
+
+// OpenPorts returns the open ports of port scan results.
+func OpenPorts(results []*PortResult) []int {
+	ports := []int{}
+	for _, result := range results {
+		if result.Success {
+			ports = append(ports, result.Port)
+		}
+	}
+	return ports
+}
+
+// PortRange returns the ports from start to end, both are included.
+func PortRange(start, end int) []int {
+	if start < 1 {
+		start = 1
+	}
+	if end > 65535 {
+		end = 65535
+	}
+
+	ports := []int{}
+	for port := start; port <= end; port++ {
+		ports = append(ports, port)
+	}
+	return ports
+}
+
+// ProbeAll runs probe for every target concurrently, at most concurrency probes run at the same time.
+// The results are in the order of targets. All targets are probed with ctx even if it's done,
+// so the probe should return quickly in that case, eg: ProbeTCP.
+func ProbeAll[T any, R any](ctx context.Context, targets []T, concurrency int, probe func(ctx context.Context, target T) R) []R {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if concurrency <= 0 || concurrency > len(targets) {
+		concurrency = len(targets)
+	}
+
+	results := make([]R, len(targets))
+	semaphore := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		semaphore <- struct{}{}
+		wg.Add(1)
+
+		go func(i int, target T) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			results[i] = probe(ctx, target)
+		}(i, target)
+	}
+	wg.Wait()
+
+	return results
+}
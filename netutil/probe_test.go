@@ -0,0 +1,231 @@
+package netutil
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+// closedPort returns a local tcp port which is not listened.
+func closedPort() int {
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+	return port
+}
+
+func TestProbeTCP(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestProbeTCP")
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.IsNil(err)
+	defer listener.Close()
+
+	result := ProbeTCP(context.Background(), listener.Addr().String(), time.Second)
+	assert.Equal(true, result.Success)
+	assert.IsNil(result.Err)
+	assert.Equal(listener.Addr().String(), result.Target)
+	assert.Equal(true, result.Latency > 0)
+
+	result = ProbeTCP(context.Background(), "127.0.0.1:"+strconv.Itoa(closedPort()), time.Second)
+	assert.Equal(false, result.Success)
+	assert.IsNotNil(result.Err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result = ProbeTCP(ctx, listener.Addr().String(), 0)
+	assert.Equal(false, result.Success)
+}
+
+func TestProbeTLS(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestProbeTLS")
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	address := server.Listener.Addr().String()
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+
+	result := ProbeTLS(context.Background(), address, &tls.Config{RootCAs: roots}, time.Second)
+	assert.IsNil(result.Err)
+	assert.Equal(true, result.Success)
+	assert.Equal(true, result.Version >= tls.VersionTLS12)
+	assert.IsNotNil(result.CipherSuite)
+	assert.Equal(1, len(result.Certificates))
+	assert.Equal(server.Certificate().DNSNames, result.Certificates[0].DNSNames)
+	assert.Equal(server.Certificate().NotAfter, result.Expiry)
+	assert.Equal(false, result.ExpiresWithin(time.Hour))
+	assert.Equal(true, result.ExpiresWithin(200*365*24*time.Hour))
+
+	// the certificate is not trusted, but it's still inspected.
+	result = ProbeTLS(context.Background(), address, nil, time.Second)
+	assert.Equal(false, result.Success)
+	assert.IsNotNil(result.Err)
+	assert.Equal(1, len(result.Certificates))
+
+	// the certificate is expired.
+	expired := &tls.Config{RootCAs: roots, Time: func() time.Time { return result.Expiry.Add(time.Hour) }}
+	result = ProbeTLS(context.Background(), address, expired, time.Second)
+	assert.Equal(false, result.Success)
+	assert.IsNotNil(result.Err)
+
+	result = ProbeTLS(context.Background(), address, &tls.Config{InsecureSkipVerify: true}, time.Second)
+	assert.Equal(true, result.Success)
+
+	result = ProbeTLS(context.Background(), "127.0.0.1:"+strconv.Itoa(closedPort()), nil, time.Second)
+	assert.Equal(false, result.Success)
+	assert.Equal(0, len(result.Certificates))
+}
+
+func TestProbeHTTP(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestProbeHTTP")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			if r.Header.Get("X-Token") != "token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"status":"UP"}`))
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	config := HTTPProbeConfig{
+		URL:          server.URL + "/health",
+		Header:       http.Header{"X-Token": {"token"}},
+		ExpectedBody: `"status":"UP"`,
+		Timeout:      time.Second,
+	}
+
+	result := ProbeHTTP(context.Background(), config)
+	assert.IsNil(result.Err)
+	assert.Equal(true, result.Success)
+	assert.Equal(http.StatusOK, result.StatusCode)
+	assert.Equal(true, result.Latency >= result.FirstByteLatency)
+
+	config.ExpectedBody = "DOWN"
+	result = ProbeHTTP(context.Background(), config)
+	assert.Equal(false, result.Success)
+	assert.Equal(http.StatusOK, result.StatusCode)
+
+	result = ProbeHTTP(context.Background(), HTTPProbeConfig{URL: server.URL + "/health"})
+	assert.Equal(false, result.Success)
+	assert.Equal(http.StatusUnauthorized, result.StatusCode)
+
+	result = ProbeHTTP(context.Background(), HTTPProbeConfig{
+		URL:            server.URL + "/down",
+		Method:         http.MethodHead,
+		ExpectedStatus: []int{http.StatusServiceUnavailable},
+	})
+	assert.Equal(true, result.Success)
+
+	result = ProbeHTTP(context.Background(), HTTPProbeConfig{URL: server.URL + "/slow", Timeout: 50 * time.Millisecond})
+	assert.Equal(false, result.Success)
+	assert.Equal(0, result.StatusCode)
+}
+
+func TestProbeDNS(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestProbeDNS")
+
+	result := ProbeDNS(context.Background(), "localhost", DNSProbeConfig{Network: "ip4", Timeout: time.Second})
+	assert.IsNil(result.Err)
+	assert.Equal(true, result.Success)
+	assert.Equal(true, len(result.IPs) > 0)
+	assert.Equal(true, result.IPs[0].IsLoopback())
+
+	// the dns server doesn't respond.
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.IsNil(err)
+	defer conn.Close()
+
+	result = ProbeDNS(context.Background(), "example.com", DNSProbeConfig{
+		Server:  conn.LocalAddr().String(),
+		Timeout: 100 * time.Millisecond,
+	})
+	assert.Equal(false, result.Success)
+	assert.IsNotNil(result.Err)
+	assert.Equal(0, len(result.IPs))
+}
+
+func TestScanPorts(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestScanPorts")
+
+	var open []int
+	for i := 0; i < 2; i++ {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.IsNil(err)
+		defer listener.Close()
+		open = append(open, listener.Addr().(*net.TCPAddr).Port)
+	}
+	closed := closedPort()
+
+	ports := []int{open[0], closed, open[1]}
+	results := ScanPorts(context.Background(), []string{"127.0.0.1"}, ports, PortScanConfig{Concurrency: 2})
+
+	assert.Equal(3, len(results))
+	for i, result := range results {
+		assert.Equal("127.0.0.1", result.Host)
+		assert.Equal(ports[i], result.Port)
+	}
+	assert.Equal(open, OpenPorts(results))
+	assert.Equal(false, results[1].Success)
+
+	assert.Equal([]int{1, 2, 3}, PortRange(-1, 3))
+	assert.Equal([]int{65535}, PortRange(65535, 70000))
+	assert.Equal([]int{}, PortRange(10, 9))
+}
+
+func TestProbeAll(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestProbeAll")
+
+	var running, maxRunning int32
+	targets := []int{1, 2, 3, 4, 5, 6, 7, 8}
+
+	results := ProbeAll(context.Background(), targets, 3, func(ctx context.Context, target int) int {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return target * 10
+	})
+
+	assert.Equal([]int{10, 20, 30, 40, 50, 60, 70, 80}, results)
+	assert.Equal(true, atomic.LoadInt32(&maxRunning) <= 3)
+
+	assert.Equal(0, len(ProbeAll(context.Background(), []int{}, 0, func(ctx context.Context, target int) int {
+		return target
+	})))
+}
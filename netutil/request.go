@@ -0,0 +1,371 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license.
+
+package netutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxErrorBodySize is the max size of response body kept in HTTPError.
+const maxErrorBodySize = 4096
+
+// HTTPError is returned by Request when the response status code is not 2xx.
+// The response body is read into Body and closed.
+type HTTPError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Status     string
+	Header     http.Header
+	// Body is the first 4KB of response body.
+	Body []byte
+	// BodyIsTruncated reports whether the response body is larger than Body.
+	BodyIsTruncated bool
+}
+
+// Error implements error interface.
+func (e *HTTPError) Error() string {
+	msg := fmt.Sprintf("%s %s: %s", e.Method, e.URL, e.Status)
+	if len(e.Body) > 0 {
+		msg += ": " + string(e.Body)
+		if e.BodyIsTruncated {
+			msg += "..."
+		}
+	}
+	return msg
+}
+
+// Request is a fluent builder of http request, it's created by HttpClient.R.
+// A Request should be sent only once.
+type Request struct {
+	client  *HttpClient
+	ctx     context.Context
+	baseURL string
+	header  http.Header
+	query   url.Values
+	body    []byte
+	result  any
+	err     error
+}
+
+// R returns a new request builder of the client.
+func (client *HttpClient) R() *Request {
+	return &Request{
+		client: client,
+		header: make(http.Header),
+		query:  make(url.Values),
+	}
+}
+
+// SetContext sets the context of request, the context of client is used if it's not set.
+func (r *Request) SetContext(ctx context.Context) *Request {
+	r.ctx = ctx
+	return r
+}
+
+// SetHeader sets a request header.
+func (r *Request) SetHeader(key, value string) *Request {
+	r.header.Set(key, value)
+	return r
+}
+
+// SetHeaders sets request headers.
+func (r *Request) SetHeaders(headers map[string]string) *Request {
+	for k, v := range headers {
+		r.header.Set(k, v)
+	}
+	return r
+}
+
+// SetQuery sets a query parameter, it replaces the existing values of the key.
+func (r *Request) SetQuery(key, value string) *Request {
+	r.query.Set(key, value)
+	return r
+}
+
+// AddQuery adds a value to a query parameter.
+func (r *Request) AddQuery(key, value string) *Request {
+	r.query.Add(key, value)
+	return r
+}
+
+// SetQueryParams sets query parameters.
+func (r *Request) SetQueryParams(params map[string]string) *Request {
+	for k, v := range params {
+		r.query.Set(k, v)
+	}
+	return r
+}
+
+// SetBasicAuth sets basic authorization header.
+func (r *Request) SetBasicAuth(username, password string) *Request {
+	req := http.Request{Header: r.header}
+	req.SetBasicAuth(username, password)
+	return r
+}
+
+// SetBearerToken sets `Authorization: Bearer <token>` header.
+func (r *Request) SetBearerToken(token string) *Request {
+	r.header.Set("Authorization", "Bearer "+token)
+	return r
+}
+
+// SetBody sets the raw request body.
+func (r *Request) SetBody(body []byte) *Request {
+	r.body = body
+	return r
+}
+
+// SetJSONBody sets the request body as json of v, and sets `Content-Type: application/json` header if it's absent.
+func (r *Request) SetJSONBody(v any) *Request {
+	return r.setEncodedBody(json.Marshal, v, "application/json")
+}
+
+// SetXMLBody sets the request body as xml of v, and sets `Content-Type: application/xml` header if it's absent.
+func (r *Request) SetXMLBody(v any) *Request {
+	return r.setEncodedBody(xml.Marshal, v, "application/xml")
+}
+
+// SetFormBody sets the request body as url encoded form, and sets `Content-Type: application/x-www-form-urlencoded` header if it's absent.
+func (r *Request) SetFormBody(values url.Values) *Request {
+	r.body = []byte(values.Encode())
+	r.setContentType("application/x-www-form-urlencoded")
+	return r
+}
+
+// SetFormData sets the request body as url encoded form of data.
+func (r *Request) SetFormData(data map[string]string) *Request {
+	values := make(url.Values, len(data))
+	for k, v := range data {
+		values.Set(k, v)
+	}
+	return r.SetFormBody(values)
+}
+
+// SetResult sets the target which 2xx response body is decoded into, the decoding format is
+// decided by the `Content-Type` response header, json is used by default.
+func (r *Request) SetResult(result any) *Request {
+	r.result = result
+	return r
+}
+
+func (r *Request) setEncodedBody(marshal func(v any) ([]byte, error), v any, contentType string) *Request {
+	body, err := marshal(v)
+	if err != nil {
+		r.err = err
+		return r
+	}
+	r.body = body
+	r.setContentType(contentType)
+	return r
+}
+
+func (r *Request) setContentType(contentType string) {
+	if r.header.Get("Content-Type") == "" {
+		r.header.Set("Content-Type", contentType)
+	}
+}
+
+// Get sends GET request.
+func (r *Request) Get(rawURL string) (*http.Response, error) {
+	return r.Send(http.MethodGet, rawURL)
+}
+
+// Post sends POST request.
+func (r *Request) Post(rawURL string) (*http.Response, error) {
+	return r.Send(http.MethodPost, rawURL)
+}
+
+// Put sends PUT request.
+func (r *Request) Put(rawURL string) (*http.Response, error) {
+	return r.Send(http.MethodPut, rawURL)
+}
+
+// Patch sends PATCH request.
+func (r *Request) Patch(rawURL string) (*http.Response, error) {
+	return r.Send(http.MethodPatch, rawURL)
+}
+
+// Delete sends DELETE request.
+func (r *Request) Delete(rawURL string) (*http.Response, error) {
+	return r.Send(http.MethodDelete, rawURL)
+}
+
+// Head sends HEAD request.
+func (r *Request) Head(rawURL string) (*http.Response, error) {
+	return r.Send(http.MethodHead, rawURL)
+}
+
+// Send sends the request with method. If the response status code is not 2xx, an *HTTPError is returned
+// with the response. If result is set by SetResult, the response body is decoded into it and closed,
+// otherwise the caller should close the response body.
+func (r *Request) Send(method, rawURL string) (*http.Response, error) {
+	resp, err := r.do(method, rawURL)
+	if err != nil {
+		return resp, err
+	}
+
+	if r.result != nil {
+		defer resp.Body.Close()
+		if err := decodeBody(resp, r.result); err != nil {
+			return resp, err
+		}
+	}
+
+	return resp, nil
+}
+
+// Do sends the request with method, and decodes the 2xx response body into T, the decoding format
+// is decided by the `Content-Type` response header, json is used by default. If T is []byte or string,
+// the raw body is returned. If the response status code is not 2xx, an *HTTPError is returned.
+func Do[T any](r *Request, method, rawURL string) (T, error) {
+	var result T
+
+	resp, err := r.do(method, rawURL)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+
+	err = decodeBody(resp, &result)
+
+	return result, err
+}
+
+// do sends the request, and checks the response status code.
+func (r *Request) do(method, rawURL string) (*http.Response, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if r.baseURL != "" {
+		rawURL = joinURL(r.baseURL, rawURL)
+	}
+
+	if err := validateRequest(&HttpRequest{RawURL: rawURL, Method: method}); err != nil {
+		return nil, err
+	}
+
+	var body io.Reader
+	if r.body != nil {
+		body = bytes.NewReader(r.body)
+	}
+
+	req, err := r.client.newRequest(r.ctx, method, rawURL, body, r.header)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.query) > 0 {
+		query := req.URL.Query()
+		for k, v := range r.query {
+			query[k] = v
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+
+	return r.client.send(req)
+}
+
+// newRequest creates a request with headers, ctx is the context of client if it's nil.
+func (client *HttpClient) newRequest(ctx context.Context, method, rawURL string, body io.Reader, headers http.Header) (*http.Request, error) {
+	if ctx == nil {
+		ctx = client.Context
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(method), rawURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	client.setHeader(req, headers.Clone())
+
+	return req, nil
+}
+
+// send sends the request, an *HTTPError is returned with the response if the status code is not 2xx.
+func (client *HttpClient) send(req *http.Request) (*http.Response, error) {
+	return client.sendWith(client.Client, req)
+}
+
+// stream sends the request without client timeout, which would interrupt reading a long-lived response body.
+func (client *HttpClient) stream(req *http.Request) (*http.Response, error) {
+	httpClient := *client.Client
+	httpClient.Timeout = 0
+	return client.sendWith(&httpClient, req)
+}
+
+func (client *HttpClient) sendWith(httpClient *http.Client, req *http.Request) (*http.Response, error) {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp, newHTTPError(req, resp)
+	}
+
+	return resp, nil
+}
+
+// newHTTPError reads the response body into HTTPError, and closes it.
+func newHTTPError(req *http.Request, resp *http.Response) *HTTPError {
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize+1))
+	truncated := len(body) > maxErrorBodySize
+	if truncated {
+		body = body[:maxErrorBodySize]
+	}
+
+	return &HTTPError{
+		Method:          req.Method,
+		URL:             req.URL.String(),
+		StatusCode:      resp.StatusCode,
+		Status:          resp.Status,
+		Header:          resp.Header,
+		Body:            body,
+		BodyIsTruncated: truncated,
+	}
+}
+
+// decodeBody decodes response body into target by content type.
+func decodeBody(resp *http.Response, target any) error {
+	switch t := target.(type) {
+	case *[]byte:
+		body, err := io.ReadAll(resp.Body)
+		*t = body
+		return err
+	case *string:
+		body, err := io.ReadAll(resp.Body)
+		*t = string(body)
+		return err
+	}
+
+	var err error
+	if strings.Contains(resp.Header.Get("Content-Type"), "xml") {
+		err = xml.NewDecoder(resp.Body).Decode(target)
+	} else {
+		err = json.NewDecoder(resp.Body).Decode(target)
+	}
+
+	// empty body, eg: 204 No Content and HEAD response.
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,154 @@
+package netutil
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+type testTodo struct {
+	XMLName xml.Name `json:"-" xml:"todo"`
+	ID      int      `json:"id" xml:"id"`
+	Title   string   `json:"title" xml:"title"`
+}
+
+func newTodoServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/todo":
+			todo := testTodo{ID: 1, Title: r.URL.Query().Get("title")}
+			if r.Header.Get("Accept") == "application/xml" {
+				w.Header().Set("Content-Type", "application/xml")
+				xml.NewEncoder(w).Encode(todo)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(todo)
+		case "/echo":
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+			w.Header().Set("X-Auth", r.Header.Get("Authorization"))
+			w.Write(body)
+		case "/empty":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Header().Set("X-Error", "not found")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(strings.Repeat("e", maxErrorBodySize+1)))
+		}
+	}))
+}
+
+func TestRequest(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRequest")
+
+	server := newTodoServer()
+	defer server.Close()
+
+	client := NewHttpClient()
+
+	var todo testTodo
+	resp, err := client.R().SetQuery("title", "go").SetResult(&todo).Get(server.URL + "/todo")
+	assert.IsNil(err)
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal(testTodo{ID: 1, Title: "go"}, todo)
+
+	todo = testTodo{}
+	_, err = client.R().SetHeader("Accept", "application/xml").SetQueryParams(map[string]string{"title": "xml"}).
+		SetResult(&todo).Get(server.URL + "/todo?id=1")
+	assert.IsNil(err)
+	assert.Equal("xml", todo.Title)
+
+	// caller reads the body if result is not set.
+	resp, err = client.R().SetBody([]byte("raw")).SetBearerToken("token").Post(server.URL + "/echo")
+	assert.IsNil(err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal("raw", string(body))
+	assert.Equal("Bearer token", resp.Header.Get("X-Auth"))
+
+	resp, err = client.R().Delete(server.URL + "/empty")
+	assert.IsNil(err)
+	resp.Body.Close()
+	assert.Equal(http.StatusNoContent, resp.StatusCode)
+}
+
+func TestRequest_Body(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRequest_Body")
+
+	server := newTodoServer()
+	defer server.Close()
+
+	client := NewHttpClient()
+	todo := testTodo{ID: 2, Title: "lancet"}
+
+	result, err := Do[testTodo](client.R().SetJSONBody(todo), http.MethodPost, server.URL+"/echo")
+	assert.IsNil(err)
+	assert.Equal(todo.Title, result.Title)
+
+	result, err = Do[testTodo](client.R().SetXMLBody(todo), http.MethodPut, server.URL+"/echo")
+	assert.IsNil(err)
+	assert.Equal(todo.Title, result.Title)
+
+	form, err := Do[string](client.R().SetFormData(map[string]string{"a": "1", "b": "x y"}), http.MethodPatch, server.URL+"/echo")
+	assert.IsNil(err)
+	assert.Equal("a=1&b=x+y", form)
+
+	raw, err := Do[[]byte](client.R().SetBasicAuth("user", "pass").SetJSONBody(map[string]int{"a": 1}), http.MethodPost, server.URL+"/echo")
+	assert.IsNil(err)
+	assert.Equal(`{"a":1}`, string(raw))
+
+	// Content-Type header set by user is kept.
+	resp, err := client.R().SetHeader("Content-Type", "application/vnd.api+json").SetJSONBody(todo).Post(server.URL + "/echo")
+	assert.IsNil(err)
+	resp.Body.Close()
+	assert.Equal("application/vnd.api+json", resp.Header.Get("Content-Type"))
+
+	_, err = Do[testTodo](client.R().SetJSONBody(make(chan int)), http.MethodPost, server.URL+"/echo")
+	assert.IsNotNil(err)
+
+	empty, err := Do[*testTodo](client.R(), http.MethodGet, server.URL+"/empty")
+	assert.IsNil(err)
+	assert.IsNil(empty)
+}
+
+func TestRequest_HTTPError(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRequest_HTTPError")
+
+	server := newTodoServer()
+	defer server.Close()
+
+	client := NewHttpClient()
+
+	resp, err := client.R().Get(server.URL + "/missing")
+	assert.Equal(http.StatusNotFound, resp.StatusCode)
+
+	var httpErr *HTTPError
+	assert.Equal(true, errors.As(err, &httpErr))
+	assert.Equal(http.StatusNotFound, httpErr.StatusCode)
+	assert.Equal("not found", httpErr.Header.Get("X-Error"))
+	assert.Equal(maxErrorBodySize, len(httpErr.Body))
+	assert.Equal(true, httpErr.BodyIsTruncated)
+	assert.Equal(true, strings.HasPrefix(err.Error(), "GET "+server.URL+"/missing: 404 Not Found: eee"))
+
+	_, err = Do[testTodo](client.R(), http.MethodGet, server.URL+"/missing")
+	assert.Equal(true, errors.As(err, &httpErr))
+
+	_, err = client.R().Send("INVALID", server.URL)
+	assert.IsNotNil(err)
+	_, err = client.R().Get("")
+	assert.IsNotNil(err)
+}
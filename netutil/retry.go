@@ -0,0 +1,235 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license.
+
+package netutil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/duke-git/lancet/v2/retry"
+)
+
+// HttpRetryConfig is the retry config of HttpClient.
+type HttpRetryConfig struct {
+	// MaxAttempts is the max times of sending a request including the first one, default is 3.
+	MaxAttempts int
+	// Backoff creates the backoff strategy for each request,
+	// default is exponential backoff from 100ms with base 2 and max jitter 100ms.
+	Backoff func() retry.BackoffStrategy
+	// MaxBackoff is the max wait time between attempts, default is 30s.
+	// response with larger Retry-After is not retried.
+	MaxBackoff time.Duration
+	// RetryableStatus is the response status codes to retry, default is 429, 502, 503 and 504.
+	RetryableStatus []int
+	// RetryNonIdempotent retries requests of non-idempotent methods, eg: POST and PATCH.
+	// by default, they are retried only if `Idempotency-Key` header is set.
+	RetryNonIdempotent bool
+	// ShouldRetry overrides the default check of whether to retry the response or error.
+	ShouldRetry func(resp *http.Response, err error) bool
+}
+
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryMaxBackoff  = 30 * time.Second
+)
+
+var defaultRetryableStatus = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// Retry retries failed requests with backoff. Retry-After header of 429 and 503 responses is honored,
+// request body is buffered, so it can be sent again.
+func Retry(config HttpRetryConfig) Middleware {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultRetryMaxAttempts
+	}
+	if config.Backoff == nil {
+		config.Backoff = func() retry.BackoffStrategy {
+			return retry.NewExponentialWithJitterBackoff(100*time.Millisecond, 2, 100*time.Millisecond)
+		}
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaultRetryMaxBackoff
+	}
+	if config.RetryableStatus == nil {
+		config.RetryableStatus = defaultRetryableStatus
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if config.MaxAttempts == 1 || (!config.RetryNonIdempotent && !isIdempotent(req)) {
+				return next.RoundTrip(req)
+			}
+
+			req = cloneRequest(req)
+			if err := bufferRequestBody(req); err != nil {
+				return nil, err
+			}
+
+			backoff := config.Backoff()
+			for attempt := 1; ; attempt++ {
+				r, err := rewindRequest(req)
+				if err != nil {
+					return nil, err
+				}
+
+				resp, err := next.RoundTrip(r)
+				if attempt >= config.MaxAttempts || !config.shouldRetry(req, resp, err) {
+					if err != nil && attempt > 1 {
+						err = fmt.Errorf("request failed after %d attempts: %w", attempt, err)
+					}
+					return resp, err
+				}
+
+				wait := backoff.CalculateInterval()
+				if resp != nil {
+					if retryAfter, ok := parseRetryAfter(resp); ok {
+						if retryAfter > config.MaxBackoff {
+							return resp, nil
+						}
+						wait = retryAfter
+					}
+				}
+				if wait > config.MaxBackoff {
+					wait = config.MaxBackoff
+				}
+
+				if resp != nil {
+					drainBody(resp.Body)
+				}
+
+				if err := sleepContext(req.Context(), wait); err != nil {
+					return nil, err
+				}
+			}
+		})
+	}
+}
+
+func (config *HttpRetryConfig) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+
+	if config.ShouldRetry != nil {
+		return config.ShouldRetry(resp, err)
+	}
+
+	if err != nil {
+		return true
+	}
+
+	for _, status := range config.RetryableStatus {
+		if resp.StatusCode == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isIdempotent checks if the request can be sent more than once safely.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// bufferRequestBody reads the body into memory if the request can not get a new copy of body.
+func bufferRequestBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.Body, _ = req.GetBody()
+
+	return nil
+}
+
+// rewindRequest returns a copy of request with a new body.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody != nil && req.Body != nil && req.Body != http.NoBody {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	return r, nil
+}
+
+// parseRetryAfter parses Retry-After header of 429 and 503 responses, which is seconds or http date.
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		wait := time.Until(t)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// drainBody reads a part of body and closes it, so the connection can be reused.
+func drainBody(body io.ReadCloser) {
+	if body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, 4096))
+	body.Close()
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,148 @@
+package netutil
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+	"github.com/duke-git/lancet/v2/retry"
+)
+
+func noBackoff() retry.BackoffStrategy {
+	return retry.NewLinearBackoff(time.Millisecond)
+}
+
+func TestRetryMiddleware(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRetryMiddleware")
+
+	var count int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if atomic.AddInt32(&count, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := NewHttpClientWithConfig(&HttpClientConfig{
+		Retry: &HttpRetryConfig{MaxAttempts: 3, Backoff: noBackoff},
+	})
+
+	resp, err := client.SendRequest(&HttpRequest{RawURL: server.URL, Method: "PUT", Body: []byte("data")})
+	assert.IsNil(err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal("ok", string(body))
+	assert.Equal([]string{"data", "data", "data"}, bodies)
+}
+
+func TestRetryMiddleware_Idempotent(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRetryMiddleware_Idempotent")
+
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&count, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewHttpClient().Use(Retry(HttpRetryConfig{MaxAttempts: 3, Backoff: noBackoff}))
+
+	// POST is not retried by default.
+	resp, err := client.SendRequest(&HttpRequest{RawURL: server.URL, Method: "POST", Body: []byte("a")})
+	assert.IsNil(err)
+	resp.Body.Close()
+	assert.Equal(int32(1), atomic.LoadInt32(&count))
+
+	// POST with Idempotency-Key is retried, form data body is replayed.
+	header := http.Header{}
+	header.Set("Idempotency-Key", "key")
+	resp, err = client.SendRequest(&HttpRequest{RawURL: server.URL, Method: "POST", Headers: header, FormData: map[string][]string{"a": {"1"}}})
+	assert.IsNil(err)
+	resp.Body.Close()
+	assert.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(int32(4), atomic.LoadInt32(&count))
+
+	client = NewHttpClient().Use(Retry(HttpRetryConfig{MaxAttempts: 2, Backoff: noBackoff, RetryNonIdempotent: true}))
+	resp, err = client.SendRequest(&HttpRequest{RawURL: server.URL, Method: "PATCH"})
+	assert.IsNil(err)
+	resp.Body.Close()
+	assert.Equal(int32(6), atomic.LoadInt32(&count))
+}
+
+func TestRetryMiddleware_RetryAfter(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRetryMiddleware_RetryAfter")
+
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&count, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewHttpClient().Use(Retry(HttpRetryConfig{Backoff: noBackoff}))
+
+	start := time.Now()
+	resp, err := client.SendRequest(&HttpRequest{RawURL: server.URL, Method: "GET"})
+	assert.IsNil(err)
+	resp.Body.Close()
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal(true, time.Since(start) >= time.Second)
+
+	// Retry-After larger than MaxBackoff is not retried.
+	atomic.StoreInt32(&count, 0)
+	client = NewHttpClient().Use(Retry(HttpRetryConfig{Backoff: noBackoff, MaxBackoff: 100 * time.Millisecond}))
+	resp, err = client.SendRequest(&HttpRequest{RawURL: server.URL, Method: "GET"})
+	assert.IsNil(err)
+	resp.Body.Close()
+	assert.Equal(http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(int32(1), atomic.LoadInt32(&count))
+}
+
+func TestRetryMiddleware_Error(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRetryMiddleware_Error")
+
+	var count int32
+	failing := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&count, 1)
+		return nil, errors.New("connection refused")
+	})
+
+	transport := ChainMiddlewares(failing, Retry(HttpRetryConfig{MaxAttempts: 3, Backoff: noBackoff}))
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	_, err := transport.RoundTrip(req)
+	assert.Equal("request failed after 3 attempts: connection refused", err.Error())
+	assert.Equal(int32(3), atomic.LoadInt32(&count))
+
+	// canceled context stops retrying.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com", nil)
+	_, err = transport.RoundTrip(req)
+	assert.IsNotNil(err)
+	assert.Equal(int32(4), atomic.LoadInt32(&count))
+}
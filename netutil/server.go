@@ -0,0 +1,196 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license.
+
+package netutil
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/duke-git/lancet/v2/validator"
+	"github.com/duke-git/lancet/v2/xerror"
+)
+
+// Error codes of server helpers, they're registered to xerror, so the http status is decided by xerror.HTTPStatus.
+var (
+	ErrCodeBadRequest = xerror.MustRegisterCode(xerror.ErrorCode{
+		Code: "HTTP_BAD_REQUEST", Message: "bad request", Category: xerror.CategoryInvalidArgument,
+	})
+	ErrCodeValidation = xerror.MustRegisterCode(xerror.ErrorCode{
+		Code: "HTTP_VALIDATION_FAILED", Message: "validation failed", Category: xerror.CategoryInvalidArgument,
+	})
+	ErrCodeUnsupportedMediaType = xerror.MustRegisterCode(xerror.ErrorCode{
+		Code: "HTTP_UNSUPPORTED_MEDIA_TYPE", Message: "unsupported media type",
+		Category: xerror.CategoryInvalidArgument, HTTPStatus: http.StatusUnsupportedMediaType,
+	})
+	ErrCodeRequestTooLarge = xerror.MustRegisterCode(xerror.ErrorCode{
+		Code: "HTTP_REQUEST_TOO_LARGE", Message: "request body too large",
+		Category: xerror.CategoryInvalidArgument, HTTPStatus: http.StatusRequestEntityTooLarge,
+	})
+	ErrCodeForbidden = xerror.MustRegisterCode(xerror.ErrorCode{
+		Code: "HTTP_FORBIDDEN", Message: "forbidden", Category: xerror.CategoryPermissionDenied,
+	})
+	ErrCodeTimeout = xerror.MustRegisterCode(xerror.ErrorCode{
+		Code: "HTTP_TIMEOUT", Message: "request timeout",
+		Category: xerror.CategoryTimeout, HTTPStatus: http.StatusServiceUnavailable,
+	})
+	ErrCodeInternal = xerror.MustRegisterCode(xerror.ErrorCode{
+		Code: "HTTP_INTERNAL", Message: "internal server error", Category: xerror.CategoryInternal,
+	})
+)
+
+// SuccessCode is the code of successful response envelope.
+const SuccessCode = "OK"
+
+// DefaultMaxBodySize is the max size of request body read by BindJSON.
+const DefaultMaxBodySize = 1 << 20
+
+// Response is the json envelope of server response.
+type Response struct {
+	// Code is SuccessCode or the error code.
+	Code    string `json:"code"`
+	Message string `json:"message,omitempty"`
+	Data    any    `json:"data,omitempty"`
+	// Errors is the messages of invalid fields, the key is field name.
+	Errors map[string]string `json:"errors,omitempty"`
+	// RequestID is the request id header of request, see RequestID middleware.
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// WriteJSON writes v as json response with status code.
+func WriteJSON(w http.ResponseWriter, status int, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_, err = w.Write(append(data, '\n'))
+
+	return err
+}
+
+// WriteData writes data in Response envelope with status 200.
+func WriteData(w http.ResponseWriter, r *http.Request, data any) error {
+	return WriteJSON(w, http.StatusOK, &Response{
+		Code:      SuccessCode,
+		Data:      data,
+		RequestID: requestIDOf(r),
+	})
+}
+
+// WriteError writes err in Response envelope, the status code and error code are decided by the xerror
+// code of err. The message of error without registered code is hidden, ErrCodeInternal is written instead.
+// Fields of validator.ValidationErrors are written to Errors.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) error {
+	resp := &Response{RequestID: requestIDOf(r)}
+
+	code, ok := xerror.LookupCode(xerror.CodeOf(err))
+	if !ok {
+		code = ErrCodeInternal
+	}
+	resp.Code = code.Code
+
+	if xerr, found := xerror.As(err, code.Code); found && ok {
+		resp.Message = xerr.Info().Message
+	}
+	if resp.Message == "" {
+		resp.Message = code.Message
+	}
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		resp.Errors = validationErrors.Map()
+	}
+
+	return WriteJSON(w, code.HTTPStatus, resp)
+}
+
+// HandlerWithError converts a handler returning error to http.HandlerFunc, the error is written by WriteError.
+func HandlerWithError(fn func(w http.ResponseWriter, r *http.Request) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := fn(w, r); err != nil {
+			WriteError(w, r, err)
+		}
+	}
+}
+
+func requestIDOf(r *http.Request) string {
+	if r == nil {
+		return ""
+	}
+	return r.Header.Get(DefaultRequestIDHeader)
+}
+
+// BindJSON decodes json request body into target, and validates target by `validate` tag if it's a struct,
+// see validator.ValidateStruct. The body size is limited by DefaultMaxBodySize. The returned error has
+// xerror code, it can be written by WriteError directly.
+func BindJSON(w http.ResponseWriter, r *http.Request, target any) error {
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+			return ErrCodeUnsupportedMediaType.New("content type %q is not json", contentType)
+		}
+	}
+
+	body := http.MaxBytesReader(w, r.Body, DefaultMaxBodySize)
+	decoder := json.NewDecoder(body)
+
+	if err := decoder.Decode(target); err != nil {
+		return bindError(err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		if err != nil {
+			return bindError(err)
+		}
+		return ErrCodeBadRequest.New("request body must contain a single json value")
+	}
+
+	value := reflect.ValueOf(target)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	if err := validator.ValidateStruct(target); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			return ErrCodeValidation.Wrap(err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// bindError converts json decoding error to XError with code.
+func bindError(err error) error {
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+
+	switch {
+	case errors.Is(err, io.EOF):
+		return ErrCodeBadRequest.New("request body is empty")
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return ErrCodeBadRequest.New("request body is malformed json")
+	case errors.As(err, &syntaxError):
+		return ErrCodeBadRequest.New("request body is malformed json at offset %d", syntaxError.Offset)
+	case errors.As(err, &typeError):
+		if typeError.Field != "" {
+			return ErrCodeBadRequest.New("field %q should be %s", typeError.Field, typeError.Type)
+		}
+		return ErrCodeBadRequest.New("request body should be %s", typeError.Type)
+	case err.Error() == "http: request body too large":
+		return ErrCodeRequestTooLarge.New("request body should not be larger than %d bytes", DefaultMaxBodySize)
+	}
+
+	return ErrCodeBadRequest.Wrap(err)
+}
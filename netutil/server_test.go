@@ -0,0 +1,129 @@
+package netutil
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+	"github.com/duke-git/lancet/v2/xerror"
+)
+
+type testSignup struct {
+	Name  string `json:"name" validate:"required,min=3"`
+	Email string `json:"email" validate:"required,email"`
+	Age   int    `json:"age" validate:"gte=18"`
+}
+
+func serveBind(body, contentType string) (*httptest.ResponseRecorder, *testSignup) {
+	var signup testSignup
+	handler := HandlerWithError(func(w http.ResponseWriter, r *http.Request) error {
+		if err := BindJSON(w, r, &signup); err != nil {
+			return err
+		}
+		return WriteData(w, r, signup)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set(DefaultRequestIDHeader, "req-1")
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	return recorder, &signup
+}
+
+func decodeResponse(recorder *httptest.ResponseRecorder) *Response {
+	var resp Response
+	json.Unmarshal(recorder.Body.Bytes(), &resp)
+	return &resp
+}
+
+func TestBindJSON(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBindJSON")
+
+	recorder, signup := serveBind(`{"name":"lancet","email":"a@b.com","age":20}`, "application/json; charset=utf-8")
+	assert.Equal(http.StatusOK, recorder.Code)
+	assert.Equal("application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.Equal("lancet", signup.Name)
+
+	resp := decodeResponse(recorder)
+	assert.Equal(SuccessCode, resp.Code)
+	assert.Equal("req-1", resp.RequestID)
+	assert.Equal(map[string]any{"name": "lancet", "email": "a@b.com", "age": float64(20)}, resp.Data)
+
+	recorder, _ = serveBind(`{"name":"a","email":"a","age":10}`, "")
+	assert.Equal(http.StatusBadRequest, recorder.Code)
+	resp = decodeResponse(recorder)
+	assert.Equal(ErrCodeValidation.Code, resp.Code)
+	assert.Equal("validation failed", resp.Message)
+	assert.Equal(3, len(resp.Errors))
+	assert.Equal("Name must be at least 3", resp.Errors["Name"])
+
+	tests := []struct {
+		body        string
+		contentType string
+		status      int
+		code        string
+		message     string
+	}{
+		{"", "", http.StatusBadRequest, ErrCodeBadRequest.Code, "request body is empty"},
+		{`{"name":`, "", http.StatusBadRequest, ErrCodeBadRequest.Code, "request body is malformed json"},
+		{`{"name":x}`, "", http.StatusBadRequest, ErrCodeBadRequest.Code, "request body is malformed json at offset 9"},
+		{`{"age":"x"}`, "", http.StatusBadRequest, ErrCodeBadRequest.Code, `field "age" should be int`},
+		{`[]`, "", http.StatusBadRequest, ErrCodeBadRequest.Code, "request body should be netutil.testSignup"},
+		{`{} {}`, "", http.StatusBadRequest, ErrCodeBadRequest.Code, "request body must contain a single json value"},
+		{`{}`, "text/plain", http.StatusUnsupportedMediaType, ErrCodeUnsupportedMediaType.Code, `content type "text/plain" is not json`},
+		{`"` + strings.Repeat("a", DefaultMaxBodySize) + `"`, "application/merge-patch+json", http.StatusRequestEntityTooLarge,
+			ErrCodeRequestTooLarge.Code, "request body should not be larger than 1048576 bytes"},
+	}
+
+	for _, tt := range tests {
+		recorder, _ = serveBind(tt.body, tt.contentType)
+		resp = decodeResponse(recorder)
+		assert.Equal(tt.status, recorder.Code)
+		assert.Equal(tt.code, resp.Code)
+		assert.Equal(tt.message, resp.Message)
+	}
+}
+
+var testUserNotFound = xerror.MustRegisterCode(xerror.ErrorCode{
+	Code: "TEST_USER_NOT_FOUND", Message: "user not found", Category: xerror.CategoryNotFound,
+})
+
+func TestWriteError(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestWriteError")
+
+	recorder := httptest.NewRecorder()
+	WriteError(recorder, nil, testUserNotFound.Wrap(errors.New("sql: no rows")))
+	resp := decodeResponse(recorder)
+	assert.Equal(http.StatusNotFound, recorder.Code)
+	assert.Equal("TEST_USER_NOT_FOUND", resp.Code)
+	assert.Equal("user not found", resp.Message)
+
+	// message of unknown error is hidden.
+	recorder = httptest.NewRecorder()
+	WriteError(recorder, nil, errors.New("password=123"))
+	resp = decodeResponse(recorder)
+	assert.Equal(http.StatusInternalServerError, recorder.Code)
+	assert.Equal(ErrCodeInternal.Code, resp.Code)
+	assert.Equal("internal server error", resp.Message)
+
+	recorder = httptest.NewRecorder()
+	WriteError(recorder, nil, xerror.New("unknown code").WithCode("NOT_REGISTERED"))
+	resp = decodeResponse(recorder)
+	assert.Equal(ErrCodeInternal.Code, resp.Code)
+	assert.Equal("internal server error", resp.Message)
+
+	assert.IsNotNil(WriteJSON(httptest.NewRecorder(), http.StatusOK, make(chan int)))
+}
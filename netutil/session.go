@@ -0,0 +1,126 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license.
+
+package netutil
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Session sends requests with base url and default headers, the cookies are kept by the cookie jar of client.
+// It's safe for concurrent use.
+type Session struct {
+	client  *HttpClient
+	baseURL string
+
+	mu      sync.RWMutex
+	headers http.Header
+	query   url.Values
+}
+
+// NewSession returns a session of client. If client is nil, a new client with in-memory CookieJar is used.
+// Relative urls of requests are joined to baseURL.
+func NewSession(client *HttpClient, baseURL string) *Session {
+	if client == nil {
+		jar, _ := NewCookieJar("")
+		client = NewHttpClient()
+		client.Client.Jar = jar
+	}
+
+	return &Session{
+		client:  client,
+		baseURL: baseURL,
+		headers: make(http.Header),
+		query:   make(url.Values),
+	}
+}
+
+// Client returns the http client of session.
+func (s *Session) Client() *HttpClient {
+	return s.client
+}
+
+// BaseURL returns the base url of session.
+func (s *Session) BaseURL() string {
+	return s.baseURL
+}
+
+// SetHeader sets a default header which is sent with every request.
+func (s *Session) SetHeader(key, value string) *Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.headers.Set(key, value)
+	return s
+}
+
+// DeleteHeader deletes a default header.
+func (s *Session) DeleteHeader(key string) *Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.headers.Del(key)
+	return s
+}
+
+// SetQuery sets a default query parameter which is sent with every request.
+func (s *Session) SetQuery(key, value string) *Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.query.Set(key, value)
+	return s
+}
+
+// SetBearerToken sets default `Authorization: Bearer <token>` header.
+func (s *Session) SetBearerToken(token string) *Session {
+	return s.SetHeader("Authorization", "Bearer "+token)
+}
+
+// Cookies returns the cookies of url in the cookie jar of client, url is joined to base url if it's relative.
+func (s *Session) Cookies(rawURL string) []*http.Cookie {
+	if s.client.Client.Jar == nil {
+		return nil
+	}
+
+	u, err := url.Parse(joinURL(s.baseURL, rawURL))
+	if err != nil {
+		return nil
+	}
+
+	return s.client.Client.Jar.Cookies(u)
+}
+
+// R returns a request builder with base url and default headers of session, the headers can be overridden.
+func (s *Session) R() *Request {
+	r := s.client.R()
+	r.baseURL = s.baseURL
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r.header = s.headers.Clone()
+	for k, v := range s.query {
+		r.query[k] = append([]string{}, v...)
+	}
+
+	return r
+}
+
+// joinURL joins relative url to base url, rawURL is returned if it's absolute.
+func joinURL(baseURL, rawURL string) string {
+	if baseURL == "" || strings.Contains(rawURL, "://") {
+		return rawURL
+	}
+	if rawURL == "" {
+		return baseURL
+	}
+	if strings.HasPrefix(rawURL, "?") {
+		return baseURL + rawURL
+	}
+
+	return strings.TrimRight(baseURL, "/") + "/" + strings.TrimLeft(rawURL, "/")
+}
@@ -0,0 +1,79 @@
+package netutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestSession(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSession")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/login" {
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc"})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"path":"` + r.URL.Path + `","query":"` + r.URL.RawQuery + `","app":"` + r.Header.Get("X-App") +
+			`","auth":"` + r.Header.Get("Authorization") + `","cookie":"` + r.Header.Get("Cookie") + `"}`))
+	}))
+	defer server.Close()
+
+	session := NewSession(nil, server.URL+"/api/")
+	session.SetHeader("X-App", "lancet").SetBearerToken("token").SetQuery("v", "1")
+	assert.Equal(server.URL+"/api/", session.BaseURL())
+
+	_, err := session.R().Post("/login")
+	assert.IsNil(err)
+	assert.Equal(1, len(session.Cookies("/login")))
+
+	result, err := Do[map[string]string](session.R().SetHeader("X-App", "override"), http.MethodGet, "users?id=2")
+	assert.IsNil(err)
+	assert.Equal(map[string]string{
+		"path":   "/api/users",
+		"query":  "id=2&v=1",
+		"app":    "override",
+		"auth":   "Bearer token",
+		"cookie": "session=abc",
+	}, result)
+
+	// default headers are not changed by request.
+	session.DeleteHeader("Authorization")
+	result, err = Do[map[string]string](session.R(), http.MethodGet, server.URL+"/other")
+	assert.IsNil(err)
+	assert.Equal("/other", result["path"])
+	assert.Equal("lancet", result["app"])
+	assert.Equal("", result["auth"])
+
+	// session without cookie jar.
+	session = NewSession(NewHttpClient(), server.URL)
+	assert.Equal(0, len(session.Cookies("/")))
+	assert.IsNotNil(session.Client())
+}
+
+func TestJoinURL(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestJoinURL")
+
+	tests := []struct {
+		base     string
+		url      string
+		expected string
+	}{
+		{"", "/a", "/a"},
+		{"http://a.com", "http://b.com/x", "http://b.com/x"},
+		{"http://a.com", "", "http://a.com"},
+		{"http://a.com/api", "?x=1", "http://a.com/api?x=1"},
+		{"http://a.com/api/", "/users", "http://a.com/api/users"},
+		{"http://a.com/api", "users/1?x=1", "http://a.com/api/users/1?x=1"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(tt.expected, joinURL(tt.base, tt.url))
+	}
+}
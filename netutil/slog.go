@@ -0,0 +1,43 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license.
+
+//go:build go1.21
+
+package netutil
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogLogging logs requests by slog logger, failed requests are logged at error level.
+// if withBody is true, the first 4KB of request and response body are logged.
+func SlogLogging(logger *slog.Logger, withBody bool) Middleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return Logging(func(entry *RequestLog) {
+		attrs := []slog.Attr{
+			slog.String("method", entry.Method),
+			slog.String("url", entry.URL),
+			slog.Duration("duration", entry.Duration),
+		}
+
+		if entry.Err != nil {
+			attrs = append(attrs, slog.String("error", entry.Err.Error()))
+			logger.LogAttrs(context.Background(), slog.LevelError, "http request failed", attrs...)
+			return
+		}
+
+		attrs = append(attrs, slog.Int("status", entry.StatusCode))
+		if withBody {
+			attrs = append(attrs,
+				slog.String("request_body", string(entry.RequestBody)),
+				slog.String("response_body", string(entry.ResponseBody)),
+				slog.Bool("body_truncated", entry.BodyIsTruncated),
+			)
+		}
+		logger.LogAttrs(context.Background(), slog.LevelInfo, "http request", attrs...)
+	}, withBody)
+}
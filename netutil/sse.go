@@ -0,0 +1,353 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license.
+
+package netutil
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SSEEvent is an event of Server-Sent Events.
+type SSEEvent struct {
+	// ID is the last event id, it's kept for the following events until a new id is received.
+	ID string
+	// Event is the event type, default is "message".
+	Event string
+	Data  string
+	// Retry is the reconnection time sent with the event, it's 0 if not set.
+	Retry time.Duration
+}
+
+// defaultSSEReconnectDelay is the default wait time before reconnecting.
+const defaultSSEReconnectDelay = 3 * time.Second
+
+// maxSSELineSize is the max size of a line in event stream.
+const maxSSELineSize = 1 << 20
+
+// SSEClient subscribes Server-Sent Events, it reconnects with `Last-Event-ID` header when the connection is lost.
+type SSEClient struct {
+	client *HttpClient
+	rawURL string
+
+	// Headers is sent with every connection request.
+	Headers http.Header
+	// LastEventID is the id of the last received event, it's sent as `Last-Event-ID` header when reconnecting.
+	LastEventID string
+	// ReconnectDelay is the wait time before reconnecting, default is 3s. it's updated by the retry field of events.
+	ReconnectDelay time.Duration
+	// MaxReconnects is the max times of reconnecting without receiving any event, 0 means unlimited,
+	// negative disables reconnecting.
+	MaxReconnects int
+	// OnError is called with the error before reconnecting.
+	OnError func(err error)
+}
+
+// NewSSEClient returns a SSEClient which subscribes the event stream of url.
+func NewSSEClient(client *HttpClient, rawURL string) *SSEClient {
+	if client == nil {
+		client = NewHttpClient()
+	}
+
+	return &SSEClient{
+		client:         client,
+		rawURL:         rawURL,
+		ReconnectDelay: defaultSSEReconnectDelay,
+	}
+}
+
+// Subscribe calls handler for each event until ctx is done, or handler returns an error.
+// It reconnects if the connection fails or the stream ends, and stops if the server responds
+// 204 No Content. other non-2xx responses are returned as *HTTPError without reconnecting.
+func (c *SSEClient) Subscribe(ctx context.Context, handler func(event *SSEEvent) error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	reconnects := 0
+	for {
+		received, err := c.connect(ctx, handler)
+		if received {
+			reconnects = 0
+		}
+
+		if errors.Is(err, errSSEClosed) {
+			return nil
+		}
+
+		var handlerErr *sseHandlerError
+		if errors.As(err, &handlerErr) {
+			return handlerErr.err
+		}
+
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) || ctx.Err() != nil {
+			return err
+		}
+
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		if c.OnError != nil {
+			c.OnError(err)
+		}
+
+		if c.MaxReconnects < 0 || (c.MaxReconnects > 0 && reconnects >= c.MaxReconnects) {
+			return err
+		}
+		reconnects++
+
+		if err := sleepContext(ctx, c.ReconnectDelay); err != nil {
+			return err
+		}
+	}
+}
+
+// errSSEClosed means the server asks client to stop reconnecting.
+var errSSEClosed = errors.New("event stream is closed by server")
+
+// sseHandlerError wraps the error returned by handler, so it's not treated as a connection error.
+type sseHandlerError struct {
+	err error
+}
+
+func (e *sseHandlerError) Error() string {
+	return e.err.Error()
+}
+
+// connect reads events from a connection until the stream ends, it reports whether any event is received.
+func (c *SSEClient) connect(ctx context.Context, handler func(event *SSEEvent) error) (bool, error) {
+	req, err := c.client.newRequest(ctx, http.MethodGet, c.rawURL, nil, c.Headers)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if c.LastEventID != "" {
+		req.Header.Set("Last-Event-ID", c.LastEventID)
+	}
+
+	resp, err := c.client.stream(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNoContent {
+		return false, errSSEClosed
+	}
+
+	received := false
+	parser := &sseParser{lastEventID: c.LastEventID}
+	err = parser.parse(resp.Body, func(event *SSEEvent) error {
+		received = true
+		c.LastEventID = event.ID
+		if err := handler(event); err != nil {
+			return &sseHandlerError{err: err}
+		}
+		return nil
+	})
+	if parser.retry > 0 {
+		c.ReconnectDelay = parser.retry
+	}
+
+	return received, err
+}
+
+// ReadSSEEvents reads Server-Sent Events from r, and calls handler for each event until r is
+// ended or handler returns an error.
+func ReadSSEEvents(r io.Reader, handler func(event *SSEEvent) error) error {
+	parser := &sseParser{}
+	return parser.parse(r, handler)
+}
+
+// sseParser parses event stream, see https://html.spec.whatwg.org/multipage/server-sent-events.html#event-stream-interpretation
+type sseParser struct {
+	lastEventID string
+	retry       time.Duration
+}
+
+func (p *sseParser) parse(r io.Reader, handler func(event *SSEEvent) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 4096), maxSSELineSize)
+	scanner.Split(scanSSELines)
+
+	var eventType string
+	var data strings.Builder
+	var retry time.Duration
+	first := true
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if first {
+			line = strings.TrimPrefix(line, "\ufeff")
+			first = false
+		}
+
+		if line == "" {
+			if data.Len() > 0 {
+				event := &SSEEvent{
+					ID:    p.lastEventID,
+					Event: eventType,
+					Data:  strings.TrimSuffix(data.String(), "\n"),
+					Retry: retry,
+				}
+				if event.Event == "" {
+					event.Event = "message"
+				}
+				if err := handler(event); err != nil {
+					return err
+				}
+			}
+			eventType, retry = "", 0
+			data.Reset()
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			data.WriteString(value)
+			data.WriteByte('\n')
+		case "id":
+			if !strings.Contains(value, "\x00") {
+				p.lastEventID = value
+			}
+		case "retry":
+			if ms, err := strconv.ParseUint(value, 10, 63); err == nil {
+				retry = time.Duration(ms) * time.Millisecond
+				p.retry = retry
+			}
+		}
+	}
+
+	return scanner.Err()
+}
+
+// scanSSELines splits lines by "\r\n", "\n" or "\r".
+func scanSSELines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		if data[i] == '\n' {
+			return i + 1, data[:i], nil
+		}
+		if i+1 < len(data) {
+			if data[i+1] == '\n' {
+				return i + 2, data[:i], nil
+			}
+			return i + 1, data[:i], nil
+		}
+		// "\r" may be followed by "\n".
+		if atEOF {
+			return i + 1, data[:i], nil
+		}
+		return 0, nil, nil
+	}
+
+	// the last line without line break is an incomplete event, which is discarded.
+	if atEOF {
+		return len(data), nil, nil
+	}
+
+	return 0, nil, nil
+}
+
+// SSEWriter writes Server-Sent Events to http response.
+type SSEWriter struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+// NewSSEWriter sets the headers of event stream, and returns a SSEWriter. It returns error
+// if w doesn't support flushing.
+func NewSSEWriter(w http.ResponseWriter) (*SSEWriter, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, errors.New("response writer doesn't support flushing")
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	return &SSEWriter{w: w, flusher: flusher}, nil
+}
+
+// Send writes the event and flushes it to client, multiline data is sent as multiple data fields.
+func (s *SSEWriter) Send(event *SSEEvent) error {
+	if strings.ContainsAny(event.ID, "\r\n\x00") || strings.ContainsAny(event.Event, "\r\n") {
+		return errors.New("event id and type should not contain line break")
+	}
+
+	var buf bytes.Buffer
+	if event.ID != "" {
+		fmt.Fprintf(&buf, "id: %s\n", event.ID)
+	}
+	if event.Event != "" {
+		fmt.Fprintf(&buf, "event: %s\n", event.Event)
+	}
+	if event.Retry > 0 {
+		fmt.Fprintf(&buf, "retry: %d\n", event.Retry.Milliseconds())
+	}
+
+	for _, line := range splitSSELines(event.Data) {
+		fmt.Fprintf(&buf, "data: %s\n", line)
+	}
+	buf.WriteByte('\n')
+
+	return s.write(buf.Bytes())
+}
+
+// SendComment writes a comment line, it's usually used as heartbeat to keep the connection alive.
+func (s *SSEWriter) SendComment(comment string) error {
+	var buf bytes.Buffer
+	for _, line := range splitSSELines(comment) {
+		fmt.Fprintf(&buf, ": %s\n", line)
+	}
+	buf.WriteByte('\n')
+
+	return s.write(buf.Bytes())
+}
+
+// splitSSELines splits s by "\r\n", "\n" or "\r", which are all line breaks of event stream.
+func splitSSELines(s string) []string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	return strings.Split(s, "\n")
+}
+
+func (s *SSEWriter) write(b []byte) error {
+	if _, err := s.w.Write(b); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+// LastEventID returns the `Last-Event-ID` header sent by reconnecting client.
+func LastEventID(r *http.Request) string {
+	return r.Header.Get("Last-Event-ID")
+}
@@ -0,0 +1,213 @@
+package netutil
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestReadSSEEvents(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestReadSSEEvents")
+
+	stream := "\ufeff: comment\n" +
+		"id: 1\nevent: add\ndata: first\ndata:second\n\n" +
+		"data: no id\r\n\r\n" +
+		"event: ignored\nretry: 500\n\n" +
+		"id: 2\rdata\r\r" +
+		"id\ndata: {\"a\":1}\nretry: abc\nunknown: field\n\n" +
+		"data: incomplete\n"
+
+	var events []*SSEEvent
+	err := ReadSSEEvents(strings.NewReader(stream), func(event *SSEEvent) error {
+		events = append(events, event)
+		return nil
+	})
+	assert.IsNil(err)
+
+	assert.Equal([]*SSEEvent{
+		{ID: "1", Event: "add", Data: "first\nsecond"},
+		{ID: "1", Event: "message", Data: "no id"},
+		{ID: "2", Event: "message", Data: ""},
+		{ID: "", Event: "message", Data: `{"a":1}`},
+	}, events)
+
+	stop := errors.New("stop")
+	err = ReadSSEEvents(strings.NewReader(stream), func(event *SSEEvent) error {
+		return stop
+	})
+	assert.Equal(stop, err)
+}
+
+func TestSSEClient(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSSEClient")
+
+	var connections int32
+	var lastEventIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&connections, 1)
+		lastEventIDs = append(lastEventIDs, LastEventID(r))
+
+		switch n {
+		case 1:
+			sse, _ := NewSSEWriter(w)
+			sse.SendComment("welcome")
+			sse.Send(&SSEEvent{ID: "1", Event: "add", Data: "line1\nline2", Retry: 10 * time.Millisecond})
+			sse.Send(&SSEEvent{ID: "2", Data: "b"})
+		case 2:
+			sse, _ := NewSSEWriter(w)
+			sse.Send(&SSEEvent{ID: "3", Data: "c"})
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	var errs int32
+	client := NewSSEClient(NewHttpClient(), server.URL)
+	client.OnError = func(err error) {
+		atomic.AddInt32(&errs, 1)
+	}
+
+	var events []*SSEEvent
+	err := client.Subscribe(context.Background(), func(event *SSEEvent) error {
+		events = append(events, event)
+		return nil
+	})
+	assert.IsNil(err)
+
+	assert.Equal([]*SSEEvent{
+		{ID: "1", Event: "add", Data: "line1\nline2", Retry: 10 * time.Millisecond},
+		{ID: "2", Event: "message", Data: "b"},
+		{ID: "3", Event: "message", Data: "c"},
+	}, events)
+	assert.Equal([]string{"", "2", "3"}, lastEventIDs)
+	assert.Equal("3", client.LastEventID)
+	assert.Equal(10*time.Millisecond, client.ReconnectDelay)
+	assert.Equal(int32(2), atomic.LoadInt32(&errs))
+}
+
+func TestSSEClient_Stop(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSSEClient_Stop")
+
+	var connections int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&connections, 1)
+		if r.URL.Path == "/error" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		sse, _ := NewSSEWriter(w)
+		if r.URL.Path != "/empty" {
+			sse.Send(&SSEEvent{Data: "a"})
+		}
+	}))
+	defer server.Close()
+
+	// handler error stops subscribing.
+	stop := errors.New("stop")
+	client := NewSSEClient(nil, server.URL)
+	err := client.Subscribe(context.Background(), func(event *SSEEvent) error {
+		return stop
+	})
+	assert.Equal(stop, err)
+
+	// non-2xx response is not reconnected.
+	atomic.StoreInt32(&connections, 0)
+	client = NewSSEClient(nil, server.URL+"/error")
+	err = client.Subscribe(context.Background(), func(event *SSEEvent) error { return nil })
+	var httpErr *HTTPError
+	assert.Equal(true, errors.As(err, &httpErr))
+	assert.Equal(int32(1), atomic.LoadInt32(&connections))
+
+	// reconnecting is limited.
+	atomic.StoreInt32(&connections, 0)
+	client = NewSSEClient(nil, server.URL+"/empty")
+	client.ReconnectDelay = time.Millisecond
+	client.MaxReconnects = 2
+	err = client.Subscribe(context.Background(), func(event *SSEEvent) error { return nil })
+	assert.Equal(io.ErrUnexpectedEOF, err)
+	assert.Equal(int32(3), atomic.LoadInt32(&connections))
+
+	atomic.StoreInt32(&connections, 0)
+	client.MaxReconnects = -1
+	client.Subscribe(context.Background(), func(event *SSEEvent) error { return nil })
+	assert.Equal(int32(1), atomic.LoadInt32(&connections))
+
+	// context is canceled while waiting to reconnect.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	client.MaxReconnects = 0
+	client.ReconnectDelay = time.Minute
+	err = client.Subscribe(ctx, func(event *SSEEvent) error { return nil })
+	assert.Equal(context.DeadlineExceeded, err)
+}
+
+type noFlushWriter struct {
+	http.ResponseWriter
+}
+
+func TestSSEWriter(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSSEWriter")
+
+	recorder := httptest.NewRecorder()
+	_, err := NewSSEWriter(noFlushWriter{recorder})
+	assert.IsNotNil(err)
+
+	sse, err := NewSSEWriter(recorder)
+	assert.IsNil(err)
+	assert.Equal("text/event-stream", recorder.Header().Get("Content-Type"))
+	assert.Equal(true, recorder.Flushed)
+
+	assert.IsNil(sse.Send(&SSEEvent{ID: "1", Event: "update", Data: "a\r\nb", Retry: time.Second}))
+	assert.IsNil(sse.SendComment("ping"))
+	assert.IsNotNil(sse.Send(&SSEEvent{ID: "1\n2", Data: "a"}))
+
+	assert.Equal("id: 1\nevent: update\nretry: 1000\ndata: a\ndata: b\n\n: ping\n\n", recorder.Body.String())
+}
+
+func TestSSEWriter_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSSEWriter_RoundTrip")
+
+	recorder := httptest.NewRecorder()
+	sse, err := NewSSEWriter(recorder)
+	assert.IsNil(err)
+
+	// every line break is normalized, so the data is not split into unexpected fields.
+	data := []string{"a\r\nb", "a\rdata: injected\rb", "a\n\nb", "a\r\rb"}
+	for _, d := range data {
+		assert.IsNil(sse.Send(&SSEEvent{Event: "msg", Data: d}))
+	}
+	assert.IsNil(sse.SendComment("ping\revent: injected"))
+
+	var events []*SSEEvent
+	err = ReadSSEEvents(recorder.Body, func(event *SSEEvent) error {
+		events = append(events, event)
+		return nil
+	})
+	assert.IsNil(err)
+
+	assert.Equal(len(data), len(events))
+	expected := []string{"a\nb", "a\ndata: injected\nb", "a\n\nb", "a\n\nb"}
+	for i, event := range events {
+		assert.Equal("msg", event.Event)
+		assert.Equal(expected[i], event.Data)
+	}
+}
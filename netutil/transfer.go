@@ -0,0 +1,622 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license.
+
+package netutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/duke-git/lancet/v2/fileutil"
+)
+
+// ProgressFunc is called when data is transferred, total is -1 if it's unknown.
+type ProgressFunc func(transferred, total int64)
+
+// FormFile is a file of multipart upload, its content is read from Path or Reader when the request is sent.
+type FormFile struct {
+	FieldName string
+	// FileName is the file name in form, default is the base name of Path.
+	FileName string
+	// ContentType default is application/octet-stream.
+	ContentType string
+	Path        string
+	// Reader is used if Path is empty.
+	Reader io.Reader
+	// Size is the size of Reader content, it's used to compute the content length of request.
+	Size int64
+}
+
+// UploadRequest is the multipart upload request of HttpClient.Upload.
+type UploadRequest struct {
+	RawURL string
+	// Method default is POST.
+	Method     string
+	Headers    http.Header
+	FormData   url.Values
+	Files      []*FormFile
+	OnProgress ProgressFunc
+}
+
+// Upload streams files to server as multipart form, file content is not buffered in memory.
+// The request can be resent by Retry middleware without buffering only if all files are read from Path.
+// If the response status code is not 2xx, an *HTTPError is returned with the response.
+func (client *HttpClient) Upload(ctx context.Context, request *UploadRequest) (*http.Response, error) {
+	method := request.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	if err := validateRequest(&HttpRequest{RawURL: request.RawURL, Method: method}); err != nil {
+		return nil, err
+	}
+
+	body, err := newMultipartBody(request.FormData, request.Files, request.OnProgress)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := client.newRequest(ctx, method, request.RawURL, nil, request.Headers)
+	if err != nil {
+		return nil, err
+	}
+	body.setRequest(req)
+
+	return client.send(req)
+}
+
+// multipartBody writes multipart form to a pipe, so the content is streamed.
+type multipartBody struct {
+	boundary string
+	fields   url.Values
+	files    []*FormFile
+	// total is the total size of files, length is the content length of body, -1 if it's unknown.
+	total      int64
+	length     int64
+	reopenable bool
+	onProgress ProgressFunc
+}
+
+func newMultipartBody(fields url.Values, files []*FormFile, onProgress ProgressFunc) (*multipartBody, error) {
+	body := &multipartBody{
+		boundary:   multipart.NewWriter(nil).Boundary(),
+		fields:     fields,
+		files:      files,
+		reopenable: true,
+		onProgress: onProgress,
+	}
+
+	sizeKnown := true
+	for _, f := range files {
+		if f.Path != "" {
+			info, err := os.Stat(f.Path)
+			if err != nil {
+				return nil, err
+			}
+			body.total += info.Size()
+			continue
+		}
+
+		if f.Reader == nil {
+			return nil, fmt.Errorf("form file %q has no content", f.FieldName)
+		}
+		body.reopenable = false
+		if f.Size > 0 {
+			body.total += f.Size
+		} else {
+			sizeKnown = false
+		}
+	}
+
+	if !sizeKnown {
+		body.total, body.length = -1, -1
+		return body, nil
+	}
+
+	// the framing of multipart form is computed by writing it without file content.
+	counter := &countWriter{}
+	if err := body.write(counter, false); err != nil {
+		return nil, err
+	}
+	body.length = counter.n + body.total
+
+	return body, nil
+}
+
+// setRequest sets the body and content headers of req.
+func (b *multipartBody) setRequest(req *http.Request) {
+	req.Body = b.open()
+	req.ContentLength = b.length
+	if b.reopenable {
+		req.GetBody = func() (io.ReadCloser, error) {
+			return b.open(), nil
+		}
+	}
+	req.Header.Set("Content-Type", "multipart/form-data; boundary="+b.boundary)
+}
+
+func (b *multipartBody) open() io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(b.write(pw, true))
+	}()
+	return pr
+}
+
+func (b *multipartBody) write(w io.Writer, withContent bool) error {
+	writer := multipart.NewWriter(w)
+	if err := writer.SetBoundary(b.boundary); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(b.fields))
+	for k := range b.fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		for _, v := range b.fields[k] {
+			if err := writer.WriteField(k, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	p := newProgress(b.onProgress, 0, b.total)
+	for _, f := range b.files {
+		part, err := writer.CreatePart(formFileHeader(f))
+		if err != nil {
+			return err
+		}
+		if withContent {
+			if err := copyFormFile(&progressWriter{w: part, p: p}, f); err != nil {
+				return err
+			}
+		}
+	}
+
+	return writer.Close()
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func formFileHeader(f *FormFile) textproto.MIMEHeader {
+	fileName := f.FileName
+	if fileName == "" && f.Path != "" {
+		fileName = filepath.Base(f.Path)
+	}
+	contentType := f.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+		quoteEscaper.Replace(f.FieldName), quoteEscaper.Replace(fileName)))
+	header.Set("Content-Type", contentType)
+
+	return header
+}
+
+func copyFormFile(w io.Writer, f *FormFile) error {
+	if f.Path == "" {
+		_, err := io.Copy(w, f.Reader)
+		return err
+	}
+
+	file, err := os.Open(f.Path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(w, file)
+	return err
+}
+
+// ErrChecksumMismatch is returned by HttpClient.Download if the checksum of downloaded file is not expected.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// ErrRemoteFileChanged is returned by HttpClient.Download if the remote file is changed during a segmented download.
+var ErrRemoteFileChanged = errors.New("remote file is changed")
+
+// DownloadOptions is the options of HttpClient.Download.
+type DownloadOptions struct {
+	Headers http.Header
+	// Resume continues downloading to the existing file with Range and If-Range request. The ETag or
+	// Last-Modified of incomplete file is saved in a ".resume" file beside it, the file is downloaded from
+	// the beginning if it's not saved or the remote file is changed.
+	Resume bool
+	// Segments is the number of parallel range requests, the file is downloaded by a single request
+	// if it's less than 2, the server doesn't support Range, or the response has no ETag or Last-Modified
+	// to check the file isn't changed between segments.
+	Segments int
+	// Checksum is the expected sha hex string of file, the file is removed if it's mismatched.
+	Checksum string
+	// ChecksumType is the sha type of Checksum: 1, 256 or 512, default is 256, see fileutil.Sha.
+	ChecksumType int
+	OnProgress   ProgressFunc
+}
+
+// Download downloads the file of url to filePath. The incomplete file is kept for resuming
+// if a single request download fails, and is removed if a segmented download fails.
+func (client *HttpClient) Download(ctx context.Context, rawURL, filePath string, opts *DownloadOptions) error {
+	if opts == nil {
+		opts = &DownloadOptions{}
+	}
+
+	var err error
+	if opts.Segments > 1 {
+		err = client.downloadSegments(ctx, rawURL, filePath, opts)
+	} else {
+		err = client.downloadFile(ctx, rawURL, filePath, opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	return verifyChecksum(filePath, opts)
+}
+
+func (client *HttpClient) downloadFile(ctx context.Context, rawURL, filePath string, opts *DownloadOptions) error {
+	var offset int64
+	var validator string
+	if opts.Resume {
+		validator = loadValidator(filePath)
+		if info, err := os.Stat(filePath); err == nil && validator != "" {
+			offset = info.Size()
+		}
+	}
+
+	req, err := client.newRequest(ctx, http.MethodGet, rawURL, nil, opts.Headers)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		req.Header.Set("If-Range", validator)
+	}
+
+	resp, err := client.send(req)
+	if err != nil {
+		// the file is downloaded completely.
+		var httpErr *HTTPError
+		if offset > 0 && errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+			if _, _, size, ok := parseContentRange(httpErr.Header.Get("Content-Range")); ok && size == offset {
+				os.Remove(filePath + resumeSuffix)
+				return nil
+			}
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusPartialContent {
+		// the server ignores Range header, download from the beginning.
+		return writeResponse(resp, filePath, os.O_TRUNC, newProgress(opts.OnProgress, 0, resp.ContentLength))
+	}
+
+	start, _, size, ok := parseContentRange(resp.Header.Get("Content-Range"))
+	if !ok || start != offset {
+		return fmt.Errorf("invalid Content-Range: %q", resp.Header.Get("Content-Range"))
+	}
+
+	return writeResponse(resp, filePath, os.O_APPEND, newProgress(opts.OnProgress, offset, size))
+}
+
+// writeResponse writes response body to file, the validator of response is saved until the file is complete.
+func writeResponse(resp *http.Response, filePath string, flag int, p *progress) error {
+	if err := saveValidator(filePath, resp.Header); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|flag, 0644)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(&progressWriter{w: file, p: p}, resp.Body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		os.Remove(filePath + resumeSuffix)
+	}
+
+	return err
+}
+
+// resumeSuffix is the suffix of file which saves the validator of incomplete download file.
+const resumeSuffix = ".resume"
+
+// saveValidator saves the strong ETag or Last-Modified of response, which is sent as If-Range when resuming.
+func saveValidator(filePath string, header http.Header) error {
+	validator := responseValidator(header)
+	if validator == "" {
+		err := os.Remove(filePath + resumeSuffix)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	return os.WriteFile(filePath+resumeSuffix, []byte(validator), 0644)
+}
+
+// responseValidator returns the strong ETag or Last-Modified of response, which can be used in If-Range.
+func responseValidator(header http.Header) string {
+	validator := header.Get("ETag")
+	if validator == "" || strings.HasPrefix(validator, "W/") {
+		// weak ETag can't be used in If-Range.
+		validator = header.Get("Last-Modified")
+	}
+	return validator
+}
+
+func loadValidator(filePath string) string {
+	data, err := os.ReadFile(filePath + resumeSuffix)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func (client *HttpClient) downloadSegments(ctx context.Context, rawURL, filePath string, opts *DownloadOptions) error {
+	// probe the file size and range support with the first byte.
+	req, err := client.newRequest(ctx, http.MethodGet, rawURL, nil, opts.Headers)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Range", "bytes=0-0")
+
+	resp, err := client.send(req)
+	if err != nil {
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+			// empty file.
+			return client.downloadFile(ctx, rawURL, filePath, &DownloadOptions{Headers: opts.Headers, OnProgress: opts.OnProgress})
+		}
+		return err
+	}
+
+	if resp.StatusCode != http.StatusPartialContent {
+		defer resp.Body.Close()
+		return writeResponse(resp, filePath, os.O_TRUNC, newProgress(opts.OnProgress, 0, resp.ContentLength))
+	}
+
+	drainBody(resp.Body)
+	// the segments are requested with If-Range, so they are from the same version of file.
+	validator := responseValidator(resp.Header)
+	_, _, size, ok := parseContentRange(resp.Header.Get("Content-Range"))
+	if !ok || size <= 0 || validator == "" {
+		return client.downloadFile(ctx, rawURL, filePath, &DownloadOptions{Headers: opts.Headers, OnProgress: opts.OnProgress})
+	}
+
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if err := file.Truncate(size); err != nil {
+		file.Close()
+		return err
+	}
+
+	segments := int64(opts.Segments)
+	if segments > size {
+		segments = size
+	}
+	segmentSize := size / segments
+
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	p := newProgress(opts.OnProgress, 0, size)
+	for i := int64(0); i < segments; i++ {
+		start := i * segmentSize
+		end := start + segmentSize - 1
+		if i == segments-1 {
+			end = size - 1
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.downloadSegment(ctx, rawURL, opts.Headers, validator, file, start, end, p); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	err = file.Close()
+	if firstErr != nil {
+		err = firstErr
+	}
+	if err != nil {
+		// the file with holes can't be resumed.
+		os.Remove(filePath)
+	}
+
+	return err
+}
+
+func (client *HttpClient) downloadSegment(ctx context.Context, rawURL string, headers http.Header, validator string,
+	file *os.File, start, end int64, p *progress) error {
+	req, err := client.newRequest(ctx, http.MethodGet, rawURL, nil, headers)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end))
+	req.Header.Set("If-Range", validator)
+
+	resp, err := client.send(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// the whole file is returned if it doesn't match If-Range.
+	if resp.StatusCode == http.StatusOK {
+		return fmt.Errorf("%w: %s", ErrRemoteFileChanged, rawURL)
+	}
+
+	if rangeStart, _, _, ok := parseContentRange(resp.Header.Get("Content-Range")); resp.StatusCode != http.StatusPartialContent || !ok || rangeStart != start {
+		return fmt.Errorf("invalid range response of bytes=%d-%d", start, end)
+	}
+
+	length := end - start + 1
+	n, err := io.Copy(&progressWriter{w: &offsetWriter{w: file, offset: start}, p: p}, io.LimitReader(resp.Body, length))
+	if err != nil {
+		return err
+	}
+	if n != length {
+		return io.ErrUnexpectedEOF
+	}
+
+	return nil
+}
+
+// parseContentRange parses `bytes start-end/size` and `bytes */size`, size is -1 if it's unknown.
+func parseContentRange(value string) (start, end, size int64, ok bool) {
+	value, found := cutPrefix(value, "bytes ")
+	if !found {
+		return 0, 0, 0, false
+	}
+
+	rangeValue, sizeValue, found := strings.Cut(value, "/")
+	if !found {
+		return 0, 0, 0, false
+	}
+
+	size = -1
+	if sizeValue != "*" {
+		var err error
+		if size, err = strconv.ParseInt(sizeValue, 10, 64); err != nil {
+			return 0, 0, 0, false
+		}
+	}
+
+	if rangeValue == "*" {
+		return 0, 0, size, true
+	}
+
+	startValue, endValue, found := strings.Cut(rangeValue, "-")
+	if !found {
+		return 0, 0, 0, false
+	}
+	start, err := strconv.ParseInt(startValue, 10, 64)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	end, err = strconv.ParseInt(endValue, 10, 64)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+
+	return start, end, size, true
+}
+
+func cutPrefix(s, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
+
+func verifyChecksum(filePath string, opts *DownloadOptions) error {
+	if opts.Checksum == "" {
+		return nil
+	}
+
+	shaType := opts.ChecksumType
+	if shaType == 0 {
+		shaType = 256
+	}
+
+	sum, err := fileutil.Sha(filePath, shaType)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(sum, opts.Checksum) {
+		os.Remove(filePath)
+		return fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, opts.Checksum, sum)
+	}
+
+	return nil
+}
+
+// progress reports the transferred bytes, it's safe for concurrent use.
+type progress struct {
+	mu          sync.Mutex
+	fn          ProgressFunc
+	transferred int64
+	total       int64
+}
+
+func newProgress(fn ProgressFunc, transferred, total int64) *progress {
+	return &progress{fn: fn, transferred: transferred, total: total}
+}
+
+func (p *progress) add(n int64) {
+	if p.fn == nil || n == 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.transferred += n
+	p.fn(p.transferred, p.total)
+}
+
+type progressWriter struct {
+	w io.Writer
+	p *progress
+}
+
+func (w *progressWriter) Write(b []byte) (int, error) {
+	n, err := w.w.Write(b)
+	w.p.add(int64(n))
+	return n, err
+}
+
+// offsetWriter writes to w from offset.
+type offsetWriter struct {
+	w      io.WriterAt
+	offset int64
+}
+
+func (w *offsetWriter) Write(b []byte) (int, error) {
+	n, err := w.w.WriteAt(b, w.offset)
+	w.offset += int64(n)
+	return n, err
+}
+
+type countWriter struct {
+	n int64
+}
+
+func (w *countWriter) Write(b []byte) (int, error) {
+	w.n += int64(len(b))
+	return len(b), nil
+}
@@ -0,0 +1,368 @@
+package netutil
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/fileutil"
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func newUploadServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.ContentLength >= 0 && r.ContentLength != int64(len(body)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		reader, err := r.MultipartReader()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		result := map[string]string{"content-length": r.Header.Get("Content-Length")}
+		for {
+			part, err := reader.NextPart()
+			if err != nil {
+				break
+			}
+			content, _ := io.ReadAll(part)
+			key := part.FormName()
+			if part.FileName() != "" {
+				key += ":" + part.FileName() + ":" + part.Header.Get("Content-Type")
+			}
+			result[key] = string(content)
+		}
+		json.NewEncoder(w).Encode(result)
+	}))
+}
+
+func TestHttpClient_Upload(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestHttpClient_Upload")
+
+	server := newUploadServer()
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "a.txt")
+	os.WriteFile(path, []byte("hello"), 0644)
+
+	var transferred, total int64
+	client := NewHttpClient()
+	resp, err := client.Upload(context.Background(), &UploadRequest{
+		RawURL:   server.URL,
+		FormData: map[string][]string{"name": {"lancet"}},
+		Files: []*FormFile{
+			{FieldName: "file1", Path: path},
+			{FieldName: "file2", FileName: "b.json", ContentType: "application/json", Reader: strings.NewReader(`{}`), Size: 2},
+		},
+		OnProgress: func(n, t int64) {
+			transferred, total = n, t
+		},
+	})
+	assert.IsNil(err)
+
+	var result map[string]string
+	client.DecodeResponse(resp, &result)
+	assert.Equal("lancet", result["name"])
+	assert.Equal("hello", result["file1:a.txt:application/octet-stream"])
+	assert.Equal("{}", result["file2:b.json:application/json"])
+	assert.NotEqual("", result["content-length"])
+	assert.Equal(int64(7), transferred)
+	assert.Equal(int64(7), total)
+
+	// size of reader is unknown, the body is chunked.
+	resp, err = client.Upload(context.Background(), &UploadRequest{
+		RawURL: server.URL,
+		Method: http.MethodPut,
+		Files:  []*FormFile{{FieldName: "file", FileName: "c.txt", Reader: strings.NewReader("stream")}},
+		OnProgress: func(n, t int64) {
+			transferred, total = n, t
+		},
+	})
+	assert.IsNil(err)
+
+	result = nil
+	client.DecodeResponse(resp, &result)
+	assert.Equal("stream", result["file:c.txt:application/octet-stream"])
+	assert.Equal("", result["content-length"])
+	assert.Equal(int64(-1), total)
+
+	_, err = client.Upload(context.Background(), &UploadRequest{RawURL: server.URL, Files: []*FormFile{{Path: "not_exist"}}})
+	assert.IsNotNil(err)
+	_, err = client.Upload(context.Background(), &UploadRequest{RawURL: server.URL, Files: []*FormFile{{FieldName: "empty"}}})
+	assert.IsNotNil(err)
+
+	ok, err := UploadFile(path, server.URL)
+	assert.IsNil(err)
+	assert.Equal(true, ok)
+}
+
+func newDownloadServer(content []byte, supportRange bool, requests *int32) *httptest.Server {
+	return httptest.NewServer(downloadHandler(content, supportRange, requests))
+}
+
+func downloadHandler(content []byte, supportRange bool, requests *int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if !supportRange {
+			w.Write(content)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeContent(w, r, "file", time.Time{}, bytes.NewReader(content))
+	})
+}
+
+func TestHttpClient_Download(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestHttpClient_Download")
+
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	var requests int32
+	server := newDownloadServer(content, true, &requests)
+	defer server.Close()
+
+	client := NewHttpClient()
+	dir := t.TempDir()
+
+	var transferred, total int64
+	path := filepath.Join(dir, "full")
+	err := client.Download(context.Background(), server.URL, path, &DownloadOptions{
+		Checksum: strings.ToUpper(checksum),
+		OnProgress: func(n, t int64) {
+			transferred, total = n, t
+		},
+	})
+	assert.IsNil(err)
+	data, _ := os.ReadFile(path)
+	assert.Equal(content, data)
+	assert.Equal(int64(len(content)), transferred)
+	assert.Equal(int64(len(content)), total)
+
+	// resume from the partial file.
+	path = filepath.Join(dir, "resume")
+	os.WriteFile(path, content[:4000], 0644)
+	os.WriteFile(path+resumeSuffix, []byte(`"v1"`), 0644)
+	requestsBefore := atomic.LoadInt32(&requests)
+	var resumedFrom int64 = -1
+	err = client.Download(context.Background(), server.URL, path, &DownloadOptions{
+		Resume:   true,
+		Checksum: checksum,
+		OnProgress: func(n, t int64) {
+			if resumedFrom < 0 {
+				resumedFrom = n
+			}
+			transferred, total = n, t
+		},
+	})
+	assert.IsNil(err)
+	data, _ = os.ReadFile(path)
+	assert.Equal(content, data)
+	assert.Equal(int64(len(content)), transferred)
+	assert.Equal(true, resumedFrom > 4000)
+	assert.Equal(int32(1), atomic.LoadInt32(&requests)-requestsBefore)
+	_, err = os.Stat(path + resumeSuffix)
+	assert.Equal(true, os.IsNotExist(err))
+
+	// the remote file is changed, or the validator is unknown, the file is downloaded from the beginning.
+	for _, validator := range []string{`"v0"`, ""} {
+		os.WriteFile(path, []byte("changed file"), 0644)
+		if validator != "" {
+			os.WriteFile(path+resumeSuffix, []byte(validator), 0644)
+		}
+		// the file would be corrupted and mismatch checksum if it's resumed.
+		err = client.Download(context.Background(), server.URL, path, &DownloadOptions{Resume: true, Checksum: checksum})
+		assert.IsNil(err)
+		data, _ = os.ReadFile(path)
+		assert.Equal(content, data)
+	}
+
+	// the file is complete.
+	err = client.Download(context.Background(), server.URL, path, &DownloadOptions{Resume: true, Checksum: checksum})
+	assert.IsNil(err)
+
+	// mismatched file is removed.
+	err = client.Download(context.Background(), server.URL, path, &DownloadOptions{Checksum: "abc"})
+	assert.Equal(true, errors.Is(err, ErrChecksumMismatch))
+	_, err = os.Stat(path)
+	assert.Equal(true, os.IsNotExist(err))
+
+	err = client.Download(context.Background(), server.URL, filepath.Join(dir, "sha"), &DownloadOptions{Checksum: checksum, ChecksumType: 3})
+	assert.IsNotNil(err)
+}
+
+func TestHttpClient_DownloadSegments(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestHttpClient_DownloadSegments")
+
+	content := bytes.Repeat([]byte("abcdefghij"), 1001)
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	var requests int32
+	server := newDownloadServer(content, true, &requests)
+	defer server.Close()
+
+	client := NewHttpClient()
+	dir := t.TempDir()
+
+	var transferred int64
+	path := filepath.Join(dir, "segments")
+	err := client.Download(context.Background(), server.URL, path, &DownloadOptions{
+		Segments: 4,
+		Checksum: checksum,
+		OnProgress: func(n, t int64) {
+			transferred = n
+		},
+	})
+	assert.IsNil(err)
+	data, _ := os.ReadFile(path)
+	assert.Equal(content, data)
+	assert.Equal(int64(len(content)), transferred)
+	// one probe request and four segment requests.
+	assert.Equal(int32(5), atomic.LoadInt32(&requests))
+
+	// segments are downloaded over https concurrently.
+	var tlsRequests int32
+	tlsServer := httptest.NewTLSServer(downloadHandler(content, true, &tlsRequests))
+	defer tlsServer.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(tlsServer.Certificate())
+	tlsClient := NewHttpClientWithConfig(&HttpClientConfig{SSLEnabled: true, TLSConfig: &tls.Config{RootCAs: pool}})
+
+	path = filepath.Join(dir, "tls")
+	err = tlsClient.Download(context.Background(), tlsServer.URL, path, &DownloadOptions{Segments: 4, Checksum: checksum})
+	assert.IsNil(err)
+	assert.Equal(int32(5), atomic.LoadInt32(&tlsRequests))
+
+	// server doesn't support Range.
+	var plainRequests int32
+	plain := newDownloadServer(content, false, &plainRequests)
+	defer plain.Close()
+
+	path = filepath.Join(dir, "plain")
+	err = client.Download(context.Background(), plain.URL, path, &DownloadOptions{Segments: 4, Checksum: checksum})
+	assert.IsNil(err)
+	data, _ = os.ReadFile(path)
+	assert.Equal(content, data)
+	assert.Equal(int32(1), atomic.LoadInt32(&plainRequests))
+
+	// empty file.
+	var emptyRequests int32
+	empty := newDownloadServer([]byte{}, true, &emptyRequests)
+	defer empty.Close()
+
+	path = filepath.Join(dir, "empty")
+	err = client.Download(context.Background(), empty.URL, path, &DownloadOptions{Segments: 4})
+	assert.IsNil(err)
+	data, _ = os.ReadFile(path)
+	assert.Equal(0, len(data))
+
+	// the server reports zero size in range response.
+	zero := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "" {
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Content-Range", "bytes */0")
+			w.WriteHeader(http.StatusPartialContent)
+		}
+	}))
+	defer zero.Close()
+
+	path = filepath.Join(dir, "zero")
+	err = client.Download(context.Background(), zero.URL, path, &DownloadOptions{Segments: 4})
+	assert.IsNil(err)
+	data, _ = os.ReadFile(path)
+	assert.Equal(0, len(data))
+
+	// the segments can't be checked without validator, the file is downloaded by a single request.
+	var noValidatorRequests int32
+	noValidator := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&noValidatorRequests, 1)
+		http.ServeContent(w, r, "file", time.Time{}, bytes.NewReader(content))
+	}))
+	defer noValidator.Close()
+
+	path = filepath.Join(dir, "no-validator")
+	err = client.Download(context.Background(), noValidator.URL, path, &DownloadOptions{Segments: 4, Checksum: checksum})
+	assert.IsNil(err)
+	assert.Equal(int32(2), atomic.LoadInt32(&noValidatorRequests))
+}
+
+func TestHttpClient_DownloadSegments_Changed(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestHttpClient_DownloadSegments_Changed")
+
+	// the file is changed after the probe request.
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, etag := bytes.Repeat([]byte("a"), 1000), `"v1"`
+		if atomic.AddInt32(&requests, 1) > 1 {
+			content, etag = bytes.Repeat([]byte("b"), 1000), `"v2"`
+		}
+		w.Header().Set("ETag", etag)
+		http.ServeContent(w, r, "file", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "changed")
+	err := NewHttpClient().Download(context.Background(), server.URL, path, &DownloadOptions{Segments: 4})
+	assert.Equal(true, errors.Is(err, ErrRemoteFileChanged))
+	assert.Equal(false, fileutil.IsExist(path))
+}
+
+func TestParseContentRange(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestParseContentRange")
+
+	tests := []struct {
+		value string
+		start int64
+		end   int64
+		size  int64
+		ok    bool
+	}{
+		{"bytes 0-99/1000", 0, 99, 1000, true},
+		{"bytes 100-199/*", 100, 199, -1, true},
+		{"bytes */1000", 0, 0, 1000, true},
+		{"bytes 0-99", 0, 0, 0, false},
+		{"items 0-99/1000", 0, 0, 0, false},
+		{"bytes a-99/1000", 0, 0, 0, false},
+		{"", 0, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		start, end, size, ok := parseContentRange(tt.value)
+		assert.Equal(tt.start, start)
+		assert.Equal(tt.end, end)
+		assert.Equal(tt.size, size)
+		assert.Equal(tt.ok, ok)
+	}
+}
@@ -59,20 +59,38 @@ func RetryWithCustomBackoff(backoffStrategy BackoffStrategy) Option {
 // RetryWithLinearBackoff set linear strategy backoff
 // Play: https://go.dev/play/p/PDet2ZQZwcB
 func RetryWithLinearBackoff(interval time.Duration) Option {
-	if interval <= 0 {
-		panic("programming error: retry interval should not be lower or equal to 0")
-	}
+	backoffStrategy := NewLinearBackoff(interval)
 
 	return func(rc *RetryConfig) {
-		rc.backoffStrategy = &linear{
-			interval: interval,
-		}
+		rc.backoffStrategy = backoffStrategy
 	}
 }
 
 // RetryWithExponentialWithJitterBackoff set exponential strategy backoff
 // Play: https://go.dev/play/p/xp1avQmn16X
 func RetryWithExponentialWithJitterBackoff(interval time.Duration, base uint64, maxJitter time.Duration) Option {
+	// check the arguments eagerly, the strategy is stateful, so it's created for each config.
+	NewExponentialWithJitterBackoff(interval, base, maxJitter)
+
+	return func(rc *RetryConfig) {
+		rc.backoffStrategy = NewExponentialWithJitterBackoff(interval, base, maxJitter)
+	}
+}
+
+// NewLinearBackoff returns a backoff strategy which waits the same interval between retries.
+func NewLinearBackoff(interval time.Duration) BackoffStrategy {
+	if interval <= 0 {
+		panic("programming error: retry interval should not be lower or equal to 0")
+	}
+
+	return &linear{
+		interval: interval,
+	}
+}
+
+// NewExponentialWithJitterBackoff returns a backoff strategy which multiplies the interval by base after each retry,
+// and adds a random jitter up to maxJitter. The strategy is stateful, create a new one for each sequence of retries.
+func NewExponentialWithJitterBackoff(interval time.Duration, base uint64, maxJitter time.Duration) BackoffStrategy {
 	if interval <= 0 {
 		panic("programming error: retry interval should not be lower or equal to 0")
 	}
@@ -82,22 +100,18 @@ func RetryWithExponentialWithJitterBackoff(interval time.Duration, base uint64,
 	}
 
 	if base%2 == 0 {
-		return func(rc *RetryConfig) {
-			rc.backoffStrategy = &shiftExponentialWithJitter{
-				interval:  interval,
-				maxJitter: maxJitter,
-				shifter:   uint64(math.Log2(float64(base))),
-			}
-		}
-	}
-
-	return func(rc *RetryConfig) {
-		rc.backoffStrategy = &exponentialWithJitter{
+		return &shiftExponentialWithJitter{
 			interval:  interval,
-			base:      time.Duration(base),
 			maxJitter: maxJitter,
+			shifter:   uint64(math.Log2(float64(base))),
 		}
 	}
+
+	return &exponentialWithJitter{
+		interval:  interval,
+		base:      time.Duration(base),
+		maxJitter: maxJitter,
+	}
 }
 
 // Context set retry context config.
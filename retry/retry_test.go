@@ -258,3 +258,21 @@ func TestCancelRetry(t *testing.T) {
 	assert.IsNotNil(err)
 	assert.Equal(4, number)
 }
+
+func TestNewBackoff(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestNewBackoff")
+
+	linear := NewLinearBackoff(time.Millisecond)
+	assert.Equal(time.Millisecond, linear.CalculateInterval())
+	assert.Equal(time.Millisecond, linear.CalculateInterval())
+
+	exponential := NewExponentialWithJitterBackoff(time.Millisecond, 2, 0)
+	assert.Equal(time.Millisecond, exponential.CalculateInterval())
+	assert.Equal(2*time.Millisecond, exponential.CalculateInterval())
+
+	exponential = NewExponentialWithJitterBackoff(time.Millisecond, 3, 0)
+	assert.Equal(time.Millisecond, exponential.CalculateInterval())
+	assert.Equal(3*time.Millisecond, exponential.CalculateInterval())
+}
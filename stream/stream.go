@@ -9,6 +9,8 @@ import (
 	"bytes"
 	"encoding/gob"
 
+	"github.com/duke-git/lancet/v2/datastructure/either"
+	"github.com/duke-git/lancet/v2/datastructure/result"
 	"github.com/duke-git/lancet/v2/slice"
 	"golang.org/x/exp/constraints"
 )
@@ -429,3 +431,29 @@ func ToMap[T any, K comparable, V any](s Stream[T], mapper func(item T) (K, V))
 	}
 	return result
 }
+
+// TryMap returns a stream consisting of the results of applying the given fallible mapper to the elements of this stream.
+// it stops at the first error and returns it as a failed Result.
+func TryMap[T any, U any](s Stream[T], mapper func(item T) (U, error)) result.Result[Stream[U]] {
+	source := make([]U, 0, len(s.source))
+	for _, v := range s.source {
+		u, err := mapper(v)
+		if err != nil {
+			return result.Err[Stream[U]](err)
+		}
+		source = append(source, u)
+	}
+	return result.Ok(FromSlice(source))
+}
+
+// CollectResults returns a successful Result of all values if all elements of the stream are successful,
+// otherwise returns the first error as a failed Result.
+func CollectResults[T any](s Stream[result.Result[T]]) result.Result[[]T] {
+	return result.Collect(s.source)
+}
+
+// PartitionEither splits the stream of Either into a stream of left values and a stream of right values.
+func PartitionEither[L any, R any](s Stream[either.Either[L, R]]) (Stream[L], Stream[R]) {
+	lefts, rights := either.Partition(s.source)
+	return FromSlice(lefts), FromSlice(rights)
+}
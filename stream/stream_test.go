@@ -1,9 +1,13 @@
 package stream
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"testing"
 
+	"github.com/duke-git/lancet/v2/datastructure/either"
+	"github.com/duke-git/lancet/v2/datastructure/result"
 	"github.com/duke-git/lancet/v2/internal"
 )
 
@@ -423,3 +427,47 @@ func TestStream_ToMap(t *testing.T) {
 	assert.EqualValues(expected, m)
 
 }
+
+func TestTryMap(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestTryMap")
+
+	r := TryMap(Of("1", "2", "3"), strconv.Atoi)
+	assert.Equal([]int{1, 2, 3}, r.Unwrap().ToSlice())
+
+	count := 0
+	r = TryMap(Of("1", "a", "3"), func(s string) (int, error) {
+		count++
+		return strconv.Atoi(s)
+	})
+	assert.Equal(true, r.IsErr())
+	assert.Equal(2, count)
+}
+
+func TestCollectResults(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCollectResults")
+
+	r := CollectResults(Of(result.Ok(1), result.Ok(2)))
+	assert.Equal([]int{1, 2}, r.Unwrap())
+
+	errFoo := errors.New("foo")
+	r = CollectResults(Of(result.Ok(1), result.Err[int](errFoo), result.Ok(3)))
+	assert.Equal(errFoo, r.Error())
+}
+
+func TestPartitionEither(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPartitionEither")
+
+	lefts, rights := PartitionEither(Of(
+		either.Left[string, int]("a"),
+		either.Right[string](1),
+		either.Right[string](2),
+	))
+	assert.Equal([]string{"a"}, lefts.ToSlice())
+	assert.Equal([]int{1, 2}, rights.ToSlice())
+}
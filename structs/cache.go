@@ -0,0 +1,73 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package structs
+
+import (
+	"reflect"
+	"sync"
+)
+
+// fieldMeta is the cached metadata of struct field.
+type fieldMeta struct {
+	field reflect.StructField
+	tag   *Tag
+	// defaultValue is the value of `default` tag.
+	defaultValue string
+	hasDefault   bool
+}
+
+// typeInfo is the cached metadata of struct type.
+type typeInfo struct {
+	fields []*fieldMeta
+	// byName indexes fields by field name and tag name, field name takes precedence.
+	byName map[string]*fieldMeta
+}
+
+type typeKey struct {
+	rtype   reflect.Type
+	tagName string
+}
+
+// typeCache caches *typeInfo by struct type and tag name.
+var typeCache sync.Map
+
+// getTypeInfo returns the metadata of struct type t, it's computed once for each type and tag name.
+func getTypeInfo(t reflect.Type, tagName string) *typeInfo {
+	key := typeKey{rtype: t, tagName: tagName}
+	if info, ok := typeCache.Load(key); ok {
+		return info.(*typeInfo)
+	}
+
+	info := &typeInfo{
+		fields: make([]*fieldMeta, t.NumField()),
+		byName: make(map[string]*fieldMeta, t.NumField()*2),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		meta := &fieldMeta{field: sf, tag: newTag(sf.Tag.Get(tagName))}
+		meta.defaultValue, meta.hasDefault = sf.Tag.Lookup(defaultTag)
+		info.fields[i] = meta
+
+		if !meta.tag.IsEmpty() && meta.tag.Name != "-" {
+			if _, ok := info.byName[meta.tag.Name]; !ok {
+				info.byName[meta.tag.Name] = meta
+			}
+		}
+	}
+	for _, meta := range info.fields {
+		info.byName[meta.field.Name] = meta
+	}
+
+	actual, _ := typeCache.LoadOrStore(key, info)
+	return actual.(*typeInfo)
+}
+
+// lookupField finds field by field name or tag name, promoted fields of embedded struct are also found by name.
+func (info *typeInfo) lookupField(t reflect.Type, name string) (reflect.StructField, bool) {
+	if meta, ok := info.byName[name]; ok {
+		return meta.field, true
+	}
+	return t.FieldByName(name)
+}
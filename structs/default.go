@@ -0,0 +1,164 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package structs
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultTag is the tag key of field default value.
+const defaultTag = "default"
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// SetDefaults sets the zero value fields to the value of `default` tag, eg:
+//
+//	Port    int           `default:"8080"`
+//	Timeout time.Duration `default:"3s"`
+//	Hosts   []string      `default:"a.com,b.com"`  // or json array `default:"[\"a.com\"]"`
+//	Limits  map[string]int `default:"{\"cpu\":2}"` // json object
+//
+// nested structs, non-nil pointers to struct and structs in slices are filled recursively.
+// the struct should be passed to New by pointer.
+func (s *Struct) SetDefaults() error {
+	if !s.IsStruct() {
+		return fmt.Errorf("invalid struct %v", s)
+	}
+	if !s.rvalue.CanSet() {
+		return errors.New("struct can not be set, pass a pointer to New")
+	}
+	return setDefaults(s.rvalue)
+}
+
+// SetDefaults sets the zero value fields of struct to the value of `default` tag, value should be a pointer to struct.
+func SetDefaults(value any) error {
+	return New(value).SetDefaults()
+}
+
+func setDefaults(v reflect.Value) error {
+	info := getTypeInfo(v.Type(), defaultTagName)
+	for i, meta := range info.fields {
+		if !meta.field.IsExported() && !meta.field.Anonymous {
+			continue
+		}
+
+		fv := v.Field(i)
+		if meta.hasDefault && fv.IsZero() && fv.CanSet() {
+			if err := parseDefault(fv, meta.defaultValue); err != nil {
+				return fmt.Errorf("default value of field %s: %w", meta.field.Name, err)
+			}
+		}
+
+		if err := setNestedDefaults(fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setNestedDefaults(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() && v.Elem().Kind() == reflect.Struct {
+			return setNestedDefaults(v.Elem())
+		}
+	case reflect.Struct:
+		return setDefaults(v)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := setNestedDefaults(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseDefault parses the default value string and sets it to v.
+func parseDefault(v reflect.Value, s string) error {
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Ptr:
+		elem := reflect.New(v.Type().Elem())
+		if err := parseDefault(elem.Elem(), s); err != nil {
+			return err
+		}
+		v.Set(elem)
+	case reflect.Slice:
+		if strings.HasPrefix(strings.TrimSpace(s), "[") {
+			return unmarshalDefault(v, s)
+		}
+		items := strings.Split(s, ",")
+		slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := parseDefault(slice.Index(i), strings.TrimSpace(item)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	case reflect.Map, reflect.Struct, reflect.Array:
+		return unmarshalDefault(v, s)
+	default:
+		return fmt.Errorf("unsupported type %v", v.Type())
+	}
+
+	return nil
+}
+
+func unmarshalDefault(v reflect.Value, s string) error {
+	ptr := reflect.New(v.Type())
+	if err := json.Unmarshal([]byte(s), ptr.Interface()); err != nil {
+		return err
+	}
+	v.Set(ptr.Elem())
+	return nil
+}
@@ -0,0 +1,61 @@
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestSetDefaults(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSetDefaults")
+
+	type Server struct {
+		Host string `default:"localhost"`
+		Port int    `default:"8080"`
+	}
+	type Config struct {
+		Server
+		Name     string         `default:"app"`
+		Debug    bool           `default:"true"`
+		Rate     float64        `default:"0.5"`
+		Mask     uint8          `default:"0xff"`
+		Timeout  time.Duration  `default:"3s"`
+		Start    time.Time      `default:"2025-01-01T00:00:00Z"`
+		Hosts    []string       `default:"a.com, b.com"`
+		Ports    []int          `default:"[80,443]"`
+		Limits   map[string]int `default:"{\"cpu\":2}"`
+		Retry    *int           `default:"3"`
+		Backup   *Server
+		Replicas []Server
+		Keep     string `default:"x"`
+	}
+
+	c := &Config{Keep: "kept", Backup: &Server{Port: 1}, Replicas: []Server{{}}}
+	err := SetDefaults(c)
+	assert.IsNil(err)
+
+	assert.Equal("localhost", c.Host)
+	assert.Equal(8080, c.Port)
+	assert.Equal("app", c.Name)
+	assert.Equal(true, c.Debug)
+	assert.Equal(0.5, c.Rate)
+	assert.Equal(uint8(255), c.Mask)
+	assert.Equal(3*time.Second, c.Timeout)
+	assert.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), c.Start)
+	assert.Equal([]string{"a.com", "b.com"}, c.Hosts)
+	assert.Equal([]int{80, 443}, c.Ports)
+	assert.Equal(map[string]int{"cpu": 2}, c.Limits)
+	assert.Equal(3, *c.Retry)
+	assert.Equal(Server{Host: "localhost", Port: 1}, *c.Backup)
+	assert.Equal([]Server{{Host: "localhost", Port: 8080}}, c.Replicas)
+	assert.Equal("kept", c.Keep)
+
+	type Invalid struct {
+		Port int `default:"abc"`
+	}
+	assert.IsNotNil(SetDefaults(&Invalid{}))
+	assert.IsNotNil(SetDefaults(Config{}))
+}
@@ -0,0 +1,715 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package structs
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ChangeType is the type of change.
+type ChangeType string
+
+const (
+	// ChangeCreate means the value is added, eg: new map key or slice element.
+	ChangeCreate ChangeType = "create"
+	// ChangeUpdate means the value is modified.
+	ChangeUpdate ChangeType = "update"
+	// ChangeDelete means the value is removed, eg: deleted map key or slice element.
+	ChangeDelete ChangeType = "delete"
+)
+
+// Change is a difference of two values at the path.
+type Change struct {
+	Type ChangeType `json:"type"`
+	// Path is the segments from root to the changed value, struct field is named by tag (`json` by default),
+	// slice element is named by index and map value is named by key.
+	Path []string `json:"path"`
+	From any      `json:"from"`
+	To   any      `json:"to"`
+}
+
+// PathString returns the path joined by dot, eg: "address.city".
+func (c Change) PathString() string {
+	return strings.Join(c.Path, ".")
+}
+
+// Changelog is a list of changes.
+type Changelog []Change
+
+// DiffOption is option of Diff, Patch and MergePatch.
+type DiffOption func(opts *diffOptions)
+
+type diffOptions struct {
+	tagName     string
+	ignoreTags  map[string][]string
+	ignorePaths map[string]bool
+}
+
+func newDiffOptions(opts []DiffOption) *diffOptions {
+	options := &diffOptions{
+		tagName:     defaultTagName,
+		ignoreTags:  map[string][]string{"diff": {"-"}},
+		ignorePaths: map[string]bool{},
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// WithDiffTagName sets the tag used to name struct fields in path, default is `json`.
+// field without the tag is named by field name, field whose tag name is "-" is ignored.
+func WithDiffTagName(tagName string) DiffOption {
+	return func(opts *diffOptions) {
+		opts.tagName = tagName
+	}
+}
+
+// WithIgnoreTag ignores struct fields whose tag `key` has one of values, default values is "-".
+// fields tagged with `diff:"-"` are always ignored.
+func WithIgnoreTag(key string, values ...string) DiffOption {
+	if len(values) == 0 {
+		values = []string{"-"}
+	}
+	return func(opts *diffOptions) {
+		opts.ignoreTags[key] = values
+	}
+}
+
+// WithIgnorePaths ignores values at the paths, path segments are joined by dot, eg: "address.city".
+func WithIgnorePaths(paths ...string) DiffOption {
+	return func(opts *diffOptions) {
+		for _, path := range paths {
+			opts.ignorePaths[path] = true
+		}
+	}
+}
+
+// Diff compares two values of same type and returns the changes from a to b,
+// nested structs, pointers, slices and maps are compared recursively. map keys should be string, integer, bool
+// or encoding.TextMarshaler, so the path can be converted back to key by Patch.
+func Diff(a, b any, opts ...DiffOption) (Changelog, error) {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.IsValid() && vb.IsValid() && va.Type() != vb.Type() {
+		return nil, fmt.Errorf("can not diff different types %v and %v", va.Type(), vb.Type())
+	}
+
+	d := &differ{opts: newDiffOptions(opts), changes: Changelog{}}
+	d.diff(nil, va, vb)
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	return d.changes, nil
+}
+
+type differ struct {
+	opts    *diffOptions
+	changes Changelog
+	// err is the first error of diffing, eg: unsupported map key type.
+	err error
+}
+
+func (d *differ) ignored(path []string) bool {
+	return len(path) > 0 && d.opts.ignorePaths[strings.Join(path, ".")]
+}
+
+func (d *differ) add(changeType ChangeType, path []string, from, to reflect.Value) {
+	d.changes = append(d.changes, Change{
+		Type: changeType,
+		Path: append([]string{}, path...),
+		From: exportValue(from),
+		To:   exportValue(to),
+	})
+}
+
+func (d *differ) diff(path []string, a, b reflect.Value) {
+	if d.ignored(path) || d.err != nil {
+		return
+	}
+
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			d.add(ChangeUpdate, path, a, b)
+		}
+		return
+	}
+
+	if a.Type() != b.Type() {
+		d.add(ChangeUpdate, path, a, b)
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.add(ChangeUpdate, path, a, b)
+			}
+			return
+		}
+		d.diff(path, a.Elem(), b.Elem())
+	case reflect.Struct:
+		if isLeafStruct(a.Type()) {
+			if !valueEqual(a, b) {
+				d.add(ChangeUpdate, path, a, b)
+			}
+			return
+		}
+		for _, f := range structFields(a.Type(), d.opts) {
+			d.diff(append(path, f.name), a.FieldByIndex(f.index), b.FieldByIndex(f.index))
+		}
+	case reflect.Map:
+		d.diffMap(path, a, b)
+	case reflect.Slice, reflect.Array:
+		d.diffSlice(path, a, b)
+	default:
+		if !valueEqual(a, b) {
+			d.add(ChangeUpdate, path, a, b)
+		}
+	}
+}
+
+func (d *differ) diffMap(path []string, a, b reflect.Value) {
+	keys := map[string]reflect.Value{}
+	for _, k := range append(a.MapKeys(), b.MapKeys()...) {
+		name, err := mapKeyString(k)
+		if err != nil {
+			d.err = err
+			return
+		}
+		keys[name] = k
+	}
+
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		key := keys[name]
+		va, vb := a.MapIndex(key), b.MapIndex(key)
+		p := append(path, name)
+
+		switch {
+		case !va.IsValid():
+			if !d.ignored(p) {
+				d.add(ChangeCreate, p, va, vb)
+			}
+		case !vb.IsValid():
+			if !d.ignored(p) {
+				d.add(ChangeDelete, p, va, vb)
+			}
+		default:
+			d.diff(p, va, vb)
+		}
+	}
+}
+
+func (d *differ) diffSlice(path []string, a, b reflect.Value) {
+	n := a.Len()
+	if b.Len() < n {
+		n = b.Len()
+	}
+
+	for i := 0; i < n; i++ {
+		d.diff(append(path, strconv.Itoa(i)), a.Index(i), b.Index(i))
+	}
+
+	for i := n; i < b.Len(); i++ {
+		if p := append(path, strconv.Itoa(i)); !d.ignored(p) {
+			d.add(ChangeCreate, p, reflect.Value{}, b.Index(i))
+		}
+	}
+
+	// elements are deleted from the end, so the changes can be applied in order.
+	for i := a.Len() - 1; i >= n; i-- {
+		if p := append(path, strconv.Itoa(i)); !d.ignored(p) {
+			d.add(ChangeDelete, p, a.Index(i), reflect.Value{})
+		}
+	}
+}
+
+// Patch applies the changes to target, target should be a pointer.
+// pointers, slices and maps are copied before modifying, so values shared with target are not changed.
+// the value of change is converted to the type at path, values decoded from json are also supported.
+func Patch(target any, changes Changelog, opts ...DiffOption) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("target should be a non-nil pointer, got %T", target)
+	}
+
+	options := newDiffOptions(opts)
+	for _, change := range changes {
+		if err := applyChange(v.Elem(), change.Path, change, options); err != nil {
+			return fmt.Errorf("patch %q: %w", change.PathString(), err)
+		}
+	}
+
+	return nil
+}
+
+func applyChange(v reflect.Value, path []string, change Change, opts *diffOptions) error {
+	if len(path) == 0 {
+		if change.Type == ChangeDelete {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		return setValue(v, change.To)
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(v.Type().Elem())
+		if !v.IsNil() {
+			elem.Elem().Set(v.Elem())
+		}
+		if err := applyChange(elem.Elem(), path, change, opts); err != nil {
+			return err
+		}
+		v.Set(elem)
+	case reflect.Interface:
+		if v.IsNil() {
+			return fmt.Errorf("can not patch nil interface at %q", path[0])
+		}
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+		if err := applyChange(elem, path, change, opts); err != nil {
+			return err
+		}
+		v.Set(elem)
+	case reflect.Struct:
+		f, ok := findField(v.Type(), path[0], opts)
+		if !ok {
+			return fmt.Errorf("field %q is not found in %v", path[0], v.Type())
+		}
+		return applyChange(v.FieldByIndex(f.index), path[1:], change, opts)
+	case reflect.Map:
+		return applyMap(v, path, change, opts)
+	case reflect.Slice:
+		return applySlice(v, path, change, opts)
+	case reflect.Array:
+		i, err := strconv.Atoi(path[0])
+		if err != nil || i < 0 || i >= v.Len() {
+			return fmt.Errorf("invalid array index %q", path[0])
+		}
+		return applyChange(v.Index(i), path[1:], change, opts)
+	default:
+		return fmt.Errorf("can not patch %q of %v", path[0], v.Type())
+	}
+
+	return nil
+}
+
+func applyMap(v reflect.Value, path []string, change Change, opts *diffOptions) error {
+	key, err := mapKey(v.Type().Key(), path[0])
+	if err != nil {
+		return err
+	}
+
+	m := reflect.MakeMapWithSize(v.Type(), v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		m.SetMapIndex(iter.Key(), iter.Value())
+	}
+
+	if len(path) == 1 && change.Type == ChangeDelete {
+		m.SetMapIndex(key, reflect.Value{})
+		v.Set(m)
+		return nil
+	}
+
+	elem := reflect.New(v.Type().Elem()).Elem()
+	if existing := m.MapIndex(key); existing.IsValid() {
+		elem.Set(existing)
+	}
+	if err := applyChange(elem, path[1:], change, opts); err != nil {
+		return err
+	}
+	m.SetMapIndex(key, elem)
+	v.Set(m)
+
+	return nil
+}
+
+func applySlice(v reflect.Value, path []string, change Change, opts *diffOptions) error {
+	i, err := strconv.Atoi(path[0])
+	if err != nil || i < 0 || i > v.Len() {
+		return fmt.Errorf("invalid slice index %q", path[0])
+	}
+
+	n := v.Len()
+	if len(path) == 1 && change.Type == ChangeDelete {
+		if i == n {
+			return fmt.Errorf("invalid slice index %q", path[0])
+		}
+		s := reflect.MakeSlice(v.Type(), 0, n-1)
+		s = reflect.AppendSlice(s, v.Slice(0, i))
+		s = reflect.AppendSlice(s, v.Slice(i+1, n))
+		v.Set(s)
+		return nil
+	}
+
+	if i == n && (len(path) > 1 || change.Type != ChangeCreate) {
+		return fmt.Errorf("invalid slice index %q", path[0])
+	}
+
+	s := reflect.MakeSlice(v.Type(), n, n+1)
+	reflect.Copy(s, v)
+
+	if len(path) == 1 && change.Type == ChangeCreate {
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if err := setValue(elem, change.To); err != nil {
+			return err
+		}
+		// insert the element at index i.
+		s = reflect.Append(s, elem)
+		reflect.Copy(s.Slice(i+1, n+1), s.Slice(i, n))
+		s.Index(i).Set(elem)
+		v.Set(s)
+		return nil
+	}
+
+	if err := applyChange(s.Index(i), path[1:], change, opts); err != nil {
+		return err
+	}
+	v.Set(s)
+
+	return nil
+}
+
+// setValue sets value to v, value is converted to the type of v if required.
+func setValue(v reflect.Value, value any) error {
+	if value == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.Type().AssignableTo(v.Type()):
+		v.Set(rv)
+		return nil
+	case rv.Type().ConvertibleTo(v.Type()) && isConvertible(rv.Kind(), v.Kind()):
+		v.Set(rv.Convert(v.Type()))
+		return nil
+	}
+
+	// fallback for values decoded from json, eg: float64 or map[string]any.
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	ptr := reflect.New(v.Type())
+	if err := json.Unmarshal(data, ptr.Interface()); err != nil {
+		return fmt.Errorf("can not convert %T to %v: %w", value, v.Type(), err)
+	}
+	v.Set(ptr.Elem())
+
+	return nil
+}
+
+// isConvertible checks if reflect conversion keeps the meaning of value, eg: int to string is not.
+func isConvertible(from, to reflect.Kind) bool {
+	return (from == reflect.String) == (to == reflect.String)
+}
+
+// JSONPatch converts the changelog to JSON Patch (RFC 6902) document.
+// create, update and delete changes are converted to add, replace and remove operations.
+func (c Changelog) JSONPatch() ([]byte, error) {
+	ops := make([]map[string]any, 0, len(c))
+	for _, change := range c {
+		op := map[string]any{"path": jsonPointer(change.Path)}
+		switch change.Type {
+		case ChangeCreate:
+			op["op"] = "add"
+			op["value"] = change.To
+		case ChangeUpdate:
+			op["op"] = "replace"
+			op["value"] = change.To
+		case ChangeDelete:
+			op["op"] = "remove"
+		default:
+			return nil, fmt.Errorf("unknown change type %q", change.Type)
+		}
+		ops = append(ops, op)
+	}
+	return json.Marshal(ops)
+}
+
+// JSONPatch compares a and b, and returns the JSON Patch (RFC 6902) document from a to b.
+func JSONPatch(a, b any, opts ...DiffOption) ([]byte, error) {
+	changes, err := Diff(a, b, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return changes.JSONPatch()
+}
+
+// MergePatch compares a and b, and returns the JSON Merge Patch (RFC 7396) document from a to b.
+// since merge patch can not change part of an array, a changed slice is replaced as a whole.
+func MergePatch(a, b any, opts ...DiffOption) ([]byte, error) {
+	changes, err := Diff(a, b, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	options := newDiffOptions(opts)
+	root := reflect.ValueOf(b)
+
+	var patch any = map[string]any{}
+	for _, change := range changes {
+		path, value, deleted := mergePatchValue(root, change, options)
+		if len(path) == 0 {
+			patch = value
+			break
+		}
+
+		node := patch.(map[string]any)
+		for _, p := range path[:len(path)-1] {
+			child, ok := node[p].(map[string]any)
+			if !ok {
+				child = map[string]any{}
+				node[p] = child
+			}
+			node = child
+		}
+
+		if deleted {
+			node[path[len(path)-1]] = nil
+		} else {
+			node[path[len(path)-1]] = value
+		}
+	}
+
+	return json.Marshal(patch)
+}
+
+// mergePatchValue returns the path and value of change in merge patch,
+// if the path passes through an array, it is truncated to the array and the value is the whole array.
+func mergePatchValue(root reflect.Value, change Change, opts *diffOptions) ([]string, any, bool) {
+	v := root
+	for i, p := range change.Path {
+		v = indirect(v)
+		if !v.IsValid() {
+			break
+		}
+
+		switch v.Kind() {
+		case reflect.Slice, reflect.Array:
+			return change.Path[:i], exportValue(v), false
+		case reflect.Struct:
+			f, ok := findField(v.Type(), p, opts)
+			if !ok {
+				return change.Path, change.To, change.Type == ChangeDelete
+			}
+			v = v.FieldByIndex(f.index)
+		case reflect.Map:
+			key, err := mapKey(v.Type().Key(), p)
+			if err != nil {
+				return change.Path, change.To, change.Type == ChangeDelete
+			}
+			v = v.MapIndex(key)
+		default:
+			return change.Path, change.To, change.Type == ChangeDelete
+		}
+	}
+
+	return change.Path, change.To, change.Type == ChangeDelete
+}
+
+// jsonPointer converts path to JSON Pointer (RFC 6901).
+func jsonPointer(path []string) string {
+	var sb strings.Builder
+	for _, p := range path {
+		sb.WriteByte('/')
+		p = strings.ReplaceAll(p, "~", "~0")
+		sb.WriteString(strings.ReplaceAll(p, "/", "~1"))
+	}
+	return sb.String()
+}
+
+// fieldInfo is a struct field which takes part in diff.
+type fieldInfo struct {
+	name  string
+	index []int
+}
+
+// structFields returns the fields of struct type, fields of embedded struct without tag are flattened like encoding/json.
+func structFields(t reflect.Type, opts *diffOptions) []fieldInfo {
+	fields := []fieldInfo{}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if isIgnoredField(sf, opts) {
+			continue
+		}
+
+		tag := newTag(sf.Tag.Get(opts.tagName))
+		if sf.Anonymous && tag.IsEmpty() && sf.Type.Kind() == reflect.Struct && !isLeafStruct(sf.Type) {
+			for _, f := range structFields(sf.Type, opts) {
+				f.index = append([]int{i}, f.index...)
+				fields = append(fields, f)
+			}
+			continue
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		name := sf.Name
+		if !tag.IsEmpty() {
+			name = tag.Name
+		}
+		fields = append(fields, fieldInfo{name: name, index: []int{i}})
+	}
+	return fields
+}
+
+func findField(t reflect.Type, name string, opts *diffOptions) (fieldInfo, bool) {
+	for _, f := range structFields(t, opts) {
+		if f.name == name {
+			return f, true
+		}
+	}
+	return fieldInfo{}, false
+}
+
+func isIgnoredField(sf reflect.StructField, opts *diffOptions) bool {
+	if newTag(sf.Tag.Get(opts.tagName)).Name == "-" {
+		return true
+	}
+
+	for key, values := range opts.ignoreTags {
+		tagValue, ok := sf.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		name := newTag(tagValue).Name
+		for _, v := range values {
+			if name == v {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isLeafStruct checks if struct type should be compared as a whole, eg: time.Time.
+func isLeafStruct(t reflect.Type) bool {
+	if _, ok := equalMethod(t); ok {
+		return true
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return false
+		}
+	}
+	return true
+}
+
+// equalMethod returns the `Equal(T) bool` method of type T.
+func equalMethod(t reflect.Type) (reflect.Method, bool) {
+	m, ok := t.MethodByName("Equal")
+	if !ok || m.Type.NumIn() != 2 || m.Type.NumOut() != 1 ||
+		m.Type.In(1) != t || m.Type.Out(0).Kind() != reflect.Bool {
+		return reflect.Method{}, false
+	}
+	return m, true
+}
+
+func valueEqual(a, b reflect.Value) bool {
+	if m, ok := equalMethod(a.Type()); ok && a.CanInterface() {
+		return m.Func.Call([]reflect.Value{a, b})[0].Bool()
+	}
+	if a.CanInterface() && b.CanInterface() {
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+	return false
+}
+
+func exportValue(v reflect.Value) any {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// mapKeyString converts map key to path segment, the key should be string, integer, bool
+// or encoding.TextMarshaler, so it can be converted back by mapKey.
+func mapKeyString(k reflect.Value) (string, error) {
+	switch {
+	case k.Kind() == reflect.String:
+		return k.String(), nil
+	case k.Type().Implements(textMarshalerType) && reflect.PtrTo(k.Type()).Implements(textUnmarshalerType):
+		text, err := k.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	case reflect.Bool:
+		return strconv.FormatBool(k.Bool()), nil
+	}
+
+	return "", fmt.Errorf("unsupported map key type %v", k.Type())
+}
+
+// mapKey converts path segment to map key, it's the inverse of mapKeyString.
+func mapKey(t reflect.Type, s string) (reflect.Value, error) {
+	key := reflect.New(t).Elem()
+	if t.Kind() != reflect.String && t.Implements(textMarshalerType) && reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		err := key.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+		return key, err
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		key.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return key, err
+		}
+		key.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return key, err
+		}
+		key.SetUint(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return key, err
+		}
+		key.SetBool(b)
+	default:
+		return key, fmt.Errorf("unsupported map key type %v", t)
+	}
+	return key, nil
+}
@@ -0,0 +1,280 @@
+package structs
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+type diffAddress struct {
+	City   string `json:"city"`
+	Street string `json:"street"`
+}
+
+type diffUser struct {
+	Name      string            `json:"name"`
+	Age       int               `json:"age"`
+	Password  string            `json:"password" audit:"-"`
+	Version   int               `json:"version" diff:"-"`
+	Address   *diffAddress      `json:"address"`
+	Tags      []string          `json:"tags"`
+	Labels    map[string]string `json:"labels"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	secret    string
+}
+
+func newDiffUser() diffUser {
+	return diffUser{
+		Name:      "Tom",
+		Age:       20,
+		Password:  "123",
+		Version:   1,
+		Address:   &diffAddress{City: "Beijing", Street: "Main"},
+		Tags:      []string{"a", "b", "c"},
+		Labels:    map[string]string{"k1": "v1", "k2": "v2"},
+		UpdatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		secret:    "s1",
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDiff")
+
+	a := newDiffUser()
+	b := newDiffUser()
+	b.Age = 21
+	b.Version = 2
+	b.secret = "s2"
+	b.Address = &diffAddress{City: "Shanghai", Street: "Main"}
+	b.Tags = []string{"a", "x"}
+	b.Labels = map[string]string{"k1": "v1", "k3": "v3"}
+	b.UpdatedAt = a.UpdatedAt.In(time.FixedZone("CST", 8*3600))
+
+	changes, err := Diff(a, b)
+	assert.IsNil(err)
+
+	expected := Changelog{
+		{Type: ChangeUpdate, Path: []string{"age"}, From: 20, To: 21},
+		{Type: ChangeUpdate, Path: []string{"address", "city"}, From: "Beijing", To: "Shanghai"},
+		{Type: ChangeUpdate, Path: []string{"tags", "1"}, From: "b", To: "x"},
+		{Type: ChangeDelete, Path: []string{"tags", "2"}, From: "c", To: nil},
+		{Type: ChangeDelete, Path: []string{"labels", "k2"}, From: "v2", To: nil},
+		{Type: ChangeCreate, Path: []string{"labels", "k3"}, From: nil, To: "v3"},
+	}
+	assert.Equal(expected, changes)
+	assert.Equal("address.city", changes[1].PathString())
+
+	changes, err = Diff(a, a)
+	assert.IsNil(err)
+	assert.Equal(0, len(changes))
+
+	_, err = Diff(a, &b)
+	assert.IsNotNil(err)
+}
+
+func TestDiff_Options(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDiff_Options")
+
+	a := newDiffUser()
+	b := newDiffUser()
+	b.Name = "Jerry"
+	b.Password = "456"
+	b.Address.City = "Shanghai"
+
+	changes, _ := Diff(a, b, WithIgnoreTag("audit"), WithIgnorePaths("address.city"))
+	assert.Equal(Changelog{
+		{Type: ChangeUpdate, Path: []string{"name"}, From: "Tom", To: "Jerry"},
+	}, changes)
+
+	// created and deleted slice elements are ignored too.
+	b = newDiffUser()
+	b.Tags = []string{"a", "b", "c", "d", "e"}
+	changes, _ = Diff(a, b, WithIgnorePaths("tags.3"))
+	assert.Equal(Changelog{
+		{Type: ChangeCreate, Path: []string{"tags", "4"}, From: nil, To: "e"},
+	}, changes)
+	changes, _ = Diff(b, a, WithIgnorePaths("tags.4"))
+	assert.Equal(Changelog{
+		{Type: ChangeDelete, Path: []string{"tags", "3"}, From: "d", To: nil},
+	}, changes)
+
+	type Item struct {
+		Name string `db:"item_name"`
+		Note string `db:"-"`
+	}
+	changes, _ = Diff(Item{"a", "x"}, Item{"b", "y"}, WithDiffTagName("db"))
+	assert.Equal(Changelog{
+		{Type: ChangeUpdate, Path: []string{"item_name"}, From: "a", To: "b"},
+	}, changes)
+}
+
+func TestDiff_Embedded(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDiff_Embedded")
+
+	type Base struct {
+		ID int `json:"id"`
+	}
+	type Entity struct {
+		Base
+		Name string
+		Ptr  *int `json:"ptr"`
+	}
+
+	n := 1
+	changes, _ := Diff(Entity{Base: Base{1}, Name: "a"}, Entity{Base: Base{2}, Name: "b", Ptr: &n})
+	assert.Equal(Changelog{
+		{Type: ChangeUpdate, Path: []string{"id"}, From: 1, To: 2},
+		{Type: ChangeUpdate, Path: []string{"Name"}, From: "a", To: "b"},
+		{Type: ChangeUpdate, Path: []string{"ptr"}, From: (*int)(nil), To: &n},
+	}, changes)
+}
+
+func TestDiff_MapKey(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDiff_MapKey")
+
+	day1 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+
+	// keys are converted to path by encoding.TextMarshaler, and converted back by Patch.
+	a := map[time.Time]int{day1: 1}
+	b := map[time.Time]int{day1: 2, day2: 3}
+	changes, err := Diff(a, b)
+	assert.IsNil(err)
+	assert.Equal(Changelog{
+		{Type: ChangeUpdate, Path: []string{"2025-01-01T00:00:00Z"}, From: 1, To: 2},
+		{Type: ChangeCreate, Path: []string{"2025-01-02T00:00:00Z"}, From: nil, To: 3},
+	}, changes)
+
+	assert.IsNil(Patch(&a, changes))
+	assert.Equal(b, a)
+
+	ints := map[int8]string{-1: "a"}
+	changes, err = Diff(ints, map[int8]string{-1: "b", 2: "c"})
+	assert.IsNil(err)
+	assert.IsNil(Patch(&ints, changes))
+	assert.Equal(map[int8]string{-1: "b", 2: "c"}, ints)
+
+	// keys which can't be converted back are not supported.
+	type point struct{ X, Y int }
+	_, err = Diff(map[point]int{{1, 2}: 1}, map[point]int{{1, 2}: 2})
+	assert.IsNotNil(err)
+	_, err = Diff(map[float64]int{1.5: 1}, map[float64]int{})
+	assert.IsNotNil(err)
+}
+
+func TestPatch(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPatch")
+
+	a := newDiffUser()
+	b := newDiffUser()
+	b.Age = 21
+	b.Address = &diffAddress{City: "Shanghai", Street: "Main"}
+	b.Tags = []string{"x", "b", "c", "d", "e"}
+	b.Labels = map[string]string{"k1": "v1", "k3": "v3"}
+
+	changes, _ := Diff(a, b)
+
+	target := newDiffUser()
+	target.Address = &diffAddress{City: "Beijing", Street: "Main"}
+	tags, labels := target.Tags, target.Labels
+
+	err := Patch(&target, changes)
+	assert.IsNil(err)
+
+	changes, _ = Diff(target, b)
+	assert.Equal(0, len(changes))
+
+	// slices and maps shared with target are not modified.
+	assert.Equal([]string{"a", "b", "c"}, tags)
+	assert.Equal(map[string]string{"k1": "v1", "k2": "v2"}, labels)
+
+	t.Run("delete slice elements", func(_ *testing.T) {
+		changes, _ := Diff(b, a)
+		target := b
+		err := Patch(&target, changes)
+		assert.IsNil(err)
+		assert.Equal(a.Tags, target.Tags)
+		assert.Equal(a.Labels, target.Labels)
+	})
+
+	t.Run("changes decoded from json", func(_ *testing.T) {
+		changes, _ := Diff(a, b)
+		data, err := json.Marshal(changes)
+		assert.IsNil(err)
+
+		var decoded Changelog
+		assert.IsNil(json.Unmarshal(data, &decoded))
+
+		target := newDiffUser()
+		assert.IsNil(Patch(&target, decoded))
+		assert.Equal(21, target.Age)
+		assert.Equal("Shanghai", target.Address.City)
+		assert.Equal(b.Tags, target.Tags)
+	})
+
+	t.Run("invalid", func(_ *testing.T) {
+		target := newDiffUser()
+		assert.IsNotNil(Patch(target, changes))
+		assert.IsNotNil(Patch(&target, Changelog{{Type: ChangeUpdate, Path: []string{"unknown"}, To: 1}}))
+		assert.IsNotNil(Patch(&target, Changelog{{Type: ChangeUpdate, Path: []string{"tags", "9"}, To: "x"}}))
+		assert.IsNotNil(Patch(&target, Changelog{{Type: ChangeUpdate, Path: []string{"age"}, To: "x"}}))
+	})
+}
+
+func TestJSONPatch(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestJSONPatch")
+
+	a := newDiffUser()
+	b := newDiffUser()
+	b.Age = 0
+	b.Tags = []string{"a", "b"}
+	b.Labels = map[string]string{"k1": "v1", "k2": "v2", "a/b": "x"}
+
+	data, err := JSONPatch(a, b)
+	assert.IsNil(err)
+	assert.Equal(`[{"op":"replace","path":"/age","value":0},`+
+		`{"op":"remove","path":"/tags/2"},`+
+		`{"op":"add","path":"/labels/a~1b","value":"x"}]`, string(data))
+
+	_, err = Changelog{{Type: "unknown"}}.JSONPatch()
+	assert.IsNotNil(err)
+}
+
+func TestMergePatch(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestMergePatch")
+
+	a := newDiffUser()
+	b := newDiffUser()
+	b.Age = 21
+	b.Address.City = "Shanghai"
+	b.Tags = []string{"a", "x"}
+	b.Labels = map[string]string{"k1": "v1"}
+
+	data, err := MergePatch(a, b)
+	assert.IsNil(err)
+	assert.Equal(`{"address":{"city":"Shanghai"},"age":21,"labels":{"k2":null},"tags":["a","x"]}`, string(data))
+
+	data, err = MergePatch(a, a)
+	assert.IsNil(err)
+	assert.Equal(`{}`, string(data))
+
+	data, err = MergePatch([]int{1}, []int{2})
+	assert.IsNil(err)
+	assert.Equal(`[2]`, string(data))
+}
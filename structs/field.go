@@ -1,6 +1,7 @@
 package structs
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/duke-git/lancet/v2/pointer"
@@ -14,10 +15,14 @@ type Field struct {
 }
 
 func newField(v reflect.Value, f reflect.StructField, tagName string) *Field {
-	tag := f.Tag.Get(tagName)
+	return newFieldWithTag(v, f, newTag(f.Tag.Get(tagName)), tagName)
+}
+
+func newFieldWithTag(v reflect.Value, f reflect.StructField, tag *Tag, tagName string) *Field {
 	field := &Field{
 		field: f,
-		tag:   newTag(tag),
+		// copy the tag, it may be shared by cached type metadata.
+		tag: &Tag{Name: tag.Name, Options: append([]string{}, tag.Options...)},
 	}
 	field.rvalue = v
 	field.rtype = v.Type()
@@ -37,6 +42,15 @@ func (f *Field) Value() any {
 	return f.rvalue.Interface()
 }
 
+// Set sets the value of field, value is converted to the field type if required.
+// the field should be exported and the struct should be passed to New by pointer.
+func (f *Field) Set(value any) error {
+	if !f.rvalue.CanSet() {
+		return fmt.Errorf("field %s can not be set", f.field.Name)
+	}
+	return setValue(f.rvalue, value)
+}
+
 // IsEmbedded returns true if the given field is an embedded field.
 // Play: https://go.dev/play/p/wV2PrbYm3Ec
 func (f *Field) IsEmbedded() bool {
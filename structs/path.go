@@ -0,0 +1,340 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SkipField is used as a return value from WalkFunc to indicate that
+// the nested values of the field are skipped.
+var SkipField = errors.New("skip this field")
+
+// WalkFunc is the function called by Walk for each struct field, path is the path of field
+// which can be used by Get and Set, eg: "Addr.City", "Items[0].Name", "Labels[key].Value".
+type WalkFunc func(path string, field *Field) error
+
+// Get returns the value at path, path segments are separated by dot and slice index or map key is in brackets,
+// eg: "Addr.City", "Tags[0]", "Labels[key]". struct field is matched by field name or tag name.
+func (s *Struct) Get(path string) (any, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	v := s.rvalue
+	for _, seg := range segments {
+		v, err = s.child(v, seg)
+		if err != nil {
+			return nil, fmt.Errorf("get %q: %w", path, err)
+		}
+	}
+
+	if !v.CanInterface() {
+		return nil, fmt.Errorf("get %q: unexported field", path)
+	}
+
+	return v.Interface(), nil
+}
+
+// Set sets the value at path, nil pointers and maps on the path are allocated, value is converted to the
+// type at path if required. the struct should be passed to New by pointer.
+func (s *Struct) Set(path string, value any) error {
+	segments, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+
+	if !s.rvalue.CanSet() {
+		return errors.New("struct can not be set, pass a pointer to New")
+	}
+
+	if err := s.set(s.rvalue, segments, value); err != nil {
+		return fmt.Errorf("set %q: %w", path, err)
+	}
+
+	return nil
+}
+
+// Walk calls fn for each field of struct in depth-first order, nested structs, pointers to struct,
+// and structs in slices and maps are walked into. map keys are visited in sorted order.
+func (s *Struct) Walk(fn WalkFunc) error {
+	if !s.IsStruct() {
+		return fmt.Errorf("invalid struct %v", s)
+	}
+	// ancestors is the pointers on the path from root to current value, a pointer to one of them is a cycle.
+	// the same pointer may appear in different paths, it's walked in every path.
+	ancestors := map[walkPointer]bool{}
+	if s.rvalue.CanAddr() {
+		ancestors[walkPointer{s.rvalue.Addr().Pointer(), s.rvalue.Addr().Type()}] = true
+	}
+	return s.walk("", s.rvalue, fn, ancestors)
+}
+
+// walkPointer identifies a pointer, pointers to a struct and its first field have same address but different types.
+type walkPointer struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// Get returns the value at path of struct value.
+func Get(value any, path string) (any, error) {
+	return New(value).Get(path)
+}
+
+// Set sets the value at path of struct value, value should be a pointer to struct.
+func Set(value any, path string, fieldValue any) error {
+	return New(value).Set(path, fieldValue)
+}
+
+// Walk calls fn for each field of struct value in depth-first order.
+func Walk(value any, fn WalkFunc) error {
+	return New(value).Walk(fn)
+}
+
+// child returns the value of path segment in v.
+func (s *Struct) child(v reflect.Value, seg string) (reflect.Value, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, fmt.Errorf("nil value at %q", seg)
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		sf, ok := getTypeInfo(v.Type(), s.TagName).lookupField(v.Type(), seg)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("field %q is not found in %v", seg, v.Type())
+		}
+		return fieldByIndex(v, sf.Index, false)
+	case reflect.Map:
+		key, err := mapKey(v.Type().Key(), seg)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		elem := v.MapIndex(key)
+		if !elem.IsValid() {
+			return reflect.Value{}, fmt.Errorf("key %q is not found", seg)
+		}
+		return elem, nil
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(seg)
+		if err != nil || i < 0 || i >= v.Len() {
+			return reflect.Value{}, fmt.Errorf("index %q is out of range", seg)
+		}
+		return v.Index(i), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("can not get %q of %v", seg, v.Type())
+	}
+}
+
+func (s *Struct) set(v reflect.Value, segments []string, value any) error {
+	if len(segments) == 0 {
+		return setValue(v, value)
+	}
+
+	seg := segments[0]
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return s.set(v.Elem(), segments, value)
+	case reflect.Interface:
+		if v.IsNil() {
+			return fmt.Errorf("nil value at %q", seg)
+		}
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+		if err := s.set(elem, segments, value); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	case reflect.Struct:
+		sf, ok := getTypeInfo(v.Type(), s.TagName).lookupField(v.Type(), seg)
+		if !ok {
+			return fmt.Errorf("field %q is not found in %v", seg, v.Type())
+		}
+		fv, err := fieldByIndex(v, sf.Index, true)
+		if err != nil {
+			return err
+		}
+		if !fv.CanSet() {
+			return fmt.Errorf("field %q is unexported", seg)
+		}
+		return s.set(fv, segments[1:], value)
+	case reflect.Map:
+		key, err := mapKey(v.Type().Key(), seg)
+		if err != nil {
+			return err
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		// map element is not addressable, set a copy back to map.
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if existing := v.MapIndex(key); existing.IsValid() {
+			elem.Set(existing)
+		}
+		if err := s.set(elem, segments[1:], value); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, elem)
+		return nil
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(seg)
+		if err != nil || i < 0 || i >= v.Len() {
+			return fmt.Errorf("index %q is out of range", seg)
+		}
+		return s.set(v.Index(i), segments[1:], value)
+	default:
+		return fmt.Errorf("can not set %q of %v", seg, v.Type())
+	}
+}
+
+func (s *Struct) walk(path string, v reflect.Value, fn WalkFunc, ancestors map[walkPointer]bool) error {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		if v.Kind() == reflect.Ptr {
+			// avoid infinite loop of cyclic pointers.
+			p := walkPointer{v.Pointer(), v.Type()}
+			if ancestors[p] {
+				return nil
+			}
+			ancestors[p] = true
+			defer delete(ancestors, p)
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		info := getTypeInfo(v.Type(), s.TagName)
+		for i, meta := range info.fields {
+			fieldPath := meta.field.Name
+			if path != "" {
+				fieldPath = path + "." + fieldPath
+			}
+
+			fv := v.Field(i)
+			err := fn(fieldPath, newFieldWithTag(fv, meta.field, meta.tag, s.TagName))
+			if err == SkipField {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			if meta.field.IsExported() || meta.field.Anonymous {
+				if err := s.walk(fieldPath, fv, fn, ancestors); err != nil {
+					return err
+				}
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := s.walk(fmt.Sprintf("%s[%d]", path, i), v.Index(i), fn, ancestors); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		names := make([]string, len(keys))
+		for i, k := range keys {
+			names[i] = fmt.Sprint(k.Interface())
+		}
+		sort.Sort(keySorter{keys: keys, names: names})
+
+		for i, k := range keys {
+			if err := s.walk(path+"["+names[i]+"]", v.MapIndex(k), fn, ancestors); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+type keySorter struct {
+	keys  []reflect.Value
+	names []string
+}
+
+func (k keySorter) Len() int           { return len(k.keys) }
+func (k keySorter) Less(i, j int) bool { return k.names[i] < k.names[j] }
+func (k keySorter) Swap(i, j int) {
+	k.keys[i], k.keys[j] = k.keys[j], k.keys[i]
+	k.names[i], k.names[j] = k.names[j], k.names[i]
+}
+
+// fieldByIndex returns the nested field of struct, nil embedded pointers are allocated if alloc is true.
+func fieldByIndex(v reflect.Value, index []int, alloc bool) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !alloc || !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("nil embedded struct %v", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+// parsePath splits path into segments, eg: "Items[0].Labels[a.b]" -> ["Items", "0", "Labels", "a.b"].
+func parsePath(path string) ([]string, error) {
+	var segments []string
+	rest := path
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: unclosed bracket", path)
+			}
+			segments = append(segments, rest[1:end])
+			rest = rest[end+1:]
+			if len(rest) > 0 && rest[0] == '.' {
+				rest = rest[1:]
+				if len(rest) == 0 {
+					return nil, fmt.Errorf("invalid path %q: empty segment", path)
+				}
+			}
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid path %q: empty segment", path)
+			}
+			segments = append(segments, rest[:end])
+			rest = rest[end:]
+			if len(rest) > 0 && rest[0] == '.' {
+				rest = rest[1:]
+				if len(rest) == 0 {
+					return nil, fmt.Errorf("invalid path %q: empty segment", path)
+				}
+			}
+		}
+	}
+
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("invalid path %q: empty segment", path)
+	}
+
+	return segments, nil
+}
@@ -0,0 +1,201 @@
+package structs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+type pathAddress struct {
+	City string `json:"city"`
+}
+
+type pathItem struct {
+	Name string `json:"name"`
+}
+
+type pathBase struct {
+	ID int `json:"id"`
+}
+
+type pathUser struct {
+	pathBase
+	Name   string                  `json:"name"`
+	Addr   *pathAddress            `json:"addr"`
+	Items  []pathItem              `json:"items"`
+	Labels map[string]string       `json:"labels"`
+	Groups map[string]*pathAddress `json:"groups"`
+	Any    any                     `json:"any"`
+	age    int
+}
+
+func TestStruct_Get(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStruct_Get")
+
+	u := &pathUser{
+		pathBase: pathBase{ID: 1},
+		Name:     "Tom",
+		Addr:     &pathAddress{City: "Beijing"},
+		Items:    []pathItem{{Name: "a"}, {Name: "b"}},
+		Labels:   map[string]string{"k.1": "v1"},
+		Any:      map[string]any{"x": []int{1, 2}},
+		age:      20,
+	}
+	s := New(u)
+
+	tests := []struct {
+		path     string
+		expected any
+	}{
+		{"Name", "Tom"},
+		{"name", "Tom"},
+		{"ID", 1},
+		{"pathBase.ID", 1},
+		{"Addr.City", "Beijing"},
+		{"addr.city", "Beijing"},
+		{"Items[1].Name", "b"},
+		{"Items.0.name", "a"},
+		{"Labels[k.1]", "v1"},
+		{"Any.x[1]", 2},
+	}
+	for _, tt := range tests {
+		v, err := s.Get(tt.path)
+		assert.IsNil(err)
+		assert.Equal(tt.expected, v)
+	}
+
+	for _, path := range []string{"", "Unknown", "Items[2]", "Labels[k2]", "Groups[a].City", "age", "Items[0", "Addr.", "Name.x"} {
+		_, err := s.Get(path)
+		assert.IsNotNil(err)
+	}
+
+	v, err := Get(*u, "Addr.City")
+	assert.IsNil(err)
+	assert.Equal("Beijing", v)
+}
+
+func TestStruct_Set(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStruct_Set")
+
+	u := &pathUser{Items: []pathItem{{Name: "a"}}}
+	s := New(u)
+
+	assert.IsNil(s.Set("Name", "Tom"))
+	assert.IsNil(s.Set("ID", 2))
+	assert.IsNil(s.Set("addr.city", "Shanghai"))
+	assert.IsNil(s.Set("Items[0].Name", "b"))
+	assert.IsNil(s.Set("Labels[k1]", "v1"))
+	assert.IsNil(s.Set("Groups[home].City", "Beijing"))
+	assert.IsNil(Set(u, "Groups[home].City", "Hangzhou"))
+
+	assert.Equal("Tom", u.Name)
+	assert.Equal(2, u.ID)
+	assert.Equal("Shanghai", u.Addr.City)
+	assert.Equal("b", u.Items[0].Name)
+	assert.Equal(map[string]string{"k1": "v1"}, u.Labels)
+	assert.Equal("Hangzhou", u.Groups["home"].City)
+
+	assert.IsNotNil(s.Set("Items[1].Name", "c"))
+	assert.IsNotNil(s.Set("Name", []int{1}))
+	assert.IsNotNil(s.Set("age", 1))
+	assert.IsNotNil(New(*u).Set("Name", "Jerry"))
+
+	f, _ := s.Field("Name")
+	assert.IsNil(f.Set("Jerry"))
+	assert.Equal("Jerry", u.Name)
+}
+
+func TestStruct_Walk(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStruct_Walk")
+
+	type Node struct {
+		Name string
+		Next *Node
+	}
+	n := &Node{Name: "a"}
+	n.Next = &Node{Name: "b", Next: n}
+
+	var paths []string
+	err := Walk(n, func(path string, field *Field) error {
+		paths = append(paths, path)
+		return nil
+	})
+	assert.IsNil(err)
+	assert.Equal([]string{"Name", "Next", "Next.Name", "Next.Next"}, paths)
+
+	// the shared pointer is not a cycle, it's walked in every path.
+	type Pair struct {
+		Left  *Node
+		Right *Node
+	}
+	shared := &Node{Name: "c"}
+	paths = nil
+	err = Walk(&Pair{Left: shared, Right: shared}, func(path string, field *Field) error {
+		paths = append(paths, path)
+		return nil
+	})
+	assert.IsNil(err)
+	assert.Equal([]string{"Left", "Left.Name", "Left.Next", "Right", "Right.Name", "Right.Next"}, paths)
+
+	u := &pathUser{
+		Addr:   &pathAddress{City: "Beijing"},
+		Items:  []pathItem{{Name: "a"}},
+		Groups: map[string]*pathAddress{"b": {City: "x"}, "a": {City: "y"}},
+	}
+
+	paths = nil
+	err = Walk(u, func(path string, field *Field) error {
+		paths = append(paths, path)
+		if field.Name() == "pathBase" || field.Name() == "Addr" {
+			return SkipField
+		}
+		return nil
+	})
+	assert.IsNil(err)
+	assert.Equal([]string{
+		"pathBase", "Name", "Addr", "Items", "Items[0].Name", "Labels",
+		"Groups", "Groups[a].City", "Groups[b].City", "Any", "age",
+	}, paths)
+
+	for _, path := range paths[4:5] {
+		v, err := Get(u, path)
+		assert.IsNil(err)
+		assert.Equal("a", v)
+	}
+
+	stop := errors.New("stop")
+	err = Walk(u, func(path string, field *Field) error {
+		return stop
+	})
+	assert.Equal(stop, err)
+
+	assert.IsNotNil(Walk(1, func(string, *Field) error { return nil }))
+}
+
+func TestTypeInfoCache(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestTypeInfoCache")
+
+	typ := reflect.TypeOf(pathUser{})
+	info := getTypeInfo(typ, "json")
+	assert.Equal(true, info == getTypeInfo(typ, "json"))
+	assert.Equal(false, info == getTypeInfo(typ, "yaml"))
+
+	sf, ok := info.lookupField(typ, "addr")
+	assert.Equal(true, ok)
+	assert.Equal("Addr", sf.Name)
+
+	// fields returned by Struct.Fields do not share tags with cache.
+	f := New(pathUser{}).Fields()[1]
+	f.Tag().Name = "changed"
+	assert.Equal("name", New(pathUser{}).Fields()[1].Tag().Name)
+}
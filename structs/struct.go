@@ -22,8 +22,12 @@ type Struct struct {
 // New returns a new *Struct
 // Play: https://go.dev/play/p/O29l8kk-Z17
 func New(value any, tagName ...string) *Struct {
-	value = pointer.ExtractPointer(value)
+	// keep the value addressable if a pointer is given, so it can be modified by Set.
 	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	value = pointer.ExtractPointer(value)
 	t := reflect.TypeOf(value)
 
 	tn := defaultTagName
@@ -91,12 +95,10 @@ func (s *Struct) ToMap() (map[string]any, error) {
 // Fields returns all the struct fields within a slice
 // Play: https://go.dev/play/p/w3Kk_CyVY7D
 func (s *Struct) Fields() []*Field {
-	fieldNum := s.rvalue.NumField()
-	fields := make([]*Field, 0, fieldNum)
-	for i := 0; i < fieldNum; i++ {
-		v := s.rvalue.Field(i)
-		sf := s.rtype.Field(i)
-		field := newField(v, sf, s.TagName)
+	info := getTypeInfo(s.rtype, s.TagName)
+	fields := make([]*Field, 0, len(info.fields))
+	for i, meta := range info.fields {
+		field := newFieldWithTag(s.rvalue.Field(i), meta.field, meta.tag, s.TagName)
 		fields = append(fields, field)
 	}
 	return fields
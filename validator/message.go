@@ -0,0 +1,129 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package validator
+
+// defaultLocale is the default locale of error messages, it's also the fallback of other locales.
+const defaultLocale = "en"
+
+// builtinMessages is the message templates of builtin rules, "default" is used by rules without message.
+var builtinMessages = map[string]map[string]string{
+	"en": {
+		"default":         "{field} is invalid",
+		"required":        "{field} is required",
+		"len":             "{field} must be {param} in length",
+		"min":             "{field} must be at least {param}",
+		"max":             "{field} must be at most {param}",
+		"gt":              "{field} must be greater than {param}",
+		"gte":             "{field} must be greater than or equal to {param}",
+		"lt":              "{field} must be less than {param}",
+		"lte":             "{field} must be less than or equal to {param}",
+		"eq":              "{field} must be equal to {param}",
+		"ne":              "{field} must not be equal to {param}",
+		"oneof":           "{field} must be one of [{param}]",
+		"contains":        "{field} must contain {param}",
+		"startswith":      "{field} must start with {param}",
+		"endswith":        "{field} must end with {param}",
+		"regex":           "{field} must match {param}",
+		"alpha":           "{field} must contain only letters",
+		"alphanum":        "{field} must contain only letters and numbers",
+		"ascii":           "{field} must contain only ascii characters",
+		"printable":       "{field} must contain only printable characters",
+		"upper":           "{field} must be upper case",
+		"lower":           "{field} must be lower case",
+		"number":          "{field} must be a number",
+		"int":             "{field} must be an integer",
+		"float":           "{field} must be a float number",
+		"json":            "{field} must be a valid json",
+		"email":           "{field} must be a valid email address",
+		"url":             "{field} must be a valid url",
+		"dns":             "{field} must be a valid domain name",
+		"ip":              "{field} must be a valid ip address",
+		"ipv4":            "{field} must be a valid ipv4 address",
+		"ipv6":            "{field} must be a valid ipv6 address",
+		"port":            "{field} must be a valid port",
+		"ip_port":         "{field} must be a valid ip:port address",
+		"base64":          "{field} must be a valid base64 string",
+		"base64url":       "{field} must be a valid base64url string",
+		"bin":             "{field} must be a binary number",
+		"hex":             "{field} must be a hexadecimal number",
+		"jwt":             "{field} must be a valid jwt",
+		"credit_card":     "{field} must be a valid credit card number",
+		"visa":            "{field} must be a valid visa card number",
+		"mastercard":      "{field} must be a valid master card number",
+		"amex":            "{field} must be a valid american express card number",
+		"unionpay":        "{field} must be a valid union pay card number",
+		"chinese":         "{field} must contain chinese characters",
+		"chinese_mobile":  "{field} must be a valid chinese mobile number",
+		"chinese_id":      "{field} must be a valid chinese id number",
+		"chinese_phone":   "{field} must be a valid chinese phone number",
+		"weak_password":   "{field} must be a password of letters and numbers",
+		"strong_password": "{field} must be a strong password of at least {param} characters",
+		"passport":        "{field} must be a valid passport number of {param}",
+		"eqfield":         "{field} must be equal to {param}",
+		"nefield":         "{field} must not be equal to {param}",
+		"gtfield":         "{field} must be greater than {param}",
+		"gtefield":        "{field} must be greater than or equal to {param}",
+		"ltfield":         "{field} must be less than {param}",
+		"ltefield":        "{field} must be less than or equal to {param}",
+	},
+	"zh": {
+		"default":         "{field}无效",
+		"required":        "{field}为必填字段",
+		"len":             "{field}长度必须是{param}",
+		"min":             "{field}最小只能为{param}",
+		"max":             "{field}最大只能为{param}",
+		"gt":              "{field}必须大于{param}",
+		"gte":             "{field}必须大于或等于{param}",
+		"lt":              "{field}必须小于{param}",
+		"lte":             "{field}必须小于或等于{param}",
+		"eq":              "{field}必须等于{param}",
+		"ne":              "{field}不能等于{param}",
+		"oneof":           "{field}必须是[{param}]中的一个",
+		"contains":        "{field}必须包含{param}",
+		"startswith":      "{field}必须以{param}开头",
+		"endswith":        "{field}必须以{param}结尾",
+		"regex":           "{field}必须匹配{param}",
+		"alpha":           "{field}只能包含字母",
+		"alphanum":        "{field}只能包含字母和数字",
+		"ascii":           "{field}只能包含ascii字符",
+		"printable":       "{field}只能包含可打印字符",
+		"upper":           "{field}必须是大写",
+		"lower":           "{field}必须是小写",
+		"number":          "{field}必须是数字",
+		"int":             "{field}必须是整数",
+		"float":           "{field}必须是浮点数",
+		"json":            "{field}必须是有效的json",
+		"email":           "{field}必须是有效的邮箱",
+		"url":             "{field}必须是有效的url",
+		"dns":             "{field}必须是有效的域名",
+		"ip":              "{field}必须是有效的ip地址",
+		"ipv4":            "{field}必须是有效的ipv4地址",
+		"ipv6":            "{field}必须是有效的ipv6地址",
+		"port":            "{field}必须是有效的端口",
+		"ip_port":         "{field}必须是有效的ip:port地址",
+		"base64":          "{field}必须是有效的base64字符串",
+		"base64url":       "{field}必须是有效的base64url字符串",
+		"bin":             "{field}必须是二进制数",
+		"hex":             "{field}必须是十六进制数",
+		"jwt":             "{field}必须是有效的jwt",
+		"credit_card":     "{field}必须是有效的信用卡号",
+		"visa":            "{field}必须是有效的visa卡号",
+		"mastercard":      "{field}必须是有效的master卡号",
+		"amex":            "{field}必须是有效的美国运通卡号",
+		"unionpay":        "{field}必须是有效的银联卡号",
+		"chinese":         "{field}必须包含中文",
+		"chinese_mobile":  "{field}必须是有效的手机号",
+		"chinese_id":      "{field}必须是有效的身份证号",
+		"chinese_phone":   "{field}必须是有效的电话号码",
+		"weak_password":   "{field}只能包含字母和数字",
+		"strong_password": "{field}必须是长度至少为{param}的强密码",
+		"passport":        "{field}必须是有效的{param}护照号",
+		"eqfield":         "{field}必须等于{param}",
+		"nefield":         "{field}不能等于{param}",
+		"gtfield":         "{field}必须大于{param}",
+		"gtefield":        "{field}必须大于或等于{param}",
+		"ltfield":         "{field}必须小于{param}",
+		"ltefield":        "{field}必须小于或等于{param}",
+	},
+}
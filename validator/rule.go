@@ -0,0 +1,248 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+
+	// strictAlphaNumericMatcher is used by alphanum rule, unlike IsAlphaNumeric, dash is not allowed.
+	strictAlphaNumericMatcher = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+)
+
+// builtinRules is the rules registered to every Validator.
+var builtinRules = map[string]RuleFunc{
+	"required": func(ctx RuleContext) bool { return !isEmptyValue(ctx.Value) },
+
+	// size rules compare the rune count of string, length of slice, array or map, or value of number.
+	"len": sizeRule(func(size, param float64) bool { return size == param }),
+	"min": sizeRule(func(size, param float64) bool { return size >= param }),
+	"max": sizeRule(func(size, param float64) bool { return size <= param }),
+	"gt":  sizeRule(func(size, param float64) bool { return size > param }),
+	"gte": sizeRule(func(size, param float64) bool { return size >= param }),
+	"lt":  sizeRule(func(size, param float64) bool { return size < param }),
+	"lte": sizeRule(func(size, param float64) bool { return size <= param }),
+
+	"eq": func(ctx RuleContext) bool { return stringValue(ctx.Value) == ctx.Param },
+	"ne": func(ctx RuleContext) bool { return stringValue(ctx.Value) != ctx.Param },
+	"oneof": func(ctx RuleContext) bool {
+		s := stringValue(ctx.Value)
+		for _, item := range strings.Fields(ctx.Param) {
+			if s == item {
+				return true
+			}
+		}
+		return false
+	},
+
+	"contains":   func(ctx RuleContext) bool { return strings.Contains(stringValue(ctx.Value), ctx.Param) },
+	"startswith": func(ctx RuleContext) bool { return strings.HasPrefix(stringValue(ctx.Value), ctx.Param) },
+	"endswith":   func(ctx RuleContext) bool { return strings.HasSuffix(stringValue(ctx.Value), ctx.Param) },
+	"regex":      func(ctx RuleContext) bool { return IsRegexMatch(stringValue(ctx.Value), ctx.Param) },
+
+	"alpha":           stringRule(IsAlpha),
+	"alphanum":        stringRule(strictAlphaNumericMatcher.MatchString),
+	"ascii":           stringRule(IsASCII),
+	"printable":       stringRule(IsPrintable),
+	"upper":           stringRule(IsAllUpper),
+	"lower":           stringRule(IsAllLower),
+	"number":          stringRule(IsNumberStr),
+	"int":             stringRule(IsIntStr),
+	"float":           stringRule(IsFloatStr),
+	"json":            stringRule(IsJSON),
+	"email":           stringRule(IsEmail),
+	"url":             stringRule(IsUrl),
+	"dns":             stringRule(IsDns),
+	"ip":              stringRule(IsIp),
+	"ipv4":            stringRule(IsIpV4),
+	"ipv6":            stringRule(IsIpV6),
+	"port":            stringRule(IsPort),
+	"ip_port":         stringRule(IsIpPort),
+	"base64":          stringRule(IsBase64),
+	"base64url":       stringRule(IsBase64URL),
+	"bin":             stringRule(IsBin),
+	"hex":             stringRule(IsHex),
+	"jwt":             stringRule(IsJWT),
+	"credit_card":     stringRule(IsCreditCard),
+	"visa":            stringRule(IsVisa),
+	"mastercard":      stringRule(IsMasterCard),
+	"amex":            stringRule(IsAmericanExpress),
+	"unionpay":        stringRule(IsUnionPay),
+	"chinese":         stringRule(ContainChinese),
+	"chinese_mobile":  stringRule(IsChineseMobile),
+	"chinese_id":      stringRule(IsChineseIdNum),
+	"chinese_phone":   stringRule(IsChinesePhone),
+	"weak_password":   stringRule(IsWeakPassword),
+	"strong_password": strongPasswordRule,
+	"passport":        func(ctx RuleContext) bool { return IsPassport(stringValue(ctx.Value), ctx.Param) },
+
+	// cross-field rules compare with the field named by param in the same struct.
+	"eqfield":  fieldRule(func(c int) bool { return c == 0 }, false),
+	"nefield":  fieldRule(func(c int) bool { return c != 0 }, true),
+	"gtfield":  fieldRule(func(c int) bool { return c > 0 }, false),
+	"gtefield": fieldRule(func(c int) bool { return c >= 0 }, false),
+	"ltfield":  fieldRule(func(c int) bool { return c < 0 }, false),
+	"ltefield": fieldRule(func(c int) bool { return c <= 0 }, false),
+}
+
+// stringRule converts a string predicate to rule, non-string value is formatted by fmt.Sprint.
+func stringRule(fn func(string) bool) RuleFunc {
+	return func(ctx RuleContext) bool {
+		return fn(stringValue(ctx.Value))
+	}
+}
+
+func strongPasswordRule(ctx RuleContext) bool {
+	length := 8
+	if ctx.Param != "" {
+		n, err := strconv.Atoi(ctx.Param)
+		if err != nil {
+			return false
+		}
+		length = n
+	}
+	return IsStrongPassword(stringValue(ctx.Value), length)
+}
+
+func sizeRule(compare func(size, param float64) bool) RuleFunc {
+	return func(ctx RuleContext) bool {
+		size, ok := sizeOf(ctx.Value)
+		if !ok {
+			return false
+		}
+
+		var param float64
+		var err error
+		if ctx.Value.Type() == durationType {
+			var d time.Duration
+			d, err = time.ParseDuration(ctx.Param)
+			param = float64(d)
+		} else {
+			param, err = strconv.ParseFloat(ctx.Param, 64)
+		}
+		if err != nil {
+			return false
+		}
+
+		return compare(size, param)
+	}
+}
+
+// fieldRule compares value with the field named by param, uncomparable is the result if they can't be compared,
+// the values which can't be compared aren't equal.
+func fieldRule(compare func(c int) bool, uncomparable bool) RuleFunc {
+	return func(ctx RuleContext) bool {
+		if ctx.Parent.Kind() != reflect.Struct {
+			return false
+		}
+		other := ctx.Parent.FieldByName(ctx.Param)
+		for other.Kind() == reflect.Ptr && !other.IsNil() {
+			other = other.Elem()
+		}
+		if !other.IsValid() {
+			return false
+		}
+
+		c, ok := compareValues(ctx.Value, other)
+		if !ok {
+			return uncomparable
+		}
+		return compare(c)
+	}
+}
+
+// sizeOf returns the size of value for size rules.
+func sizeOf(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), true
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return float64(v.Len()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// compareValues compares numbers, strings and time, it returns -1, 0 or 1.
+func compareValues(a, b reflect.Value) (int, bool) {
+	if a.Type() == timeType && b.Type() == timeType {
+		ta, tb := a.Interface().(time.Time), b.Interface().(time.Time)
+		switch {
+		case ta.Before(tb):
+			return -1, true
+		case ta.After(tb):
+			return 1, true
+		}
+		return 0, true
+	}
+
+	if a.Kind() == reflect.String && b.Kind() == reflect.String {
+		return strings.Compare(a.String(), b.String()), true
+	}
+
+	if x, ok := numberOf(a); ok {
+		if y, ok := numberOf(b); ok {
+			switch {
+			case x < y:
+				return -1, true
+			case x > y:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+
+	if a.Type() == b.Type() && a.CanInterface() && b.CanInterface() && reflect.DeepEqual(a.Interface(), b.Interface()) {
+		return 0, true
+	}
+
+	return 0, false
+}
+
+func numberOf(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return sizeOf(v)
+	}
+	return 0, false
+}
+
+func stringValue(v reflect.Value) string {
+	if v.Kind() == reflect.String {
+		return v.String()
+	}
+	if !v.IsValid() || !v.CanInterface() {
+		return ""
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Slice, reflect.Map, reflect.String, reflect.Chan:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	}
+	return v.IsZero()
+}
@@ -0,0 +1,529 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// defaultValidateTag is the default tag of validation rules.
+const defaultValidateTag = "validate"
+
+// RuleContext is the context of field passed to rule function.
+type RuleContext struct {
+	// Value is the field value, pointer is dereferenced.
+	Value reflect.Value
+	// Param is the parameter of rule, eg: "3" of `min=3`.
+	Param string
+	// Parent is the struct which contains the field, it's used by cross-field rules.
+	Parent reflect.Value
+	// Field is the path of field, eg: "Address.City", "Items[0].Name".
+	Field string
+}
+
+// RuleFunc checks if the field value is valid.
+type RuleFunc func(ctx RuleContext) bool
+
+// FieldError is the validation error of a field.
+type FieldError struct {
+	// Field is the path of field, eg: "Address.City", "Items[0].Name".
+	Field string `json:"field"`
+	// Rule is the name of failed rule, eg: "required", "min".
+	Rule string `json:"rule"`
+	// Param is the parameter of failed rule.
+	Param string `json:"param,omitempty"`
+	// Value is the field value.
+	Value any `json:"-"`
+	// Message is the localized error message.
+	Message string `json:"message"`
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+// ValidationErrors is the list of field errors returned by Struct.
+type ValidationErrors []*FieldError
+
+// Error implements the error interface, messages of fields are joined by "; ".
+func (ve ValidationErrors) Error() string {
+	messages := make([]string, len(ve))
+	for i, e := range ve {
+		messages[i] = e.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Map returns the messages keyed by field path, only the first error of each field is kept.
+func (ve ValidationErrors) Map() map[string]string {
+	result := make(map[string]string, len(ve))
+	for _, e := range ve {
+		if _, ok := result[e.Field]; !ok {
+			result[e.Field] = e.Message
+		}
+	}
+	return result
+}
+
+// Validator validates struct fields by tag, eg: `validate:"required,email,min=3,max=64"`.
+//
+// Rules are separated by comma, parameter follows "=" and alternatives are separated by "|", eg: `validate:"email|url"`.
+// Special rules:
+//   - "-": skip the field.
+//   - omitempty: skip the other rules if the field is zero value.
+//   - dive: the rules after dive are applied to elements of slice, array or map.
+//
+// Nested structs and non-nil pointers to struct are validated recursively.
+// Nil pointer is only checked by required rule.
+type Validator struct {
+	mu           sync.RWMutex
+	tagName      string
+	fieldNameTag string
+	locale       string
+	// rules and messages are replaced instead of modified, so they are used by validation without holding lock.
+	rules    map[string]RuleFunc
+	messages map[string]map[string]string
+
+	// cache is the parsed rules of struct types.
+	cache *sync.Map
+}
+
+// NewValidator returns a Validator with builtin rules and messages.
+func NewValidator() *Validator {
+	v := &Validator{
+		tagName:  defaultValidateTag,
+		locale:   defaultLocale,
+		rules:    make(map[string]RuleFunc, len(builtinRules)),
+		messages: make(map[string]map[string]string, len(builtinMessages)),
+		cache:    &sync.Map{},
+	}
+
+	for name, fn := range builtinRules {
+		v.rules[name] = fn
+	}
+	for locale, messages := range builtinMessages {
+		v.RegisterMessages(locale, messages)
+	}
+
+	return v
+}
+
+// SetTagName sets the tag of validation rules, default is `validate`.
+func (v *Validator) SetTagName(tagName string) *Validator {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.tagName = tagName
+	v.cache = &sync.Map{}
+	return v
+}
+
+// SetFieldNameTag sets the tag used to name fields in error, eg: "json". field name is used by default.
+func (v *Validator) SetFieldNameTag(tagName string) *Validator {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.fieldNameTag = tagName
+	v.cache = &sync.Map{}
+	return v
+}
+
+// SetLocale sets the locale of error messages, "en" and "zh" are builtin, default is "en".
+func (v *Validator) SetLocale(locale string) *Validator {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.locale = locale
+	return v
+}
+
+// RegisterRule registers a custom rule, builtin rule with same name is replaced.
+// the message of rule is registered by RegisterMessages.
+func (v *Validator) RegisterRule(name string, fn RuleFunc) error {
+	if name == "" || strings.ContainsAny(name, ",|=") || isSpecialRule(name) {
+		return fmt.Errorf("invalid rule name %q", name)
+	}
+	if fn == nil {
+		return errors.New("rule function is nil")
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	rules := make(map[string]RuleFunc, len(v.rules)+1)
+	for ruleName, ruleFn := range v.rules {
+		rules[ruleName] = ruleFn
+	}
+	rules[name] = fn
+	v.rules = rules
+
+	return nil
+}
+
+// RegisterMessages registers message templates of rules for locale, "{field}" and "{param}" in template
+// are replaced by field path and rule parameter.
+func (v *Validator) RegisterMessages(locale string, messages map[string]string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	localeMessages := make(map[string]string, len(v.messages[locale])+len(messages))
+	for rule, message := range v.messages[locale] {
+		localeMessages[rule] = message
+	}
+	for rule, message := range messages {
+		localeMessages[rule] = message
+	}
+
+	all := make(map[string]map[string]string, len(v.messages)+1)
+	for name, m := range v.messages {
+		all[name] = m
+	}
+	all[locale] = localeMessages
+	v.messages = all
+}
+
+// Struct validates the fields of struct, it returns ValidationErrors if any field is invalid.
+func (v *Validator) Struct(value any) error {
+	state := &validation{ancestors: map[ancestor]bool{}}
+
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return errors.New("validate nil pointer")
+		}
+		state.ancestors[ancestor{rv.Pointer(), rv.Type()}] = true
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("validate non-struct type %T", value)
+	}
+
+	// the lock isn't held when running rules, so the rules may call the methods of validator.
+	v = v.snapshot()
+	if err := v.validateStruct("", rv, state); err != nil {
+		return err
+	}
+	if len(state.errs) > 0 {
+		return state.errs
+	}
+
+	return nil
+}
+
+// snapshot returns a copy of validator settings, the rules and messages are shared since they aren't modified.
+func (v *Validator) snapshot() *Validator {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	return &Validator{
+		tagName:      v.tagName,
+		fieldNameTag: v.fieldNameTag,
+		locale:       v.locale,
+		rules:        v.rules,
+		messages:     v.messages,
+		cache:        v.cache,
+	}
+}
+
+// validation is the state of validating a struct.
+type validation struct {
+	errs ValidationErrors
+	// ancestors is the pointers on the path from root to current value, nested struct of a pointer to
+	// one of them is not validated again, so cyclic pointers don't cause infinite recursion.
+	ancestors map[ancestor]bool
+}
+
+// ancestor identifies a pointer, pointers to a struct and its first field have same address but different types.
+type ancestor struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// fieldRules is the parsed rules of struct field.
+type fieldRules struct {
+	index int
+	name  string
+	rules []rule
+}
+
+// rule is a parsed rule, alternatives are the rules separated by "|".
+type rule struct {
+	name         string
+	param        string
+	alternatives []rule
+}
+
+func (v *Validator) validateStruct(path string, rv reflect.Value, state *validation) error {
+	fields, err := v.structRules(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, f := range fields {
+		fieldPath := f.name
+		if path != "" {
+			fieldPath = path + "." + f.name
+		}
+		if err := v.validateValue(fieldPath, rv.Field(f.index), rv, f.rules, state); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *Validator) validateValue(path string, fv reflect.Value, parent reflect.Value, rules []rule, state *validation) error {
+	value := fv
+	cyclic := false
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			break
+		}
+		if value.Kind() == reflect.Ptr {
+			p := ancestor{value.Pointer(), value.Type()}
+			if state.ancestors[p] {
+				cyclic = true
+			} else {
+				state.ancestors[p] = true
+				defer delete(state.ancestors, p)
+			}
+		}
+		value = value.Elem()
+	}
+	isNil := (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) && value.IsNil()
+
+	for i, r := range rules {
+		switch r.name {
+		case "omitempty":
+			if isNil || value.IsZero() {
+				return nil
+			}
+			continue
+		case "dive":
+			if isNil {
+				return nil
+			}
+			return v.validateElements(path, value, parent, rules[i+1:], state)
+		}
+
+		if isNil && r.name != "required" {
+			return nil
+		}
+
+		// required checks pointer and interface is not nil, instead of the value it points to.
+		if r.name == "required" && (fv.Kind() == reflect.Ptr || fv.Kind() == reflect.Interface) {
+			if isNil {
+				state.errs = append(state.errs, v.fieldError(path, r, fv))
+				return nil
+			}
+			continue
+		}
+
+		if !v.check(r, RuleContext{Value: value, Param: r.param, Parent: parent, Field: path}) {
+			state.errs = append(state.errs, v.fieldError(path, r, fv))
+			return nil
+		}
+	}
+
+	if !isNil && !cyclic {
+		return v.validateNested(path, value, state)
+	}
+
+	return nil
+}
+
+func (v *Validator) validateElements(path string, value reflect.Value, parent reflect.Value, rules []rule, state *validation) error {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := v.validateValue(fmt.Sprintf("%s[%d]", path, i), value.Index(i), parent, rules, state); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			elemPath := fmt.Sprintf("%s[%v]", path, key.Interface())
+			if err := v.validateValue(elemPath, value.MapIndex(key), parent, rules, state); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("dive on non-container field %s", path)
+	}
+	return nil
+}
+
+// validateNested validates nested struct, structs in slice or map are validated only with dive rule.
+func (v *Validator) validateNested(path string, value reflect.Value, state *validation) error {
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+	if fields, err := v.structRules(value.Type()); err != nil || len(fields) == 0 {
+		return err
+	}
+	return v.validateStruct(path, value, state)
+}
+
+func (v *Validator) check(r rule, ctx RuleContext) bool {
+	if len(r.alternatives) > 0 {
+		for _, alt := range r.alternatives {
+			ctx.Param = alt.param
+			if v.check(alt, ctx) {
+				return true
+			}
+		}
+		return false
+	}
+
+	fn := v.rules[r.name]
+	return fn(ctx)
+}
+
+func (v *Validator) fieldError(path string, r rule, value reflect.Value) *FieldError {
+	var fieldValue any
+	if value.IsValid() && value.CanInterface() {
+		fieldValue = value.Interface()
+	}
+
+	return &FieldError{
+		Field:   path,
+		Rule:    r.name,
+		Param:   r.param,
+		Value:   fieldValue,
+		Message: v.message(path, r),
+	}
+}
+
+func (v *Validator) message(path string, r rule) string {
+	template, ok := v.messages[v.locale][r.name]
+	if !ok {
+		template, ok = v.messages[defaultLocale][r.name]
+	}
+	if !ok {
+		template = v.messages[defaultLocale]["default"]
+	}
+
+	return strings.NewReplacer("{field}", path, "{param}", r.param, "{rule}", r.name).Replace(template)
+}
+
+// structRules returns the parsed rules of struct type, fields without rules and nested struct are omitted.
+func (v *Validator) structRules(t reflect.Type) ([]fieldRules, error) {
+	if cached, ok := v.cache.Load(t); ok {
+		return cached.([]fieldRules), nil
+	}
+
+	fields := make([]fieldRules, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag := sf.Tag.Get(v.tagName)
+		if tag == "-" {
+			continue
+		}
+
+		rules, err := v.parseRules(tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s of %v: %w", sf.Name, t, err)
+		}
+		if len(rules) == 0 && !hasNestedStruct(sf.Type) {
+			continue
+		}
+
+		fields = append(fields, fieldRules{index: i, name: v.fieldName(sf), rules: rules})
+	}
+
+	v.cache.Store(t, fields)
+	return fields, nil
+}
+
+func (v *Validator) fieldName(sf reflect.StructField) string {
+	if v.fieldNameTag == "" {
+		return sf.Name
+	}
+	name := strings.Split(sf.Tag.Get(v.fieldNameTag), ",")[0]
+	if name == "" || name == "-" {
+		return sf.Name
+	}
+	return name
+}
+
+func (v *Validator) parseRules(tag string) ([]rule, error) {
+	if strings.TrimSpace(tag) == "" {
+		return nil, nil
+	}
+
+	var rules []rule
+	for _, item := range strings.Split(tag, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		var alternatives []rule
+		for _, alt := range strings.Split(item, "|") {
+			r := rule{name: alt}
+			if idx := strings.Index(alt, "="); idx >= 0 {
+				r.name, r.param = alt[:idx], alt[idx+1:]
+			}
+			if _, ok := v.rules[r.name]; !ok && !isSpecialRule(r.name) {
+				return nil, fmt.Errorf("unknown rule %q", r.name)
+			}
+			alternatives = append(alternatives, r)
+		}
+
+		if len(alternatives) == 1 {
+			rules = append(rules, alternatives[0])
+			continue
+		}
+		for _, alt := range alternatives {
+			if isSpecialRule(alt.name) {
+				return nil, fmt.Errorf("rule %q can not be used in alternatives", alt.name)
+			}
+		}
+		rules = append(rules, rule{name: item, alternatives: alternatives})
+	}
+
+	return rules, nil
+}
+
+func isSpecialRule(name string) bool {
+	return name == "omitempty" || name == "dive"
+}
+
+func hasNestedStruct(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+var defaultValidator = NewValidator()
+
+// ValidateStruct validates struct fields by `validate` tag with the default validator.
+func ValidateStruct(value any) error {
+	return defaultValidator.Struct(value)
+}
+
+// RegisterRule registers a custom rule to the default validator.
+func RegisterRule(name string, fn RuleFunc) error {
+	return defaultValidator.RegisterRule(name, fn)
+}
+
+// RegisterMessages registers message templates of rules for locale to the default validator.
+func RegisterMessages(locale string, messages map[string]string) {
+	defaultValidator.RegisterMessages(locale, messages)
+}
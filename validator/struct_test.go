@@ -0,0 +1,276 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+type testAddress struct {
+	City string `json:"city" validate:"required"`
+	Zip  string `json:"zip" validate:"omitempty,len=6,number"`
+}
+
+type testItem struct {
+	Name  string `json:"name" validate:"required,max=8"`
+	Count int    `json:"count" validate:"gte=1"`
+}
+
+type testUser struct {
+	Name      string            `json:"name" validate:"required,min=3,max=64"`
+	Email     string            `json:"email" validate:"required,email"`
+	Mobile    string            `json:"mobile" validate:"omitempty,chinese_mobile"`
+	Site      string            `json:"site" validate:"omitempty,url|ip"`
+	Age       *int              `json:"age" validate:"omitempty,gte=18,lte=150"`
+	Role      string            `json:"role" validate:"oneof=admin user"`
+	Password  string            `json:"password" validate:"required"`
+	Confirm   string            `json:"confirm" validate:"eqfield=Password"`
+	StartAt   time.Time         `json:"start_at"`
+	EndAt     time.Time         `json:"end_at" validate:"gtfield=StartAt"`
+	Timeout   time.Duration     `json:"timeout" validate:"max=1m"`
+	Address   testAddress       `json:"address"`
+	Backup    *testAddress      `json:"backup"`
+	Items     []testItem        `json:"items" validate:"min=1,dive"`
+	Tags      []string          `json:"tags" validate:"max=3,dive,required,alpha"`
+	Labels    map[string]string `json:"labels" validate:"dive,max=4"`
+	Ignored   string            `validate:"-"`
+	internal_ string
+}
+
+func validUser() *testUser {
+	age := 20
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &testUser{
+		Name:     "Tom",
+		Email:    "tom@example.com",
+		Mobile:   "13263527980",
+		Site:     "127.0.0.1",
+		Age:      &age,
+		Role:     "admin",
+		Password: "secret",
+		Confirm:  "secret",
+		StartAt:  start,
+		EndAt:    start.Add(time.Hour),
+		Timeout:  time.Second,
+		Address:  testAddress{City: "Beijing"},
+		Items:    []testItem{{Name: "apple", Count: 1}},
+		Tags:     []string{"go"},
+		Labels:   map[string]string{"k": "v"},
+	}
+}
+
+func TestValidateStruct(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestValidateStruct")
+
+	assert.IsNil(ValidateStruct(validUser()))
+
+	age := 10
+	u := validUser()
+	u.Name = "To"
+	u.Email = "tom"
+	u.Mobile = "123"
+	u.Site = "not a site"
+	u.Age = &age
+	u.Role = "guest"
+	u.Confirm = "other"
+	u.EndAt = u.StartAt
+	u.Timeout = time.Hour
+	u.Address = testAddress{Zip: "12345a"}
+	u.Backup = &testAddress{}
+	u.Items = []testItem{{Name: "apple"}, {Name: "watermelon", Count: 1}}
+	u.Tags = []string{"go", "", "c++"}
+	u.Labels = map[string]string{"b": "value", "a": "v"}
+
+	err := ValidateStruct(u)
+	assert.IsNotNil(err)
+
+	var errs ValidationErrors
+	assert.Equal(true, errors.As(err, &errs))
+
+	fields := make([]string, len(errs))
+	for i, e := range errs {
+		fields[i] = e.Field + ":" + e.Rule
+	}
+	assert.Equal([]string{
+		"Name:min", "Email:email", "Mobile:chinese_mobile", "Site:url|ip", "Age:gte", "Role:oneof",
+		"Confirm:eqfield", "EndAt:gtfield", "Timeout:max", "Address.City:required", "Address.Zip:number",
+		"Backup.City:required", "Items[0].Count:gte", "Items[1].Name:max",
+		"Tags[1]:required", "Tags[2]:alpha", "Labels[b]:max",
+	}, fields)
+
+	assert.Equal("Name must be at least 3", errs[0].Message)
+	assert.Equal("Site is invalid", errs[3].Message)
+	assert.Equal(10, *(errs[4].Value.(*int)))
+	assert.Equal("Name must be at least 3", errs.Map()["Name"])
+	assert.Equal(true, strings.HasPrefix(err.Error(), "Name must be at least 3; Email must be"))
+}
+
+func TestValidator_Required(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestValidator_Required")
+
+	type Input struct {
+		Name  string         `validate:"required"`
+		Ptr   *int           `validate:"required"`
+		List  []int          `validate:"required"`
+		Map   map[string]int `validate:"required"`
+		Any   any            `validate:"required"`
+		Count int            `validate:"required"`
+	}
+
+	err := ValidateStruct(Input{})
+	errs := err.(ValidationErrors)
+	assert.Equal(6, len(errs))
+
+	zero := 0
+	err = ValidateStruct(&Input{Name: "a", Ptr: &zero, List: []int{0}, Map: map[string]int{"a": 0}, Any: 0, Count: 1})
+	assert.IsNil(err)
+}
+
+type cyclicNode struct {
+	Name     string        `validate:"required,alphanum"`
+	Parent   *cyclicNode   `validate:"omitempty"`
+	Children []*cyclicNode `validate:"dive"`
+}
+
+func TestValidator_Cycle(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestValidator_Cycle")
+
+	root := &cyclicNode{Name: "root"}
+	child := &cyclicNode{Name: "child-1", Parent: root}
+	root.Children = []*cyclicNode{child, child}
+	root.Parent = root
+
+	err := ValidateStruct(root)
+	errs, ok := err.(ValidationErrors)
+	assert.Equal(true, ok)
+
+	// the shared child is validated in every path.
+	assert.Equal(2, len(errs))
+	assert.Equal("Children[0].Name", errs[0].Field)
+	assert.Equal("Children[1].Name", errs[1].Field)
+	assert.Equal("Children[0].Name must contain only letters and numbers", errs[0].Message)
+}
+
+func TestValidator_CustomRule(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestValidator_CustomRule")
+
+	v := NewValidator()
+	err := v.RegisterRule("even", func(ctx RuleContext) bool {
+		return ctx.Value.Kind() == reflect.Int && ctx.Value.Int()%2 == 0
+	})
+	assert.IsNil(err)
+	v.RegisterMessages("en", map[string]string{"even": "{field} must be even"})
+	v.RegisterMessages("zh", map[string]string{"even": "{field}必须是偶数"})
+
+	type Input struct {
+		Number int    `json:"number" validate:"even"`
+		Name   string `json:"name" validate:"required"`
+	}
+
+	err = v.Struct(Input{Number: 1})
+	assert.Equal("Number must be even; Name is required", err.Error())
+
+	v.SetLocale("zh").SetFieldNameTag("json")
+	err = v.Struct(Input{Number: 1})
+	assert.Equal("number必须是偶数; name为必填字段", err.Error())
+
+	// fallback to default locale.
+	v.SetLocale("fr")
+	err = v.Struct(Input{Number: 2})
+	assert.Equal("name is required", err.Error())
+
+	v.SetTagName("check")
+	assert.IsNil(v.Struct(Input{Number: 1}))
+
+	assert.IsNotNil(v.RegisterRule("", func(RuleContext) bool { return true }))
+	assert.IsNotNil(v.RegisterRule("a,b", func(RuleContext) bool { return true }))
+	assert.IsNotNil(v.RegisterRule("dive", func(RuleContext) bool { return true }))
+	assert.IsNotNil(v.RegisterRule("nil", nil))
+}
+
+func TestValidator_ReentrantRule(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestValidator_ReentrantRule")
+
+	// the rule may call the methods of validator.
+	v := NewValidator()
+	err := v.RegisterRule("lazy", func(ctx RuleContext) bool {
+		assert.IsNil(v.RegisterRule("registered", func(RuleContext) bool { return true }))
+		return ctx.Value.String() != ""
+	})
+	assert.IsNil(err)
+
+	type Input struct {
+		Name string `validate:"lazy"`
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- v.Struct(Input{Name: "lancet"})
+	}()
+
+	select {
+	case err := <-done:
+		assert.IsNil(err)
+	case <-time.After(time.Second):
+		t.Fatal("validation is blocked")
+	}
+
+	type Registered struct {
+		Name string `validate:"registered"`
+	}
+	assert.IsNil(v.Struct(Registered{}))
+}
+
+func TestValidator_NeField(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestValidator_NeField")
+
+	type Input struct {
+		Old []string
+		New []string `validate:"nefield=Old"`
+	}
+
+	assert.IsNil(ValidateStruct(Input{Old: []string{"a"}, New: []string{"b"}}))
+
+	err := ValidateStruct(Input{Old: []string{"a"}, New: []string{"a"}})
+	assert.IsNotNil(err)
+	assert.Equal("New:nefield", err.(ValidationErrors)[0].Field+":"+err.(ValidationErrors)[0].Rule)
+}
+
+func TestValidator_InvalidInput(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestValidator_InvalidInput")
+
+	var nilUser *testUser
+	assert.IsNotNil(ValidateStruct(nilUser))
+	assert.IsNotNil(ValidateStruct("abc"))
+
+	type UnknownRule struct {
+		Name string `validate:"unknown"`
+	}
+	err := ValidateStruct(UnknownRule{})
+	assert.IsNotNil(err)
+	_, ok := err.(ValidationErrors)
+	assert.Equal(false, ok)
+
+	type InvalidDive struct {
+		Name string `validate:"dive,required"`
+	}
+	assert.IsNotNil(ValidateStruct(InvalidDive{Name: "a"}))
+}
@@ -0,0 +1,317 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package xerror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Category classifies errors, it decides the default http status, grpc code and retryable of error codes.
+type Category string
+
+const (
+	CategoryUnknown            Category = "unknown"
+	CategoryCanceled           Category = "canceled"
+	CategoryInvalidArgument    Category = "invalid_argument"
+	CategoryNotFound           Category = "not_found"
+	CategoryAlreadyExists      Category = "already_exists"
+	CategoryPermissionDenied   Category = "permission_denied"
+	CategoryUnauthenticated    Category = "unauthenticated"
+	CategoryResourceExhausted  Category = "resource_exhausted"
+	CategoryFailedPrecondition Category = "failed_precondition"
+	CategoryConflict           Category = "conflict"
+	CategoryTimeout            Category = "timeout"
+	CategoryUnavailable        Category = "unavailable"
+	CategoryUnimplemented      Category = "unimplemented"
+	CategoryInternal           Category = "internal"
+)
+
+type categoryInfo struct {
+	httpStatus int
+	grpcCode   uint32
+	retryable  bool
+}
+
+// grpc codes are the values of google.golang.org/grpc/codes.
+var categories = map[Category]categoryInfo{
+	CategoryUnknown:            {http.StatusInternalServerError, 2, false},
+	CategoryCanceled:           {499, 1, false},
+	CategoryInvalidArgument:    {http.StatusBadRequest, 3, false},
+	CategoryNotFound:           {http.StatusNotFound, 5, false},
+	CategoryAlreadyExists:      {http.StatusConflict, 6, false},
+	CategoryPermissionDenied:   {http.StatusForbidden, 7, false},
+	CategoryUnauthenticated:    {http.StatusUnauthorized, 16, false},
+	CategoryResourceExhausted:  {http.StatusTooManyRequests, 8, true},
+	CategoryFailedPrecondition: {http.StatusBadRequest, 9, false},
+	CategoryConflict:           {http.StatusConflict, 10, true},
+	CategoryTimeout:            {http.StatusGatewayTimeout, 4, true},
+	CategoryUnavailable:        {http.StatusServiceUnavailable, 14, true},
+	CategoryUnimplemented:      {http.StatusNotImplemented, 12, false},
+	CategoryInternal:           {http.StatusInternalServerError, 13, false},
+}
+
+func (c Category) info() categoryInfo {
+	if info, ok := categories[c]; ok {
+		return info
+	}
+	return categories[CategoryUnknown]
+}
+
+// HTTPStatus returns the default http status code of category.
+func (c Category) HTTPStatus() int {
+	return c.info().httpStatus
+}
+
+// GRPCCode returns the default grpc status code of category.
+func (c Category) GRPCCode() uint32 {
+	return c.info().grpcCode
+}
+
+// Retryable checks if errors of category are retryable by default.
+func (c Category) Retryable() bool {
+	return c.info().retryable
+}
+
+// ErrorCode describes a registered error code.
+type ErrorCode struct {
+	// Code is the unique identifier of error code, eg: "USER_NOT_FOUND".
+	Code string `json:"code"`
+	// Message is the default message of errors created by ErrorCode.New.
+	Message  string   `json:"message"`
+	Category Category `json:"category"`
+	// HTTPStatus is the http status code, if it's 0, the default status of category is used.
+	HTTPStatus int `json:"httpStatus"`
+	// GRPCCode is the grpc status code, if it's 0, the default code of category is used.
+	GRPCCode uint32 `json:"grpcCode"`
+	// Retryable marks errors of the code retryable, errors of retryable category are always retryable.
+	Retryable bool `json:"retryable"`
+}
+
+// New creates a new XError with the code, if format is empty, the default message of code is used.
+func (c ErrorCode) New(format string, args ...any) *XError {
+	err := newXError()
+	err.code = c.Code
+	if format == "" {
+		err.message = c.Message
+	} else {
+		err.message = fmt.Sprintf(format, args...)
+	}
+	return err
+}
+
+// Wrap creates a new XError with the code and add message, if message is empty, the default message of code is used.
+func (c ErrorCode) Wrap(cause error, message ...any) *XError {
+	err := newXError()
+	err.code = c.Code
+	err.cause = cause
+
+	if len(message) > 0 {
+		newMsgs := make([]string, 0, len(message))
+		for _, m := range message {
+			newMsgs = append(newMsgs, fmt.Sprintf("%v", m))
+		}
+		err.message = strings.Join(newMsgs, " ")
+	} else {
+		err.message = c.Message
+	}
+
+	return err
+}
+
+// Is checks if there is an XError with the code in err's tree.
+func (c ErrorCode) Is(err error) bool {
+	return Is(err, c.Code)
+}
+
+var codeRegistry = struct {
+	sync.RWMutex
+	codes map[string]ErrorCode
+}{codes: make(map[string]ErrorCode)}
+
+// RegisterCode registers an error code, the zero HTTPStatus, GRPCCode and empty Category are filled with defaults.
+// it returns error if code is empty or already registered.
+func RegisterCode(code ErrorCode) (ErrorCode, error) {
+	if code.Code == "" {
+		return ErrorCode{}, errors.New("xerror: error code is empty")
+	}
+
+	if code.Category == "" {
+		code.Category = CategoryUnknown
+	}
+	if code.HTTPStatus == 0 {
+		code.HTTPStatus = code.Category.HTTPStatus()
+	}
+	if code.GRPCCode == 0 {
+		code.GRPCCode = code.Category.GRPCCode()
+	}
+	code.Retryable = code.Retryable || code.Category.Retryable()
+
+	codeRegistry.Lock()
+	defer codeRegistry.Unlock()
+
+	if _, ok := codeRegistry.codes[code.Code]; ok {
+		return ErrorCode{}, fmt.Errorf("xerror: error code %s is already registered", code.Code)
+	}
+	codeRegistry.codes[code.Code] = code
+
+	return code, nil
+}
+
+// MustRegisterCode is like RegisterCode but panics if the code can not be registered.
+// It's intended to initialize package level error codes.
+func MustRegisterCode(code ErrorCode) ErrorCode {
+	result, err := RegisterCode(code)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// LookupCode returns the registered error code.
+func LookupCode(code string) (ErrorCode, bool) {
+	codeRegistry.RLock()
+	defer codeRegistry.RUnlock()
+
+	result, ok := codeRegistry.codes[code]
+	return result, ok
+}
+
+// RegisteredCodes returns all registered error codes sorted by code.
+func RegisteredCodes() []ErrorCode {
+	codeRegistry.RLock()
+	defer codeRegistry.RUnlock()
+
+	result := make([]ErrorCode, 0, len(codeRegistry.codes))
+	for _, code := range codeRegistry.codes {
+		result = append(result, code)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Code < result[j].Code
+	})
+
+	return result
+}
+
+// WithCode sets error code of XError.
+func (e *XError) WithCode(code string) *XError {
+	e.code = code
+	return e
+}
+
+// Code returns error code of XError, if it's not set, returns the code of wrapped XError.
+func (e *XError) Code() string {
+	if e.code != "" {
+		return e.code
+	}
+	if cause := Unwrap(e.cause); cause != nil {
+		return cause.Code()
+	}
+	return ""
+}
+
+// codeTarget is used as target of errors.Is to match XError by code.
+type codeTarget string
+
+func (t codeTarget) Error() string {
+	return string(t)
+}
+
+// Is checks if there is an XError with the code in err's tree.
+func Is(err error, code string) bool {
+	if code == "" {
+		return false
+	}
+	return errors.Is(err, codeTarget(code))
+}
+
+// As finds the first XError with the code in err's tree.
+func As(err error, code string) (*XError, bool) {
+	if code == "" {
+		return nil, false
+	}
+
+	result := find(err, func(e *XError) bool {
+		return e.code == code
+	})
+
+	return result, result != nil
+}
+
+// CodeOf returns the first error code found in err's tree, returns empty string if there is no code.
+func CodeOf(err error) string {
+	result := find(err, func(e *XError) bool {
+		return e.code != ""
+	})
+	if result == nil {
+		return ""
+	}
+	return result.code
+}
+
+// CategoryOf returns the category of err's error code, returns CategoryUnknown if the code is not registered.
+func CategoryOf(err error) Category {
+	if code, ok := LookupCode(CodeOf(err)); ok {
+		return code.Category
+	}
+	return CategoryUnknown
+}
+
+// IsRetryable checks if err's error code is retryable.
+func IsRetryable(err error) bool {
+	if code, ok := LookupCode(CodeOf(err)); ok {
+		return code.Retryable
+	}
+	return false
+}
+
+// HTTPStatus returns the http status code of err, returns 200 if err is nil and 500 if the code is not registered.
+func HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	if code, ok := LookupCode(CodeOf(err)); ok {
+		return code.HTTPStatus
+	}
+	return CategoryUnknown.HTTPStatus()
+}
+
+// GRPCCode returns the grpc status code of err, returns 0 (OK) if err is nil and 2 (Unknown) if the code is not registered.
+func GRPCCode(err error) uint32 {
+	if err == nil {
+		return 0
+	}
+	if code, ok := LookupCode(CodeOf(err)); ok {
+		return code.GRPCCode
+	}
+	return CategoryUnknown.GRPCCode()
+}
+
+// find walks err's tree in depth-first order and returns the first XError matched.
+func find(err error, match func(e *XError) bool) *XError {
+	if err == nil {
+		return nil
+	}
+
+	if e, ok := err.(*XError); ok && match(e) {
+		return e
+	}
+
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		return find(x.Unwrap(), match)
+	case interface{ Unwrap() []error }:
+		for _, e := range x.Unwrap() {
+			if result := find(e, match); result != nil {
+				return result
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package xerror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+var (
+	errCodeUserNotFound = MustRegisterCode(ErrorCode{
+		Code:     "TEST_USER_NOT_FOUND",
+		Message:  "user not found",
+		Category: CategoryNotFound,
+	})
+	errCodeDBUnavailable = MustRegisterCode(ErrorCode{
+		Code:       "TEST_DB_UNAVAILABLE",
+		Message:    "database is unavailable",
+		Category:   CategoryUnavailable,
+		HTTPStatus: http.StatusBadGateway,
+	})
+)
+
+func TestRegisterCode(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRegisterCode")
+
+	assert.Equal(http.StatusNotFound, errCodeUserNotFound.HTTPStatus)
+	assert.Equal(uint32(5), errCodeUserNotFound.GRPCCode)
+	assert.Equal(false, errCodeUserNotFound.Retryable)
+
+	assert.Equal(http.StatusBadGateway, errCodeDBUnavailable.HTTPStatus)
+	assert.Equal(uint32(14), errCodeDBUnavailable.GRPCCode)
+	assert.Equal(true, errCodeDBUnavailable.Retryable)
+
+	_, err := RegisterCode(ErrorCode{Code: "TEST_USER_NOT_FOUND"})
+	assert.IsNotNil(err)
+
+	_, err = RegisterCode(ErrorCode{})
+	assert.IsNotNil(err)
+
+	code, ok := LookupCode("TEST_USER_NOT_FOUND")
+	assert.Equal(true, ok)
+	assert.Equal(errCodeUserNotFound, code)
+
+	_, ok = LookupCode("TEST_NOT_EXIST")
+	assert.Equal(false, ok)
+
+	codes := RegisteredCodes()
+	assert.Equal(true, len(codes) >= 2)
+
+	defer func() {
+		assert.IsNotNil(recover())
+	}()
+	MustRegisterCode(ErrorCode{Code: "TEST_USER_NOT_FOUND"})
+}
+
+func TestErrorCode_New_Wrap(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestErrorCode_New_Wrap")
+
+	err := errCodeUserNotFound.New("")
+	assert.Equal("user not found", err.Error())
+	assert.Equal("TEST_USER_NOT_FOUND", err.Code())
+
+	err = errCodeUserNotFound.New("user %d not found", 1)
+	assert.Equal("user 1 not found", err.Error())
+
+	err = errCodeDBUnavailable.Wrap(errors.New("connection refused"))
+	assert.Equal("database is unavailable: connection refused", err.Error())
+
+	err = errCodeDBUnavailable.Wrap(errors.New("connection refused"), "query", "failed")
+	assert.Equal("query failed: connection refused", err.Error())
+
+	wrapped := Wrap(errCodeUserNotFound.New(""), "get user")
+	assert.Equal("TEST_USER_NOT_FOUND", wrapped.Code())
+	assert.Equal("", New("foo").Code())
+	assert.Equal("FOO", New("foo").WithCode("FOO").Code())
+}
+
+func TestIs_As(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestIs_As")
+
+	inner := errCodeUserNotFound.New("")
+	err := fmt.Errorf("handler: %w", Wrap(inner, "service"))
+
+	assert.Equal(true, Is(err, "TEST_USER_NOT_FOUND"))
+	assert.Equal(true, errCodeUserNotFound.Is(err))
+	assert.Equal(false, Is(err, "TEST_DB_UNAVAILABLE"))
+	assert.Equal(false, Is(err, ""))
+	assert.Equal(false, Is(nil, "TEST_USER_NOT_FOUND"))
+
+	xerr, ok := As(err, "TEST_USER_NOT_FOUND")
+	assert.Equal(true, ok)
+	assert.Equal(inner, xerr)
+
+	_, ok = As(err, "TEST_DB_UNAVAILABLE")
+	assert.Equal(false, ok)
+
+	joined := internal.JoinError(errors.New("foo"), errCodeDBUnavailable.New(""))
+	assert.Equal(true, Is(joined, "TEST_DB_UNAVAILABLE"))
+	_, ok = As(joined, "TEST_DB_UNAVAILABLE")
+	assert.Equal(true, ok)
+	assert.Equal("TEST_DB_UNAVAILABLE", CodeOf(joined))
+}
+
+func TestClassification(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestClassification")
+
+	notFound := Wrap(errCodeUserNotFound.New(""), "get user")
+	unavailable := fmt.Errorf("query: %w", errCodeDBUnavailable.New(""))
+	unknown := errors.New("foo")
+
+	assert.Equal(CategoryNotFound, CategoryOf(notFound))
+	assert.Equal(CategoryUnavailable, CategoryOf(unavailable))
+	assert.Equal(CategoryUnknown, CategoryOf(unknown))
+
+	assert.Equal(false, IsRetryable(notFound))
+	assert.Equal(true, IsRetryable(unavailable))
+	assert.Equal(false, IsRetryable(unknown))
+
+	assert.Equal(http.StatusNotFound, HTTPStatus(notFound))
+	assert.Equal(http.StatusBadGateway, HTTPStatus(unavailable))
+	assert.Equal(http.StatusInternalServerError, HTTPStatus(unknown))
+	assert.Equal(http.StatusOK, HTTPStatus(nil))
+
+	assert.Equal(uint32(5), GRPCCode(notFound))
+	assert.Equal(uint32(2), GRPCCode(unknown))
+	assert.Equal(uint32(0), GRPCCode(nil))
+
+	assert.Equal(http.StatusInternalServerError, Category("not_exist").HTTPStatus())
+}
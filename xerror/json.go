@@ -0,0 +1,115 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package xerror
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// maxJSONStackDepth is the max number of stack frames in json representation of XError.
+const maxJSONStackDepth = 10
+
+// jsonError is the json representation of XError.
+type jsonError struct {
+	Id      string         `json:"id,omitempty"`
+	Code    string         `json:"code,omitempty"`
+	Message string         `json:"message"`
+	Values  map[string]any `json:"values,omitempty"`
+	Stack   []*Stack       `json:"stack,omitempty"`
+	Cause   *jsonError     `json:"cause,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// the stack trace is trimmed to the top frames without runtime functions,
+// a cause which is not XError is encoded with its message only.
+func (e *XError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.toJSON())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, it rebuilds XError from its json representation.
+// note: values are decoded by encoding/json, so numbers become float64.
+func (e *XError) UnmarshalJSON(data []byte) error {
+	var je jsonError
+	if err := json.Unmarshal(data, &je); err != nil {
+		return err
+	}
+
+	*e = *fromJSON(&je)
+	return nil
+}
+
+// Decode rebuilds an XError from json encoded by XError.MarshalJSON.
+func Decode(data []byte) (*XError, error) {
+	e := &XError{}
+	if err := e.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *XError) toJSON() *jsonError {
+	je := &jsonError{
+		Id:      e.id,
+		Code:    e.code,
+		Message: e.message,
+		Stack:   e.trimmedStacks(),
+	}
+
+	if len(e.values) > 0 {
+		je.Values = make(map[string]any, len(e.values))
+		for k, v := range e.values {
+			je.Values[k] = v
+		}
+	}
+
+	if e.cause != nil {
+		if cause, ok := e.cause.(*XError); ok {
+			je.Cause = cause.toJSON()
+		} else {
+			je.Cause = &jsonError{Message: e.cause.Error()}
+		}
+	}
+
+	return je
+}
+
+func fromJSON(je *jsonError) *XError {
+	e := &XError{
+		id:           je.Id,
+		code:         je.Code,
+		message:      je.Message,
+		stack:        &stack{},
+		values:       make(map[string]any, len(je.Values)),
+		remoteStacks: je.Stack,
+	}
+
+	for k, v := range je.Values {
+		e.values[k] = v
+	}
+
+	if je.Cause != nil {
+		e.cause = fromJSON(je.Cause)
+	}
+
+	return e
+}
+
+// trimmedStacks returns the top stack frames without runtime functions.
+func (e *XError) trimmedStacks() []*Stack {
+	stacks := e.Stacks()
+
+	result := make([]*Stack, 0, maxJSONStackDepth)
+	for _, s := range stacks {
+		if len(result) == maxJSONStackDepth {
+			break
+		}
+		if strings.HasPrefix(s.Func, "runtime.") {
+			continue
+		}
+		result = append(result, s)
+	}
+
+	return result
+}
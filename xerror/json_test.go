@@ -0,0 +1,65 @@
+package xerror
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestXError_JSON(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestXError_JSON")
+
+	inner := errCodeUserNotFound.Wrap(errors.New("no rows")).With("userId", 1)
+	err := Wrap(inner, "get user").Id("request-1").With("path", "/users/1")
+
+	data, e := json.Marshal(err)
+	assert.IsNil(e)
+
+	var je jsonError
+	assert.IsNil(json.Unmarshal(data, &je))
+	assert.Equal("request-1", je.Id)
+	assert.Equal("get user", je.Message)
+	assert.Equal(map[string]any{"path": "/users/1"}, je.Values)
+	assert.Equal("TEST_USER_NOT_FOUND", je.Cause.Code)
+	assert.Equal("no rows", je.Cause.Cause.Message)
+	assert.Equal(true, len(je.Stack) > 0 && len(je.Stack) <= maxJSONStackDepth)
+	for _, s := range je.Stack {
+		assert.Equal(false, strings.HasPrefix(s.Func, "runtime."))
+	}
+
+	decoded, e := Decode(data)
+	assert.IsNil(e)
+	assert.Equal(err.Error(), decoded.Error())
+	assert.Equal("TEST_USER_NOT_FOUND", decoded.Code())
+	assert.Equal(true, Is(decoded, "TEST_USER_NOT_FOUND"))
+	assert.Equal(true, errors.Is(decoded, err))
+	assert.Equal(map[string]any{"path": "/users/1", "userId": float64(1)}, decoded.Values())
+	assert.Equal(je.Stack[0].Func, decoded.Stacks()[0].Func)
+	assert.Equal(0, len(decoded.StackTrace()))
+
+	_, e = Decode([]byte("{"))
+	assert.IsNotNil(e)
+}
+
+func TestXError_JSON_Field(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestXError_JSON_Field")
+
+	type response struct {
+		Error *XError `json:"error"`
+	}
+
+	data, err := json.Marshal(response{Error: errCodeDBUnavailable.New("")})
+	assert.IsNil(err)
+
+	var resp response
+	assert.IsNil(json.Unmarshal(data, &resp))
+	assert.Equal("database is unavailable", resp.Error.Error())
+	assert.Equal(true, IsRetryable(resp.Error))
+}
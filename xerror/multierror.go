@@ -0,0 +1,187 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package xerror
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// MultiError aggregates multiple errors into one, it's safe for concurrent use.
+// the stack trace of each error is kept, for error which is not XError, it's the stack where it's appended.
+// errors.Is and errors.As check every aggregated error.
+type MultiError struct {
+	mu      sync.RWMutex
+	entries []multiErrorEntry
+}
+
+type multiErrorEntry struct {
+	err   error
+	stack *stack
+}
+
+// NewMultiError creates a MultiError with errs, nil errors are discarded.
+func NewMultiError(errs ...error) *MultiError {
+	m := &MultiError{}
+	m.append(errs)
+	return m
+}
+
+// Append adds errs to MultiError and returns itself, nil errors are discarded.
+// errors of MultiError are flattened, and error which is already aggregated is skipped, errors are the same
+// if they are equal, or they are XError with the same id. distinct errors wrapping the same cause are all kept.
+func (m *MultiError) Append(errs ...error) *MultiError {
+	m.append(errs)
+	return m
+}
+
+func (m *MultiError) append(errs []error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		if other, ok := err.(*MultiError); ok {
+			if other == m {
+				continue
+			}
+			other.mu.RLock()
+			entries := append([]multiErrorEntry{}, other.entries...)
+			other.mu.RUnlock()
+
+			for _, entry := range entries {
+				m.add(entry)
+			}
+			continue
+		}
+
+		entry := multiErrorEntry{err: err}
+		if _, ok := err.(*XError); !ok {
+			entry.stack = callers()
+		}
+		m.add(entry)
+	}
+}
+
+// add should be called with lock held.
+func (m *MultiError) add(entry multiErrorEntry) {
+	for _, e := range m.entries {
+		if sameError(e.err, entry.err) {
+			return
+		}
+	}
+	m.entries = append(m.entries, entry)
+}
+
+// sameError checks if a and b are the same error, or XError with the same id.
+func sameError(a, b error) bool {
+	typ := reflect.TypeOf(a)
+	if typ == reflect.TypeOf(b) && typ.Comparable() && a == b {
+		return true
+	}
+
+	xa, ok := a.(*XError)
+	if !ok {
+		return false
+	}
+	xb, ok := b.(*XError)
+
+	return ok && xa.id != "" && xa.id == xb.id
+}
+
+// Errors returns all aggregated errors.
+func (m *MultiError) Errors() []error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	errs := make([]error, len(m.entries))
+	for i, entry := range m.entries {
+		errs[i] = entry.err
+	}
+	return errs
+}
+
+// Stacks returns stack trace of each aggregated error, in the same order as Errors.
+func (m *MultiError) Stacks() [][]*Stack {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([][]*Stack, len(m.entries))
+	for i, entry := range m.entries {
+		if xerr, ok := entry.err.(*XError); ok {
+			result[i] = xerr.Stacks()
+		} else {
+			result[i] = (&XError{stack: entry.stack}).Stacks()
+		}
+	}
+	return result
+}
+
+// Len returns the number of aggregated errors.
+func (m *MultiError) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.entries)
+}
+
+// ErrorOrNil returns nil if there is no aggregated error, otherwise returns the MultiError itself.
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil || m.Len() == 0 {
+		return nil
+	}
+	return m
+}
+
+// Error implements standard error interface, messages of errors are joined with newline.
+func (m *MultiError) Error() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	msgs := make([]string, len(m.entries))
+	for i, entry := range m.entries {
+		msgs[i] = entry.err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns all aggregated errors, so errors.Is and errors.As can check them.
+func (m *MultiError) Unwrap() []error {
+	return m.Errors()
+}
+
+// Format returns:
+// - %v, %s, %q: formatted message
+// - %+v: formatted message of each error with its stack trace
+func (m *MultiError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			m.mu.RLock()
+			defer m.mu.RUnlock()
+
+			fmt.Fprintf(s, "%d errors occurred:", len(m.entries))
+			for i, entry := range m.entries {
+				fmt.Fprintf(s, "\n#%d %s", i+1, entry.err.Error())
+				if xerr, ok := entry.err.(*XError); ok {
+					xerr.stack.Format(s, verb)
+				} else {
+					entry.stack.Format(s, verb)
+				}
+			}
+			return
+		}
+		fallthrough
+	case 's':
+		_, _ = io.WriteString(s, m.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", m.Error())
+	}
+}
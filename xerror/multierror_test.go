@@ -0,0 +1,114 @@
+package xerror
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestMultiError_Append(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestMultiError_Append")
+
+	m := NewMultiError()
+	assert.IsNil(m.ErrorOrNil())
+
+	errFoo := errors.New("foo")
+	errBar := New("bar")
+
+	m.Append(errFoo, nil, errBar)
+	assert.Equal(2, m.Len())
+	assert.Equal("foo\nbar", m.Error())
+	assert.Equal([]error{errFoo, errBar}, m.Errors())
+
+	err := m.ErrorOrNil()
+	assert.IsNotNil(err)
+	assert.Equal(true, errors.Is(err, errFoo))
+	assert.Equal(true, errors.Is(err, errBar))
+
+	var xerr *XError
+	assert.Equal(true, errors.As(err, &xerr))
+	assert.Equal(errBar, xerr)
+
+	var nilMultiError *MultiError
+	assert.IsNil(nilMultiError.ErrorOrNil())
+}
+
+func TestMultiError_Dedup(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestMultiError_Dedup")
+
+	errFoo := errors.New("foo")
+	errBar := New("bar")
+
+	m := NewMultiError(errFoo, errBar)
+	m.Append(errFoo, errBar)
+	// errBar.Wrap has the same id as errBar.
+	m.Append(errBar.Wrap(errors.New("baz")))
+	assert.Equal(2, m.Len())
+
+	// distinct errors wrapping the same error are kept.
+	m.Append(fmt.Errorf("read config: %w", errFoo), fmt.Errorf("write config: %w", errFoo))
+	assert.Equal(4, m.Len())
+
+	other := NewMultiError(errFoo, errors.New("qux"))
+	m.Append(other, m)
+	assert.Equal(5, m.Len())
+	assert.Equal("qux", m.Errors()[4].Error())
+
+	// errors of non-comparable type are not compared by ==.
+	m.Append(sliceError{"a"}, sliceError{"a"})
+	assert.Equal(7, m.Len())
+}
+
+func TestMultiError_Stacks(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestMultiError_Stacks")
+
+	m := NewMultiError(errors.New("foo"), New("bar"))
+
+	stacks := m.Stacks()
+	assert.Equal(2, len(stacks))
+	for _, stack := range stacks {
+		assert.Equal(true, len(stack) > 0)
+		assert.Equal(true, strings.HasSuffix(stack[0].Func, "TestMultiError_Stacks"))
+	}
+
+	formatted := fmt.Sprintf("%+v", m)
+	assert.Equal(true, strings.HasPrefix(formatted, "2 errors occurred:\n#1 foo\n"))
+	assert.Equal(true, strings.Contains(formatted, "multierror_test.go"))
+	assert.Equal("foo\nbar", fmt.Sprintf("%v", m))
+}
+
+func TestMultiError_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestMultiError_Concurrent")
+
+	m := NewMultiError()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			m.Append(fmt.Errorf("error %d", i))
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(50, m.Len())
+}
+
+type sliceError []string
+
+func (e sliceError) Error() string {
+	return strings.Join(e, ",")
+}
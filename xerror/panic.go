@@ -0,0 +1,90 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package xerror
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// FromPanic converts the value returned by recover into XError,
+// the stack trace starts at the site where panic happened when it's called in the deferred function.
+// if value is an error, it's the cause of XError with message "panic", so it can be checked by errors.Is and errors.As.
+func FromPanic(value any) *XError {
+	err := newXError()
+	err.stack = panicCallers()
+
+	if cause, ok := value.(error); ok {
+		err.message = "panic"
+		err.cause = cause
+	} else {
+		err.message = fmt.Sprint(value)
+	}
+
+	return err.With("panic", true)
+}
+
+// Recover recovers panic and stores it as XError into errp, it should be called directly by defer.
+// if *errp is not nil, the panic error is appended to it as MultiError.
+func Recover(errp *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	err := FromPanic(r)
+	if errp == nil {
+		return
+	}
+	if *errp != nil {
+		*errp = NewMultiError(*errp, err)
+		return
+	}
+	*errp = err
+}
+
+// SafeCall calls fn and returns panic in it as XError, returns nil if fn does not panic.
+func SafeCall(fn func()) (err error) {
+	defer Recover(&err)
+	fn()
+	return nil
+}
+
+// SafeGo runs fn in a new goroutine, panic in fn is recovered and passed to handler as XError.
+// if handler is nil, the panic is discarded.
+func SafeGo(fn func(), handler func(err error)) {
+	go func() {
+		if err := SafeCall(fn); err != nil && handler != nil {
+			handler(err)
+		}
+	}()
+}
+
+// panicCallers returns the stack trace from the panic site if it's called during panicking,
+// otherwise returns the stack trace of caller.
+func panicCallers() *stack {
+	const depth = 64
+	var pcs [depth]uintptr
+	n := runtime.Callers(2, pcs[:])
+	frames := pcs[0:n]
+
+	for i, pc := range frames {
+		if frame(pc).name() != "runtime.gopanic" {
+			continue
+		}
+
+		// skip runtime frames between gopanic and panic site, eg: runtime.panicmem.
+		j := i + 1
+		for j < len(frames) && strings.HasPrefix(frame(frames[j]).name(), "runtime.") {
+			j++
+		}
+
+		var st stack = frames[j:]
+		return &st
+	}
+
+	var st stack = frames[1:]
+	return &st
+}
@@ -0,0 +1,108 @@
+package xerror
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+	"github.com/duke-git/lancet/v2/slice"
+)
+
+func panicSite() {
+	panic("boom")
+}
+
+func TestRecover(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRecover")
+
+	fn := func() (err error) {
+		defer Recover(&err)
+		panicSite()
+		return nil
+	}
+
+	err := fn()
+	assert.IsNotNil(err)
+	assert.Equal("boom", err.Error())
+
+	xerr := Unwrap(err)
+	assert.Equal(true, xerr.Values()["panic"])
+	assert.Equal(true, strings.HasSuffix(xerr.Stacks()[0].Func, "panicSite"))
+
+	errFoo := errors.New("foo")
+	fn = func() (err error) {
+		defer Recover(&err)
+		err = errFoo
+		panic(errors.New("bar"))
+	}
+
+	err = fn()
+	assert.Equal("foo\npanic: bar", err.Error())
+	assert.Equal(true, errors.Is(err, errFoo))
+}
+
+func TestSafeCall(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSafeCall")
+
+	assert.IsNil(SafeCall(func() {}))
+
+	errFoo := errors.New("foo")
+	err := SafeCall(func() { panic(errFoo) })
+	assert.Equal("panic: foo", err.Error())
+	assert.Equal(true, errors.Is(err, errFoo))
+
+	err = SafeCall(func() {
+		var m map[string]int
+		m["a"] = 1
+	})
+	assert.IsNotNil(err)
+	assert.Equal(true, strings.HasPrefix(Unwrap(err).Stacks()[0].Func, "github.com/duke-git/lancet/v2/xerror.TestSafeCall"))
+}
+
+func TestSafeGo(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSafeGo")
+
+	errCh := make(chan error, 1)
+	SafeGo(panicSite, func(err error) {
+		errCh <- err
+	})
+
+	select {
+	case err := <-errCh:
+		assert.Equal("boom", err.Error())
+	case <-time.After(time.Second):
+		t.Fatal("handler is not called")
+	}
+
+	done := make(chan struct{})
+	SafeGo(func() {
+		defer close(done)
+		panicSite()
+	}, nil)
+	<-done
+}
+
+func TestSafeCall_ForEachConcurrent(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSafeCall_ForEachConcurrent")
+
+	errs := NewMultiError()
+	slice.ForEachConcurrent([]int{1, 2, 3, 4}, func(index int, item int) {
+		errs.Append(SafeCall(func() {
+			if item%2 == 0 {
+				panic(item)
+			}
+		}))
+	}, 2)
+
+	assert.Equal(2, errs.Len())
+}
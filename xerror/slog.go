@@ -0,0 +1,50 @@
+// Copyright 2025 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+//go:build go1.21
+
+package xerror
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+)
+
+// LogValue implements the slog.LogValuer interface, XError is logged as a group of
+// id, code, full message, merged values and the trimmed stack trace.
+func (e *XError) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 6)
+
+	if e.id != "" {
+		attrs = append(attrs, slog.String("id", e.id))
+	}
+	if code := e.Code(); code != "" {
+		attrs = append(attrs, slog.String("code", code))
+	}
+	attrs = append(attrs, slog.String("message", e.Error()))
+
+	if values := e.Values(); len(values) > 0 {
+		keys := make([]string, 0, len(values))
+		for k := range values {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		valueAttrs := make([]any, 0, len(keys))
+		for _, k := range keys {
+			valueAttrs = append(valueAttrs, slog.Any(k, values[k]))
+		}
+		attrs = append(attrs, slog.Group("values", valueAttrs...))
+	}
+
+	if stacks := e.trimmedStacks(); len(stacks) > 0 {
+		frames := make([]string, len(stacks))
+		for i, s := range stacks {
+			frames[i] = fmt.Sprintf("%s %s:%d", s.Func, s.File, s.Line)
+		}
+		attrs = append(attrs, slog.Any("stack", frames))
+	}
+
+	return slog.GroupValue(attrs...)
+}
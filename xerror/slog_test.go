@@ -0,0 +1,35 @@
+//go:build go1.21
+
+package xerror
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestXError_LogValue(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestXError_LogValue")
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	err := errCodeUserNotFound.Wrap(errors.New("no rows")).Id("id-1").With("userId", 1)
+	logger.Error("request failed", "err", err)
+
+	var record map[string]any
+	assert.IsNil(json.Unmarshal(buf.Bytes(), &record))
+
+	logged := record["err"].(map[string]any)
+	assert.Equal("id-1", logged["id"])
+	assert.Equal("TEST_USER_NOT_FOUND", logged["code"])
+	assert.Equal("user not found: no rows", logged["message"])
+	assert.Equal(map[string]any{"userId": float64(1)}, logged["values"])
+	assert.IsNotNil(logged["stack"])
+}
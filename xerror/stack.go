@@ -21,6 +21,10 @@ type Stack struct {
 
 // Stacks returns stack trace array generated by pkg/errors
 func (e *XError) Stacks() []*Stack {
+	if len(*e.stack) == 0 && e.remoteStacks != nil {
+		return e.remoteStacks
+	}
+
 	resp := make([]*Stack, len(*e.stack))
 	for i, st := range *e.stack {
 		f := frame(st)
@@ -16,10 +16,14 @@ import (
 // XError is to handle error related information.
 type XError struct {
 	id      string
+	code    string
 	message string
 	stack   *stack
 	cause   error
 	values  map[string]any
+
+	// remoteStacks is the stack trace decoded from json, it's used when stack is empty.
+	remoteStacks []*Stack
 }
 
 // New creates a new XError with message
@@ -71,6 +75,7 @@ func newXError() *XError {
 func (e *XError) copy(dest *XError) {
 	dest.message = e.message
 	dest.id = e.id
+	dest.code = e.code
 	dest.cause = e.cause
 
 	for k, v := range e.values {
@@ -132,6 +137,10 @@ func (e *XError) With(key string, value any) *XError {
 
 // Is checks if target error is XError and Error.id of two errors are matched.
 func (e *XError) Is(target error) bool {
+	if code, ok := target.(codeTarget); ok {
+		return e.code == string(code)
+	}
+
 	var err *XError
 
 	if errors.As(target, &err) {
@@ -174,6 +183,7 @@ func (e *XError) Values() map[string]any {
 type errInfo struct {
 	Message    string         `json:"message"`
 	Id         string         `json:"id"`
+	Code       string         `json:"code"`
 	StackTrace []*Stack       `json:"stacktrace"`
 	Cause      error          `json:"cause"`
 	Values     map[string]any `json:"values"`
@@ -184,6 +194,7 @@ func (e *XError) Info() *errInfo {
 	errInfo := &errInfo{
 		Message:    e.message,
 		Id:         e.id,
+		Code:       e.code,
 		StackTrace: e.Stacks(),
 		Cause:      e.cause,
 		Values:     make(map[string]any),